@@ -166,3 +166,32 @@ func (ec *Client) WaitForConfirmations(raw types.Log) error {
 		time.Sleep(time.Second * 5)
 	}
 }
+
+// BlockAt returns the number of the first confirmed block, mined at or after the timestamp (in seconds).
+// Returns the number of the block next to the latest confirmed one, if there is no such block
+func (ec *Client) BlockAt(timestamp int64) (uint64, error) {
+	latest, err := ec.BlockNumber(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	if latest < ec.config.BlockConfirmations {
+		return 0, nil
+	}
+	confirmed := latest - ec.config.BlockConfirmations
+
+	// Binary search for the first block in [low; high], which is not older than the timestamp
+	low, high := uint64(0), confirmed+1
+	for low < high {
+		middle := low + (high-low)/2
+		header, err := ec.HeaderByNumber(context.Background(), new(big.Int).SetUint64(middle))
+		if err != nil {
+			return 0, err
+		}
+		if int64(header.Time) < timestamp {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+	return low, nil
+}
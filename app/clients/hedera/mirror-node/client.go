@@ -29,6 +29,9 @@ import (
 	"time"
 )
 
+// transactionsPageSize is the number of transactions, requested per page of the paginated queries
+const transactionsPageSize = 100
+
 type Client struct {
 	mirrorAPIAddress string
 	httpClient       *http.Client
@@ -105,6 +108,35 @@ func (c Client) GetMessagesForTopicBetween(topicId hedera.TopicID, from, to int6
 	return res, nil
 }
 
+// GetScheduledTransactionsAfterTimestamp returns the scheduled transactions, which debit the specified account after the given timestamp
+func (c Client) GetScheduledTransactionsAfterTimestamp(accountId hedera.AccountID, from int64) ([]Transaction, error) {
+	var res []Transaction
+	for {
+		query := fmt.Sprintf("?account.id=%s&type=debit&timestamp=gt:%s&order=asc&transactiontype=cryptotransfer&limit=%d",
+			accountId.String(),
+			timestampHelper.String(from),
+			transactionsPageSize)
+		response, err := c.getTransactionsByQuery(query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range response.Transactions {
+			if t.Scheduled {
+				res = append(res, t)
+			}
+		}
+		if len(response.Transactions) < transactionsPageSize {
+			return res, nil
+		}
+
+		from, err = timestampHelper.FromString(response.Transactions[len(response.Transactions)-1].ConsensusTimestamp)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
 func (c Client) GetTransaction(transactionID string) (*Response, error) {
 	transactionsDownloadQuery := fmt.Sprintf("/%s",
 		transactionID)
@@ -127,6 +159,58 @@ func (c Client) GetStateProof(transactionID string) ([]byte, error) {
 	return readResponseBody(response)
 }
 
+// GetSchedule returns the schedule with the given id (f.e. its memo)
+func (c Client) GetSchedule(scheduleID string) (*Schedule, error) {
+	query := fmt.Sprintf("%s%s/%s", c.mirrorAPIAddress, "schedules", scheduleID)
+
+	response, e := c.get(query)
+	if e != nil {
+		return nil, e
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New(fmt.Sprintf("Schedule HTTP GET for ScheduleID [%s] ended with Status Code [%d].", scheduleID, response.StatusCode))
+	}
+
+	bodyBytes, e := readResponseBody(response)
+	if e != nil {
+		return nil, e
+	}
+
+	var schedule *Schedule
+	e = json.Unmarshal(bodyBytes, &schedule)
+	if e != nil {
+		return nil, e
+	}
+	return schedule, nil
+}
+
+// GetToken returns the token with the given id (f.e. its decimals)
+func (c Client) GetToken(tokenID string) (*Token, error) {
+	query := fmt.Sprintf("%s%s/%s", c.mirrorAPIAddress, "tokens", tokenID)
+
+	response, e := c.get(query)
+	if e != nil {
+		return nil, e
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New(fmt.Sprintf("Token HTTP GET for TokenID [%s] ended with Status Code [%d].", tokenID, response.StatusCode))
+	}
+
+	bodyBytes, e := readResponseBody(response)
+	if e != nil {
+		return nil, e
+	}
+
+	var token *Token
+	e = json.Unmarshal(bodyBytes, &token)
+	if e != nil {
+		return nil, e
+	}
+	return token, nil
+}
+
 func (c Client) AccountExists(accountID hedera.AccountID) bool {
 	mirrorNodeApiTransactionAddress := fmt.Sprintf("%s%s", c.mirrorAPIAddress, "accounts")
 	accountQuery := fmt.Sprintf("%s/%s",
@@ -0,0 +1,26 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mirror_node
+
+// Schedule struct used by the Hedera Mirror node REST API to represent a Schedule
+type Schedule struct {
+	ScheduleID        string `json:"schedule_id"`
+	CreatorAccountID  string `json:"creator_account_id"`
+	PayerAccountID    string `json:"payer_account_id"`
+	Memo              string `json:"memo"`
+	ExecutedTimestamp string `json:"executed_timestamp"`
+}
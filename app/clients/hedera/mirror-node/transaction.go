@@ -95,6 +95,31 @@ func (t Transaction) GetIncomingTransfer(account string) (string, string, error)
 	return amount, asset, err
 }
 
+// GetSender returns the account with the largest debit of the given asset in the transaction
+func (t Transaction) GetSender(asset string) (string, error) {
+	transfers := t.TokenTransfers
+	if asset == constants.Hbar {
+		transfers = t.Transfers
+	}
+
+	var sender string
+	var debit int64
+	for _, tr := range transfers {
+		if asset != constants.Hbar && tr.Token != asset {
+			continue
+		}
+		if tr.Amount < debit {
+			sender = tr.Account
+			debit = tr.Amount
+		}
+	}
+
+	if sender == "" {
+		return "", errors.New("no outgoing transfer found")
+	}
+	return sender, nil
+}
+
 // GetLatestTxnConsensusTime iterates all transactions and returns the consensus timestamp of the latest one
 func (r Response) GetLatestTxnConsensusTime() (int64, error) {
 	var max int64 = 0
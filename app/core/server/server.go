@@ -17,10 +17,14 @@
 package server
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"github.com/go-chi/chi"
 	"github.com/limechain/hedera-eth-bridge-validator/app/core/pair"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	log "github.com/sirupsen/logrus"
+	"io/ioutil"
 	"net/http"
 )
 
@@ -47,3 +51,34 @@ func (s *Server) Run(chi *chi.Mux, port string) {
 	s.logger.Infof("Listening on port [%s]", port)
 	s.logger.Fatal(http.ListenAndServe(port, chi))
 }
+
+// RunAdmin serves the admin API on its own port. Client certificates are required, if a client CA is configured
+func (s *Server) RunAdmin(handler http.Handler, cfg config.Admin) {
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%s", cfg.Port),
+		Handler: handler,
+	}
+
+	if cfg.TLS.ClientCAFile != "" {
+		caCert, err := ioutil.ReadFile(cfg.TLS.ClientCAFile)
+		if err != nil {
+			s.logger.Fatalf("Failed to read admin client CA [%s]. Error: [%s]", cfg.TLS.ClientCAFile, err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caCert) {
+			s.logger.Fatalf("Invalid admin client CA [%s].", cfg.TLS.ClientCAFile)
+		}
+		server.TLSConfig = &tls.Config{
+			ClientCAs:  clientCAs,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+		}
+	}
+
+	go func() {
+		s.logger.Infof("Admin API listening on port [%s]", cfg.Port)
+		if cfg.TLS.CertFile != "" {
+			s.logger.Fatal(server.ListenAndServeTLS(cfg.TLS.CertFile, cfg.TLS.KeyFile))
+		}
+		s.logger.Fatal(server.ListenAndServe())
+	}()
+}
@@ -34,4 +34,7 @@ type Ethereum interface {
 	WaitForTransaction(hex string, onSuccess, onRevert func(), onError func(err error))
 	// WaitForConfirmations starts a loop which ends either when we reach the target block number or an error occurs with block number retrieval
 	WaitForConfirmations(raw types.Log) error
+	// BlockAt returns the number of the first confirmed block, mined at or after the timestamp (in seconds).
+	// Returns the number of the block next to the latest confirmed one, if there is no such block
+	BlockAt(timestamp int64) (uint64, error)
 }
@@ -0,0 +1,34 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+
+type Archive interface {
+	// Returns the reference to the archive file of the record. Returns nil if not archived
+	Get(id string) (*entity.ArchivedRecord, error)
+	// GetArchivableTransfers returns up to `limit` completed transfers, which started before the timestamp (in seconds),
+	// with their fee and signature messages. Transfers with fees, which are not yet paid out, are not archivable
+	GetArchivableTransfers(before int64, limit int) ([]*entity.Transfer, error)
+	// GetArchivableBurnEvents returns up to `limit` completed or failed burn events, which occurred before the
+	// timestamp (in seconds), with their fee. Burn events with fees, which are not yet paid out, are not archivable
+	GetArchivableBurnEvents(before int64, limit int) ([]*entity.BurnEvent, error)
+	// PruneTransfers deletes the transfers with their fees and signature messages and references the archive file, to which they are exported
+	PruneTransfers(ids []string, file string) error
+	// PruneBurnEvents deletes the burn events with their fees and references the archive file, to which they are exported
+	PruneBurnEvents(ids []string, file string) error
+}
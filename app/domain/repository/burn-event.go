@@ -16,13 +16,27 @@
 
 package repository
 
-import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+import (
+	model "github.com/limechain/hedera-eth-bridge-validator/app/model/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+)
 
 type BurnEvent interface {
-	Create(id string, amount int64, recipient string) error
-	UpdateStatusSubmitted(id, scheduleID, transactionId string) error
-	UpdateStatusCompleted(txId string) error
-	UpdateStatusFailed(txId string) error
+	Create(event *model.BurnEvent) error
+	// CreateHeld creates a BurnEvent record, held for manual review because of the violated asset policy limit
+	CreateHeld(event *model.BurnEvent, violation string) error
+	// UpdateStatusSubmitted records the scheduled transaction of an initial, held or failed burn event.
+	// Status updates return ErrIllegalTransition, if they are not allowed from the current status, and
+	// ErrStaleStatus, if the status is changed concurrently. The cause is recorded with the transition
+	UpdateStatusSubmitted(id, scheduleID, transactionId string, cause Cause) error
+	// UpdateStatusCompleted completes a submitted burn event
+	UpdateStatusCompleted(txId string, cause Cause) error
+	// UpdateStatusFailed fails a burn event, which is not completed
+	UpdateStatusFailed(txId string, cause Cause) error
 	// Returns BurnEvent by its Id (represented in {ethTxHash}-{logIndex})
 	Get(txId string) (*entity.BurnEvent, error)
+	// GetUnprocessed returns the BurnEvents, which are initial or submitted
+	GetUnprocessed() ([]*entity.BurnEvent, error)
+	// CountByStatus returns the number of burn events by status
+	CountByStatus() (map[string]int64, error)
 }
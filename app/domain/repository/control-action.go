@@ -0,0 +1,32 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+
+type ControlAction interface {
+	// Create persists the applied control action. Already recorded actions are ignored
+	Create(action *entity.ControlAction) error
+	// GetLatest returns the last applied control action. Returns nil if none was applied
+	GetLatest() (*entity.ControlAction, error)
+	// CreateSignature persists the signature of a member on a control action. Already recorded signatures are ignored
+	CreateSignature(signature *entity.ControlSignature) error
+	// GetSignatures returns the signatures on the control action with the given nonce
+	GetSignatures(nonce int64, action string) ([]entity.ControlSignature, error)
+	// GetSignaturesAfter returns the signatures on control actions, newer than the given nonce, ordered by nonce
+	GetSignaturesAfter(nonce int64) ([]entity.ControlSignature, error)
+}
@@ -0,0 +1,27 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+
+type Equivocation interface {
+	// Create persists the equivocation. Already recorded equivocations are ignored
+	Create(equivocation *entity.Equivocation) error
+	// Get returns the equivocations, ordered by the consensus timestamp of the conflicting message.
+	// Filters by transfer and signer, unless they are empty
+	Get(transferID, signer string) ([]entity.Equivocation, error)
+}
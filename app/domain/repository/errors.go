@@ -0,0 +1,29 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import "errors"
+
+// ErrStaleStatus is returned for status transitions, which do not apply to the current status of the record.
+// The record was either not found or its status was changed concurrently
+var ErrStaleStatus = errors.New("stale-status")
+
+// ErrDuplicate is returned for records, which were already created, f.e. by a concurrent handler
+var ErrDuplicate = errors.New("duplicate")
+
+// ErrIllegalTransition is returned for status transitions, which are not allowed from the current status of the record
+var ErrIllegalTransition = errors.New("illegal-transition")
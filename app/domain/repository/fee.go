@@ -18,10 +18,39 @@ package repository
 
 import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
 
+// FeeBatch identifies the batch of accrued fees of an asset
+type FeeBatch struct {
+	NativeAsset string
+	Batch       int64
+}
+
 type Fee interface {
 	// Returns Fee. Returns nil if not found
 	Get(txId string) (*entity.Fee, error)
 	Create(entity *entity.Fee) error
-	UpdateStatusCompleted(txId string) error
-	UpdateStatusFailed(txId string) error
+	// UpdateStatusCompleted completes a submitted fee.
+	// Status updates return ErrIllegalTransition, if they are not allowed from the current status of any fee, and
+	// ErrStaleStatus, if the status is changed concurrently. The cause is recorded with the transitions
+	UpdateStatusCompleted(txId string, cause Cause) error
+	// UpdateStatusFailed fails a submitted fee
+	UpdateStatusFailed(txId string, cause Cause) error
+	// GetSubmitted returns all fees, which are waiting for the outcome of their or their settlement's scheduled transaction
+	GetSubmitted() ([]*entity.Fee, error)
+	// CountByStatus returns the number of fees by status
+	CountByStatus() (map[string]int64, error)
+	// GetAccruedBatches returns the batches with accrued fees, which started before the given batch, ordered by asset and batch
+	GetAccruedBatches(before int64) ([]FeeBatch, error)
+	// GetAccruedInBatch returns the accrued fees of the asset's batch, ordered by transaction id
+	GetAccruedInBatch(nativeAsset string, batch int64) ([]*entity.Fee, error)
+	// UpdateSettlementSubmitted marks the accrued fees as submitted with the scheduled transaction settling them
+	// and records the settlement of their batch
+	UpdateSettlementSubmitted(txIds []string, settlement *entity.FeeSettlement, cause Cause) error
+	// UpdateSettlementCompleted completes the submitted fees of the settlement
+	UpdateSettlementCompleted(txIds []string, cause Cause) error
+	// UpdateSettlementAccrued returns the submitted fees of a failed settlement to accrued, so that they are settled again
+	UpdateSettlementAccrued(txIds []string, cause Cause) error
+	// GetSettlement returns the settlement with the given id. Returns nil if not found
+	GetSettlement(id string) (*entity.FeeSettlement, error)
+	// UpdateBatch moves the accrued fees to the given batch
+	UpdateBatch(txIds []string, batch int64) error
 }
@@ -21,6 +21,7 @@ import (
 )
 
 type Message interface {
+	// Create persists the message. Returns ErrDuplicate, if the signature was already persisted
 	Create(message *entity.Message) error
 	Exist(transferID, signature, hash string) (bool, error)
 	Get(transferID string) ([]entity.Message, error)
@@ -0,0 +1,28 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+
+type PendingMessage interface {
+	// Create persists the pending message. Already pending messages are ignored
+	Create(message *entity.PendingMessage) error
+	// Pop removes and returns the pending messages of the transfer
+	Pop(transferID string) ([]entity.PendingMessage, error)
+	// DeleteExpired removes and returns the pending messages, which expired before the given timestamp (in seconds)
+	DeleteExpired(now int64) ([]entity.PendingMessage, error)
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+
+type Refund interface {
+	Create(refund *entity.Refund) error
+	// UpdateStatusSubmitted records the scheduled transaction of an initial refund.
+	// Status updates return ErrIllegalTransition, if they are not allowed from the current status, and
+	// ErrStaleStatus, if the status is changed concurrently. The cause is recorded with the transition
+	UpdateStatusSubmitted(transferID, scheduleID, transactionID string, cause Cause) error
+	// UpdateStatusCompleted completes a submitted refund
+	UpdateStatusCompleted(transferID string, cause Cause) error
+	// UpdateStatusFailed fails an initial or submitted refund
+	UpdateStatusFailed(transferID string, cause Cause) error
+	// Get returns the Refund of the given deposit. Returns nil if not found
+	Get(transferID string) (*entity.Refund, error)
+	// GetInitial returns the Refunds, which are not submitted yet
+	GetInitial() ([]*entity.Refund, error)
+}
@@ -0,0 +1,29 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+
+type RejectedMessage interface {
+	Create(message *entity.RejectedMessage) error
+	// Get returns the rejected messages, ordered by consensus timestamp.
+	// Filters by transfer and signer, unless they are empty
+	Get(transferID, signer string) ([]entity.RejectedMessage, error)
+	// GetPage returns up to `limit` rejected messages with consensus timestamp after `after`, ordered by consensus timestamp.
+	// Filters by transfer and signer, unless they are empty
+	GetPage(transferID, signer string, after int64, limit int) ([]entity.RejectedMessage, error)
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+
+// Cause describes the component, which changes the status of a record, and the reason for the change
+type Cause struct {
+	Actor  string
+	Reason string
+}
+
+type StatusTransition interface {
+	// GetForTransfer returns the status transitions of the transfer, its signature, fee and refund, ordered by time
+	GetForTransfer(transferID string) ([]entity.StatusTransition, error)
+	// GetForBurnEvent returns the status transitions of the burn event and its fee, ordered by time
+	GetForBurnEvent(id string) ([]entity.StatusTransition, error)
+}
@@ -28,12 +28,23 @@ type Transfer interface {
 	GetWithFee(txId string) (*entity.Transfer, error)
 	GetWithPreloads(txId string) (*entity.Transfer, error)
 	GetUnprocessedTransfers() ([]*entity.Transfer, error)
+	// CountByStatus returns the number of transfers by status
+	CountByStatus() (map[string]int64, error)
 
-	Create(ct *transfer.Transfer) (*entity.Transfer, error)
-	SaveRecoveredTxn(ct *transfer.Transfer) error
-	UpdateStatusCompleted(txId string) error
+	// Create creates new record of Transfer, snapshotting the eligible signers and the number of required signatures
+	Create(ct *transfer.Transfer, signers []string, requiredSignatures int) (*entity.Transfer, error)
+	SaveRecoveredTxn(ct *transfer.Transfer, signers []string, requiredSignatures int) error
+	// CreateHeld creates new record of Transfer, held for manual review because of the violated asset policy limit
+	CreateHeld(ct *transfer.Transfer, violation string) (*entity.Transfer, error)
+	// CreateRejected creates new record of a rejected deposit
+	CreateRejected(ct *transfer.Transfer) (*entity.Transfer, error)
+	// UpdateStatusCompleted completes a transfer, which is not rejected.
+	// Status updates return ErrIllegalTransition, if they are not allowed from the current status, and
+	// ErrStaleStatus, if the status is changed concurrently. The cause is recorded with the transition
+	UpdateStatusCompleted(txId string, cause Cause) error
 
-	UpdateStatusSignatureSubmitted(txId string) error
-	UpdateStatusSignatureMined(txId string) error
-	UpdateStatusSignatureFailed(txId string) error
+	// UpdateStatusSignatureSubmitted records the submission of the signature. Unfinished transfers are moved to in progress
+	UpdateStatusSignatureSubmitted(txId string, cause Cause) error
+	UpdateStatusSignatureMined(txId string, cause Cause) error
+	UpdateStatusSignatureFailed(txId string, cause Cause) error
 }
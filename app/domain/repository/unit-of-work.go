@@ -0,0 +1,35 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+// UnitOfWork executes multiple repository operations atomically
+type UnitOfWork interface {
+	// Do executes the work in a database transaction. The transaction is committed if the work
+	// returns nil and is rolled back otherwise
+	Do(work func(tx Transaction) error) error
+}
+
+// Transaction provides the repositories, bound to the database transaction of a unit of work
+type Transaction interface {
+	Transfers() Transfer
+	Messages() Message
+	RejectedMessages() RejectedMessage
+	Equivocations() Equivocation
+	Fees() Fee
+	BurnEvents() BurnEvent
+	Refunds() Refund
+}
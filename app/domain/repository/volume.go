@@ -0,0 +1,28 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+
+type Volume interface {
+	// Create persists the volume. Already recorded volumes are ignored
+	Create(volume *entity.Volume) error
+	// Get returns the volume of the given operation. Returns nil if not found
+	Get(id string) (*entity.Volume, error)
+	// GetBetween returns the volumes of the native asset in the direction with timestamp in the interval (from; to]
+	GetBetween(nativeAsset, direction string, from, to int64) ([]entity.Volume, error)
+}
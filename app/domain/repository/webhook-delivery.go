@@ -0,0 +1,29 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+
+// WebhookDelivery is the delivery log of the webhook events
+type WebhookDelivery interface {
+	// Create records a delivery
+	Create(delivery *entity.WebhookDelivery) error
+	// UpdateAttempt records the outcome of the latest attempt of the delivery
+	UpdateAttempt(id uint64, status string, attempts, responseCode int, lastError string) error
+	// GetPending returns the deliveries, which are neither delivered nor failed, ordered by their creation
+	GetPending() ([]*entity.WebhookDelivery, error)
+}
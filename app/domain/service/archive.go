@@ -0,0 +1,32 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+
+// Archive interface is implemented by the Archive Service
+// Provides business logic for exporting terminal records to archive files and looking them up
+type Archive interface {
+	// Start periodically archives the records, older than the retention period
+	Start()
+	// Archive exports the records, older than the retention period, and prunes them from the database
+	Archive() error
+	// Transfer returns the archived transfer with its fee and signature messages. Returns nil if not archived
+	Transfer(id string) (*entity.Transfer, error)
+	// BurnEvent returns the archived burn event with its fee. Returns nil if not archived
+	BurnEvent(id string) (*entity.BurnEvent, error)
+}
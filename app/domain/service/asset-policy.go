@@ -0,0 +1,29 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import "math/big"
+
+// AssetPolicy interface is implemented by the Asset Policy Service
+type AssetPolicy interface {
+	// Check verifies the operation against the limits of its native asset and accounts its amount
+	// in the daily volumes of the asset in the direction. Returns the violated limit or an empty string if the operation is allowed.
+	// The amount is in native asset units and the timestamp of the operation is in seconds
+	Check(id, direction, nativeAsset, receiver string, amount *big.Int, timestamp int64) (violation string, err error)
+	// Preview verifies the operation like Check, without accounting its amount in the daily volumes of the asset
+	Preview(id, direction, nativeAsset, receiver string, amount *big.Int, timestamp int64) (violation string, err error)
+}
@@ -23,7 +23,17 @@ type BurnEvent interface {
 	// ProcessEvent processes the burn event by submitting the appropriate
 	// scheduled transaction, leaving the synchronization of the actual transfer on HCS
 	ProcessEvent(event burn_event.BurnEvent)
+	// HoldEvent stores the burn event, which is outside of the asset policy, as held for manual review
+	HoldEvent(event burn_event.BurnEvent, violation string)
+	// Execute executes again the scheduled transaction of the stored burn event, if it is initial,
+	// held for manual review or failed
+	Execute(id string) error
+	// Reconcile resumes the burn event, left unfinished by a restart. The scheduled transaction of an
+	// initial burn event is executed again, while the outcome of a submitted one is awaited
+	Reconcile(id string) error
 	// TransactionID returns the corresponding Scheduled Transaction paying out the
 	// fees to validators and the amount being bridged to the receiver address
 	TransactionID(id string) (string, error)
+	// Timeline returns the status transitions of the given burn event and its fee, ordered by time
+	Timeline(id string) ([]StatusTransition, error)
 }
@@ -0,0 +1,26 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+// Checkpoints are the timestamps, after which the watchers continue monitoring
+type Checkpoints interface {
+	// Get returns the checkpoints by watcher
+	Get() (map[string]int64, error)
+	// Reset sets the checkpoint of the watcher, from which it continues with its next poll.
+	// Returns ErrNotFound for unknown watchers
+	Reset(watcher string, timestamp int64) error
+}
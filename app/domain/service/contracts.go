@@ -33,8 +33,16 @@ type Contracts interface {
 	IsMember(address string) bool
 	// WatchBurnEventLogs creates a subscription for Burn Events emitted in the Bridge contract
 	WatchBurnEventLogs(opts *bind.WatchOpts, sink chan<- *abi.RouterBurn) (event.Subscription, error)
+	// WatchMintEventLogs creates a subscription for Mint Events emitted in the Bridge contract
+	WatchMintEventLogs(opts *bind.WatchOpts, sink chan<- *abi.RouterMint) (event.Subscription, error)
+	// FilterBurnEventLogs returns the Burn Events emitted in the Bridge contract in the range of blocks
+	FilterBurnEventLogs(opts *bind.FilterOpts) ([]*abi.RouterBurn, error)
 	// Check whether a specific asset has a valid bridge token address. Returns the erc20 token address if native asset is valid. Returns an empty string if not.
 	ToWrapped(native string) (string, error)
 	// Checks whether a specific wrapped token has a corresponding native token. Returns the native token as string
 	ToNative(wrapped common.Address) (string, error)
+	// WrappedDecimals returns the decimals of the wrapped ERC-20 token
+	WrappedDecimals(wrapped string) (uint8, error)
+	// WrappedPaused returns whether the wrapped ERC-20 token is paused
+	WrappedPaused(wrapped string) (bool, error)
 }
@@ -0,0 +1,30 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import "math/big"
+
+// Decimals interface is implemented by the Decimals Service. It normalises amounts between
+// the decimals of a Hedera native asset and the decimals of its wrapped ERC-20 token
+type Decimals interface {
+	// ToWrapped converts an amount in native asset units into wrapped token units.
+	// Returns the dust (in native asset units), which cannot be represented with the wrapped token decimals
+	ToWrapped(nativeAsset, wrappedAsset string, amount *big.Int) (wrappedAmount, dust *big.Int, err error)
+	// ToNative converts an amount in wrapped token units into native asset units.
+	// Returns the dust (in wrapped token units), which cannot be represented with the native asset decimals
+	ToNative(nativeAsset, wrappedAsset string, amount *big.Int) (nativeAmount, dust *big.Int, err error)
+}
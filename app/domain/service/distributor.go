@@ -16,13 +16,16 @@
 
 package service
 
-import "github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
+import (
+	"math/big"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
+)
 
 // Distributor interface is implemented by the Distributor Service
 // Handles distribution of proportional amounts to members
 type Distributor interface {
-	// CalculateMemberDistribution Returns an equally divided to each member
-	CalculateMemberDistribution(validFee int64) ([]transfer.Hedera, error)
-	// ValidAmount Returns the closest amount, which can be equally divided to members
-	ValidAmount(amount int64) int64
+	// CalculateMemberDistribution returns the transfers distributing the whole amount to members
+	// proportionally to their weights. The remainder is allocated deterministically based on the `id`
+	CalculateMemberDistribution(id string, amount *big.Int) ([]transfer.Hedera, error)
 }
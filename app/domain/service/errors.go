@@ -19,3 +19,15 @@ package service
 import "errors"
 
 var ErrNotFound = errors.New("not found")
+
+// ErrUnsupportedAsset is returned for native assets, which have no wrapped token
+var ErrUnsupportedAsset = errors.New("token-not-supported")
+
+// ErrInvalidStateProof is returned for transactions, whose state proof does not verify them
+var ErrInvalidStateProof = errors.New("invalid-state-proof")
+
+// ErrInvalidStatus is returned for operations, which are not allowed in the current status of the record
+var ErrInvalidStatus = errors.New("invalid-status")
+
+// ErrRecoveryInProgress is returned, when a recovery is requested, while another one is running
+var ErrRecoveryInProgress = errors.New("recovery-in-progress")
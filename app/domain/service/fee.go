@@ -16,8 +16,11 @@
 
 package service
 
+import "math/big"
+
 // Fee interface is implemented by the Calculator Service
 type Fee interface {
-	// CalculateFee calculates the fee and remainder of a given amount
-	CalculateFee(amount int64) (fee, remainder int64)
+	// CalculateFee calculates the fee and remainder of a given amount, applying the policy configured for
+	// the native asset and the receiver, which is effective at the timestamp (in seconds) of the operation
+	CalculateFee(nativeAsset, receiver string, amount *big.Int, timestamp int64) (fee, remainder *big.Int)
 }
@@ -0,0 +1,28 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import "github.com/hashgraph/hedera-sdk-go/v2"
+
+// MemberRegistry interface is implemented by the Member Registry Service
+// Provides the current bridge members and the Hedera accounts, to which their fees are paid out
+type MemberRegistry interface {
+	// Signers returns the Ethereum addresses of the bridge members currently set in the Bridge contract
+	Signers() []string
+	// Accounts returns the Hedera fee accounts of the current bridge members
+	Accounts() []hedera.AccountID
+}
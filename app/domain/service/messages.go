@@ -26,4 +26,37 @@ type Messages interface {
 	SanityCheckSignature(tm message.Message) (bool, error)
 	// ProcessSignature processes the signature message, verifying and updating all necessary fields in the DB
 	ProcessSignature(tm message.Message) error
+	// Processed returns whether the signature message was already accepted or rejected
+	Processed(tm message.Message) (bool, error)
+	// RejectedMessages returns up to `limit` rejected signature messages with consensus timestamp after `after`,
+	// filtered by transfer and signer, unless they are empty
+	RejectedMessages(transferID, signer string, after int64, limit int) ([]RejectedMessage, error)
+	// Equivocations returns the conflicting signatures of members, filtered by transfer and signer, unless they are empty
+	Equivocations(transferID, signer string) ([]Equivocation, error)
+}
+
+type RejectedMessage struct {
+	TransferID           string `json:"transferId"`
+	Signature            string `json:"signature"`
+	Signer               string `json:"signer"`
+	Reason               string `json:"reason"`
+	RouterAddress        string `json:"routerAddress"`
+	Receiver             string `json:"receiver"`
+	Amount               string `json:"amount"`
+	WrappedAsset         string `json:"wrappedAsset"`
+	TransactionTimestamp int64  `json:"transactionTimestamp"`
+}
+
+// Equivocation is the evidence of a member, signing conflicting data for the same transfer
+type Equivocation struct {
+	TransferID string        `json:"transferId"`
+	Signer     string        `json:"signer"`
+	First      SignedMessage `json:"first"`
+	Second     SignedMessage `json:"second"`
+}
+
+type SignedMessage struct {
+	Signature            string `json:"signature"`
+	Hash                 string `json:"hash"`
+	TransactionTimestamp int64  `json:"transactionTimestamp"`
 }
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/model/message"
+
+// Pause is the circuit breaker of the bridge operations. It is controlled by the Bridge members
+// through signed control messages on the Bridge topic, so that all validators honour it. A pause is applied with the
+// signature of any member, while a resume requires the signatures of the quorum of the members
+type Pause interface {
+	// Paused returns whether the bridge operations are paused by the Bridge members
+	Paused() bool
+	// Queue queues the operation, if the bridge or the wrapped token (unless empty) is paused.
+	// Queued operations are run once resumed. Returns whether the operation was queued
+	Queue(id, wrappedAsset string, operation func()) bool
+	// Submit signs the control action and submits it to the Bridge topic. A zero nonce proposes a new action,
+	// otherwise the proposed action with the given nonce is signed
+	Submit(action, reason string, nonce int64) (int64, error)
+	// ProcessControlMessage verifies the control message and records its signature. The action is applied once
+	// it has the required signatures, if it is newer than the last applied one
+	ProcessControlMessage(msg message.Message) error
+	// Status returns the current pause state
+	Status() PauseStatus
+}
+
+type PauseStatus struct {
+	Paused    bool     `json:"paused"`
+	Reason    string   `json:"reason"`
+	Signer    string   `json:"signer"`
+	Nonce     int64    `json:"nonce"`
+	Timestamp int64    `json:"timestamp"`
+	Queued    []string `json:"queued"`
+	// Pending are the proposed actions, which do not have the required signatures yet
+	Pending []PendingControlAction `json:"pending"`
+}
+
+type PendingControlAction struct {
+	Action  string   `json:"action"`
+	Nonce   int64    `json:"nonce"`
+	Reason  string   `json:"reason"`
+	Signers []string `json:"signers"`
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/model/message"
+
+// PendingSignatures interface is implemented by the Pending Signatures Service
+// Buffers signature messages, received before their transfer is ready to be processed
+type PendingSignatures interface {
+	// Add buffers the signature message until its transfer is ready to be processed or until it expires
+	Add(tm message.Message) error
+	// Release hands the buffered signature messages of the transfer over to the subscribed handler
+	Release(transferID string)
+	// Subscribe sets the handler of the released signature messages
+	Subscribe(handler func(tm message.Message))
+	// Start periodically removes the buffered signature messages, whose transfer was not processed in time
+	Start()
+}
@@ -0,0 +1,31 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+
+// Quorum interface is implemented by the Quorum Service
+// Provides the number of signatures required for the transfers to be authorised
+type Quorum interface {
+	// Snapshot returns the currently eligible signers and the number of signatures required out of them
+	Snapshot() (signers []string, required int)
+	// Reached returns the number of collected signatures from the eligible signers of the transfer,
+	// the number of eligible signers and whether the required number of signatures is reached
+	Reached(t *entity.Transfer, messages []entity.Message) (collected, eligible int, reached bool)
+	// Eligible returns the messages of the eligible signers of the transfer, a single one per signer
+	Eligible(t *entity.Transfer, messages []entity.Message) []entity.Message
+}
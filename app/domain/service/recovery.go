@@ -0,0 +1,105 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+const (
+	// RecoveryScopeTransfers recovers the incoming transfers to the bridge account
+	RecoveryScopeTransfers = "transfers"
+	// RecoveryScopeMessages recovers the messages on the bridge topic
+	RecoveryScopeMessages = "messages"
+	// RecoveryScopeBurns recovers the burn events of the router contract
+	RecoveryScopeBurns = "burns"
+)
+
+const (
+	// RecoveryActionRecover persists the incoming transfer as recovered, to be processed
+	RecoveryActionRecover = "recover"
+	// RecoveryActionHold persists the operation, which is outside of the asset policy, as held for manual review
+	RecoveryActionHold = "hold"
+	// RecoveryActionRefund returns the rejected deposit to its sender
+	RecoveryActionRefund = "refund"
+	// RecoveryActionImport processes the signature message of a validator
+	RecoveryActionImport = "import"
+	// RecoveryActionProcess processes the transfer or burn event
+	RecoveryActionProcess = "process"
+	// RecoveryActionExecute executes again the scheduled transaction of the operation
+	RecoveryActionExecute = "execute"
+	// RecoveryActionAwait awaits the outcome of the submitted scheduled transaction
+	RecoveryActionAwait = "await"
+	// RecoveryActionSkip skips the operation
+	RecoveryActionSkip = "skip"
+)
+
+// RecoveryScopes are the scopes of the on-demand recovery, in the order of their recovery
+var RecoveryScopes = []string{RecoveryScopeTransfers, RecoveryScopeMessages, RecoveryScopeBurns}
+
+// Recovery is the service used for recovering operations, missed by the watchers
+type Recovery interface {
+	// Recover starts the recovery of the scopes in the interval [from; to) (in nanoseconds) and returns its progress.
+	// Records, which already exist, are not processed again. Returns ErrRecoveryInProgress, if a recovery is running
+	Recover(from, to int64, scopes []string) (*RecoveryProgress, error)
+	// Progress returns the progress of the last on-demand recovery. Returns nil, if there is none
+	Progress() *RecoveryProgress
+	// Report returns what the recovery of the scopes in the interval [from; to) (in nanoseconds) and the restart of
+	// the unfinished operations would do, without writing to the database or submitting any transactions
+	Report(from, to int64, scopes []string) (*RecoveryReport, error)
+}
+
+// RecoveryProgress is the progress of an on-demand recovery
+type RecoveryProgress struct {
+	From       int64                      `json:"from"`
+	To         int64                      `json:"to"`
+	Scopes     []string                   `json:"scopes"`
+	StartedAt  int64                      `json:"startedAt"`
+	FinishedAt int64                      `json:"finishedAt,omitempty"`
+	Running    bool                       `json:"running"`
+	Error      string                     `json:"error,omitempty"`
+	Results    map[string]*RecoveryResult `json:"results"`
+}
+
+// RecoveryResult is the outcome of the recovery of a single scope
+type RecoveryResult struct {
+	Found     int `json:"found"`
+	Recovered int `json:"recovered"`
+	Existing  int `json:"existing"`
+	Skipped   int `json:"skipped"`
+}
+
+// RecoveryReport is the outcome of a recovery dry-run
+type RecoveryReport struct {
+	TransfersFrom int64                `json:"transfersFrom"`
+	MessagesFrom  int64                `json:"messagesFrom"`
+	To            int64                `json:"to"`
+	Transfers     []RecoveryReportItem `json:"transfers"`
+	Signatures    []RecoveryReportItem `json:"signatures"`
+	Burns         []RecoveryReportItem `json:"burns"`
+	Unfinished    []RecoveryReportItem `json:"unfinished"`
+	Skipped       []RecoveryReportItem `json:"skipped"`
+}
+
+// RecoveryReportItem is an operation, which the recovery would process or skip
+type RecoveryReportItem struct {
+	Scope     string `json:"scope"`
+	ID        string `json:"id"`
+	Action    string `json:"action"`
+	Status    string `json:"status,omitempty"`
+	Receiver  string `json:"receiver,omitempty"`
+	Asset     string `json:"asset,omitempty"`
+	Amount    string `json:"amount,omitempty"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
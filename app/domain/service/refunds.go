@@ -0,0 +1,41 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import "github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
+
+// Refunds is the service used for returning rejected deposits to the bridge account back to their senders
+type Refunds interface {
+	// Refund records the rejected deposit and submits a scheduled transaction,
+	// returning the deposit to its sender, reduced by the refund fee
+	Refund(deposit transfer.Transfer, sender, reason string) error
+	// Reconcile schedules again the refund of the given deposit, which is not submitted yet
+	Reconcile(transferID string) error
+	// RefundData returns the refund of the given deposit
+	RefundData(transferID string) (RefundData, error)
+}
+
+type RefundData struct {
+	TransferID    string `json:"transferId"`
+	Sender        string `json:"sender"`
+	NativeAsset   string `json:"nativeAsset"`
+	Amount        string `json:"amount"`
+	Fee           string `json:"fee"`
+	Reason        string `json:"reason"`
+	Status        string `json:"status"`
+	TransactionID string `json:"transactionId"`
+}
@@ -23,4 +23,9 @@ import "github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
 type Scheduled interface {
 	// Execute submits a scheduled transaction and executes provided functions when necessary
 	Execute(id, nativeAsset string, transfers []transfer.Hedera, onExecutionSuccess func(transactionID, scheduleID string), onExecutionFail, onSuccess, onFail func(transactionID string))
+	// Await waits for the outcome of the submitted scheduled transaction and executes the provided functions
+	Await(transactionID string, onSuccess, onFail func(transactionID string))
+	// Find returns the executed scheduled transaction, whose schedule has the given memo, submitted after the given
+	// timestamp (in nanoseconds), and its schedule id. Returns empty ids, if there is none
+	Find(memo string, from int64) (transactionID, scheduleID string, err error)
 }
@@ -0,0 +1,28 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+// FeeSettlement interface is implemented by the Fee Settlement Service
+// Provides business logic for accruing fees and settling them periodically in batches
+type FeeSettlement interface {
+	// Enabled returns whether fees are accrued, instead of being paid out for every operation
+	Enabled() bool
+	// Batch returns the batch, in which the fee of an operation with the given timestamp (in seconds) is accrued
+	Batch(timestamp int64) int64
+	// Start periodically settles the accrued fees of batches, which are due
+	Start()
+}
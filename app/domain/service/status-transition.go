@@ -0,0 +1,29 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+// StatusTransition is a change of the status of a transfer, burn event or their fee and refund,
+// part of the timeline of the transfer or burn event
+type StatusTransition struct {
+	Kind      string `json:"kind"`
+	RecordID  string `json:"recordId"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Actor     string `json:"actor"`
+	Reason    string `json:"reason"`
+	Timestamp int64  `json:"timestamp"`
+}
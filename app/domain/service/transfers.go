@@ -32,12 +32,22 @@ type Transfers interface {
 	// InitiateNewTransfer Stores the incoming transfer message into the Database
 	// aware of already processed transfers
 	InitiateNewTransfer(tm transfer.Transfer) (*entity.Transfer, error)
+	// HoldTransfer stores the incoming transfer, which is outside of the asset policy, as held for manual review
+	HoldTransfer(tm transfer.Transfer, violation string) error
 	// ProcessTransfer processes the transfer message by signing the required
 	// authorisation signature submitting it into the required HCS Topic
 	ProcessTransfer(tm transfer.Transfer) error
+	// ReprocessTransfer processes the stored transfer again, if it is initial, held for manual review
+	// or its signature submission failed. If the fee of the transfer is already recorded, only the signature is submitted again
+	ReprocessTransfer(txId string) error
+	// ExecuteFee executes again the scheduled transaction paying out the fee of the processed transfer,
+	// if it failed or was not submitted
+	ExecuteFee(txId string) error
 	// TransferData returns from the database the given transfer, its signatures and
 	// calculates if its messages have reached super majority
 	TransferData(txId string) (TransferData, error)
+	// Timeline returns the status transitions of the given transfer, its signature, fee and refund, ordered by time
+	Timeline(txId string) ([]StatusTransition, error)
 }
 
 type TransferData struct {
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+// Webhook events
+const (
+	// WebhookTransferCreated is sent once the transfer is recorded
+	WebhookTransferCreated = "TRANSFER_CREATED"
+	// WebhookSignatureSubmitted is sent once the validator submits its signature of the transfer to the topic
+	WebhookSignatureSubmitted = "SIGNATURE_SUBMITTED"
+	// WebhookMajorityReached is sent once the transfer has the required signatures and can be minted
+	WebhookMajorityReached = "MAJORITY_REACHED"
+	// WebhookMintObserved is sent once the wrapped asset of the transfer is minted by the Router contract
+	WebhookMintObserved = "MINT_OBSERVED"
+	// WebhookBurnScheduled is sent once the scheduled transaction of the burn event is submitted
+	WebhookBurnScheduled = "BURN_SCHEDULED"
+	// WebhookBurnCompleted is sent once the scheduled transaction of the burn event is executed
+	WebhookBurnCompleted = "BURN_COMPLETED"
+	// WebhookBurnFailed is sent once the scheduled transaction of the burn event fails or cannot be submitted
+	WebhookBurnFailed = "BURN_FAILED"
+	// WebhookFeeCompleted is sent once the fee of a transfer or burn event is paid out
+	WebhookFeeCompleted = "FEE_COMPLETED"
+)
+
+// Webhooks notifies the subscribed integrators about the lifecycle events of transfers and burn events
+type Webhooks interface {
+	// Enabled returns whether any subscriptions are configured
+	Enabled() bool
+	// Notify records a delivery of the event for every subscription of the event and delivers them in the background.
+	// Failed deliveries are retried with backoff
+	Notify(event string, data WebhookData)
+	// Start resumes the pending deliveries, left unfinished by a restart
+	Start()
+}
+
+// WebhookData describes the transfer or burn event, which the webhook event is about.
+// Properties, which do not apply to the event, are omitted
+type WebhookData struct {
+	TransferID    string `json:"transferId,omitempty"`
+	BurnEventID   string `json:"burnEventId,omitempty"`
+	TransactionID string `json:"transactionId,omitempty"`
+	ScheduleID    string `json:"scheduleId,omitempty"`
+	Receiver      string `json:"receiver,omitempty"`
+	Amount        string `json:"amount,omitempty"`
+	NativeAsset   string `json:"nativeAsset,omitempty"`
+	WrappedAsset  string `json:"wrappedAsset,omitempty"`
+}
+
+// WebhookPayload is the signed JSON body of a webhook delivery
+type WebhookPayload struct {
+	Event     string      `json:"event"`
+	Timestamp int64       `json:"timestamp"`
+	Data      WebhookData `json:"data"`
+}
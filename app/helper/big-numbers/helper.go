@@ -26,8 +26,17 @@ func ToBigInt(value string) (*big.Int, error) {
 	amount := new(big.Int)
 	amount, ok := amount.SetString(value, 10)
 	if !ok {
-		return nil, errors.New(fmt.Sprintf("Failed to parse amount [%s] to big integer.", amount))
+		return nil, errors.New(fmt.Sprintf("Failed to parse amount [%s] to big integer.", value))
 	}
 
 	return amount, nil
 }
+
+// ToInt64 converts the value to int64 (f.e. Hedera units). Returns an error, instead of truncating, if the value overflows
+func ToInt64(value *big.Int) (int64, error) {
+	if value == nil || !value.IsInt64() {
+		return 0, errors.New(fmt.Sprintf("Amount [%s] overflows int64.", value))
+	}
+
+	return value.Int64(), nil
+}
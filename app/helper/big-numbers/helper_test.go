@@ -37,3 +37,18 @@ func Test_ToBigIntError(t *testing.T) {
 	_, err := ToBigInt(notValidNumber)
 	assert.Error(t, err)
 }
+
+func Test_ToInt64(t *testing.T) {
+	value, err := ToInt64(big.NewInt(54321))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(54321), value)
+}
+
+func Test_ToInt64Overflow(t *testing.T) {
+	value, _ := ToBigInt("9223372036854775808")
+	_, err := ToInt64(value)
+	assert.Error(t, err)
+
+	_, err = ToInt64(nil)
+	assert.Error(t, err)
+}
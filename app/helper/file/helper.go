@@ -0,0 +1,77 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// watcher reloads the contents of a file, once it is modified
+type watcher struct {
+	path         string
+	name         string
+	lastModified time.Time
+	reload       func() error
+	logger       *log.Entry
+}
+
+// Watch polls the file at intervals (in seconds) and calls reload once the file is modified.
+// If the reload fails, the current contents are kept until the file is modified again.
+// The name of the contents is used in the logs
+func Watch(path, name string, interval time.Duration, logger *log.Entry, reload func() error) {
+	w := newWatcher(path, name, logger, reload)
+	for {
+		time.Sleep(interval * time.Second)
+		w.check()
+	}
+}
+
+func newWatcher(path, name string, logger *log.Entry, reload func() error) *watcher {
+	w := &watcher{
+		path:   path,
+		name:   name,
+		reload: reload,
+		logger: logger,
+	}
+	if info, err := os.Stat(path); err == nil {
+		w.lastModified = info.ModTime()
+	}
+	return w
+}
+
+// check reloads the file, if it is modified since the last check
+func (w *watcher) check() {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		w.logger.Errorf("Failed to stat %s file [%s]. Error: [%s]", w.name, w.path, err)
+		return
+	}
+	if !info.ModTime().After(w.lastModified) {
+		return
+	}
+	w.lastModified = info.ModTime()
+
+	err = w.reload()
+	if err != nil {
+		w.logger.Errorf("Failed to reload %s from [%s]. Keeping current %s. Error: [%s]", w.name, w.path, w.name, err)
+		return
+	}
+	w.logger.Infof("Reloaded %s from [%s]", w.name, w.path)
+}
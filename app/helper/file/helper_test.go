@@ -0,0 +1,76 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Check(t *testing.T) {
+	directory, err := ioutil.TempDir("", "watch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "rules.yml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("first"), 0644))
+
+	reloads := 0
+	reloadErr := errors.New("invalid")
+	w := newWatcher(path, "rules", config.GetLoggerFor("Test"), func() error {
+		reloads++
+		return reloadErr
+	})
+
+	w.check()
+	assert.Equal(t, 0, reloads)
+
+	modified := time.Now().Add(time.Minute)
+	assert.Nil(t, os.Chtimes(path, modified, modified))
+	w.check()
+	assert.Equal(t, 1, reloads)
+
+	// A failed reload is not retried until the file is modified again
+	w.check()
+	assert.Equal(t, 1, reloads)
+
+	reloadErr = nil
+	modified = modified.Add(time.Minute)
+	assert.Nil(t, os.Chtimes(path, modified, modified))
+	w.check()
+	assert.Equal(t, 2, reloads)
+}
+
+func Test_CheckMissingFile(t *testing.T) {
+	reloads := 0
+	w := newWatcher(filepath.Join(os.TempDir(), "missing-watch-file.yml"), "rules", config.GetLoggerFor("Test"), func() error {
+		reloads++
+		return nil
+	})
+
+	w.check()
+
+	assert.Equal(t, 0, reloads)
+}
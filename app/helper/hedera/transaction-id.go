@@ -17,6 +17,7 @@
 package hedera
 
 import (
+	"errors"
 	"fmt"
 	"github.com/hashgraph/hedera-sdk-go/v2"
 	"strings"
@@ -51,6 +52,20 @@ func FromHederaTransactionID(id *hedera.TransactionID) HederaTransactionID {
 	}
 }
 
+// FromMirrorNodeTransactionID parses TX with format `0.0.X-{seconds}-{nanos}`
+func FromMirrorNodeTransactionID(txId string) (HederaTransactionID, error) {
+	split := strings.Split(txId, "-")
+	if len(split) != 3 {
+		return HederaTransactionID{}, errors.New(fmt.Sprintf("invalid transaction id [%s]", txId))
+	}
+
+	return HederaTransactionID{
+		AccountId: split[0],
+		Seconds:   split[1],
+		Nanos:     split[2],
+	}, nil
+}
+
 type HederaTransactionID struct {
 	AccountId string
 	Seconds   string
@@ -47,3 +47,17 @@ func Test_FromHederaTransactionID(t *testing.T) {
 	assert.Equal(t, expectedTimestamp, res.Timestamp())
 	assert.Nil(t, err)
 }
+
+func Test_FromMirrorNodeTransactionID(t *testing.T) {
+	res, err := FromMirrorNodeTransactionID(expectedTransactionID)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAccountID, res.AccountId)
+	assert.Equal(t, expectedSeconds, res.Seconds)
+	assert.Equal(t, expectedNanos, res.Nanos)
+	assert.Equal(t, expectedTransactionID, res.String())
+}
+
+func Test_FromMirrorNodeTransactionIDInvalid(t *testing.T) {
+	_, err := FromMirrorNodeTransactionID(transactionID)
+	assert.Error(t, err)
+}
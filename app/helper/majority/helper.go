@@ -0,0 +1,63 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package majority
+
+import (
+	"strings"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+)
+
+const separator = ","
+
+// Join encodes the eligible signers to be persisted with the transfer
+func Join(signers []string) string {
+	return strings.Join(signers, separator)
+}
+
+// Signers returns the eligible signers persisted once the transfer was created.
+// Returns nil for transfers created without a snapshot
+func Signers(t *entity.Transfer) []string {
+	if t.EligibleSigners == "" {
+		return nil
+	}
+	return strings.Split(t.EligibleSigners, separator)
+}
+
+// Count returns the number of distinct eligible signers of the provided messages
+func Count(messages []entity.Message, signers []string) int {
+	return len(Eligible(messages, signers))
+}
+
+// Eligible returns the first message of every distinct eligible signer of the provided messages
+func Eligible(messages []entity.Message, signers []string) []entity.Message {
+	eligible := make(map[string]bool)
+	for _, s := range signers {
+		eligible[strings.ToLower(s)] = true
+	}
+
+	var result []entity.Message
+	counted := make(map[string]bool)
+	for _, m := range messages {
+		signer := strings.ToLower(m.Signer)
+		if eligible[signer] && !counted[signer] {
+			counted[signer] = true
+			result = append(result, m)
+		}
+	}
+	return result
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package majority
+
+import (
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var members = []string{"0xAa", "0xBb", "0xCc"}
+
+func Test_Signers(t *testing.T) {
+	transfer := &entity.Transfer{EligibleSigners: Join([]string{"0xAa", "0xBb"})}
+
+	assert.Equal(t, []string{"0xAa", "0xBb"}, Signers(transfer))
+	assert.Nil(t, Signers(&entity.Transfer{}))
+}
+
+func Test_Count(t *testing.T) {
+	messages := []entity.Message{
+		{Signer: "0xAA"},
+		{Signer: "0xaa"},
+		{Signer: "0xBb"},
+		{Signer: "0xDd"},
+	}
+
+	assert.Equal(t, 2, Count(messages, members))
+}
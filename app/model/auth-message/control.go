@@ -0,0 +1,62 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth_message
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// EncodeControlBytesFrom returns the array of bytes representing a
+// bridge control action ready to be signed by Ethereum Private Key
+func EncodeControlBytesFrom(topicID, action, reason string, nonce int64) ([]byte, error) {
+	bytesType, err := abi.NewType("bytes", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	args := abi.Arguments{
+		{
+			Type: bytesType,
+		},
+		{
+			Type: stringType,
+		},
+		{
+			Type: stringType,
+		},
+		{
+			Type: uint256Type,
+		}}
+
+	bytesToHash, err := args.Pack([]byte(topicID), action, reason, big.NewInt(nonce))
+	if err != nil {
+		return nil, err
+	}
+	return keccak(bytesToHash), nil
+}
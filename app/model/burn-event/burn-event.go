@@ -16,13 +16,18 @@
 
 package burn_event
 
-import "github.com/hashgraph/hedera-sdk-go/v2"
+import (
+	"math/big"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+)
 
 // BurnEvent serves as a model between Ethereum Watcher and Handler
 type BurnEvent struct {
 	Id           string // {ethereumTxHash}-{logIndex}
-	Amount       int64
+	Amount       *big.Int
 	Recipient    hedera.AccountID
 	NativeAsset  string
 	WrappedAsset string
+	Timestamp    int64 // timestamp (in seconds) of the block, in which the burn occurred
 }
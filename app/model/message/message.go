@@ -76,6 +76,19 @@ func NewSignature(transferID, routerAddress, receiver, amount, signature, wrappe
 	return &Message{topicMsg}
 }
 
+// NewControl instantiates Control Message struct ready for submission to the Bridge Topic
+func NewControl(action, reason string, nonce int64, signature string) *Message {
+	topicMsg := &model.TopicEthSignatureMessage{
+		Control: &model.TopicControlMessage{
+			Action:    action,
+			Reason:    reason,
+			Nonce:     nonce,
+			Signature: signature,
+		},
+	}
+	return &Message{topicMsg}
+}
+
 // ToBytes marshals the underlying protobuf Message into bytes
 func (tm *Message) ToBytes() ([]byte, error) {
 	return proto.Marshal(tm.TopicEthSignatureMessage)
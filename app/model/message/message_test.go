@@ -75,6 +75,24 @@ func Test_NewSignatureWorks(t *testing.T) {
 	signatureEqualFields(t, expectedSignature(), actualSignature.TopicEthSignatureMessage)
 }
 
+func Test_NewControlWorks(t *testing.T) {
+	control := NewControl("PAUSE", "incident", now.UnixNano(), "somesigneddatahere")
+
+	bytes, err := control.ToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := FromBytes(bytes)
+	assert.Nil(t, err)
+	assert.NotNil(t, actual.Control)
+	assert.Equal(t, "PAUSE", actual.Control.Action)
+	assert.Equal(t, "incident", actual.Control.Reason)
+	assert.Equal(t, now.UnixNano(), actual.Control.Nonce)
+	assert.Equal(t, "somesigneddatahere", actual.Control.Signature)
+	assert.Empty(t, actual.TransferID)
+}
+
 func Test_FromStringWithInvalidTS(t *testing.T) {
 	result, err := FromString(invalidStringData, invalidStringTs)
 	assert.Nil(t, result)
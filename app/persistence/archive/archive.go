@@ -0,0 +1,173 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"errors"
+	"time"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	archived_record "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/archived-record"
+	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// unpaidFeeStatuses are the statuses of fees, which are not yet paid out
+var unpaidFeeStatuses = []string{fee.StatusSubmitted, fee.StatusAccrued, fee.StatusFailed}
+
+type Repository struct {
+	dbClient *gorm.DB
+	logger   *log.Entry
+}
+
+func NewRepository(dbClient *gorm.DB) *Repository {
+	return &Repository{
+		dbClient: dbClient,
+		logger:   config.GetLoggerFor("Archive Repository"),
+	}
+}
+
+func (r Repository) Get(id string) (*entity.ArchivedRecord, error) {
+	record := &entity.ArchivedRecord{}
+	result := r.dbClient.
+		Model(entity.ArchivedRecord{}).
+		Where("id = ?", id).
+		First(record)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return record, nil
+}
+
+func (r Repository) GetArchivableTransfers(before int64, limit int) ([]*entity.Transfer, error) {
+	var transfers []*entity.Transfer
+	err := r.dbClient.
+		Preload("Fee").
+		Preload("Messages").
+		Where("status = ? AND timestamp > 0 AND timestamp < ?", transfer.StatusCompleted, before).
+		Where("NOT EXISTS (SELECT 1 FROM fees WHERE fees.transfer_id = transfers.transaction_id AND fees.status IN ?)", unpaidFeeStatuses).
+		Order("timestamp").
+		Limit(limit).
+		Find(&transfers).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+func (r Repository) GetArchivableBurnEvents(before int64, limit int) ([]*entity.BurnEvent, error) {
+	var burnEvents []*entity.BurnEvent
+	err := r.dbClient.
+		Preload("Fee").
+		Where("status IN ? AND timestamp > 0 AND timestamp < ?", []string{burn_event.StatusCompleted, burn_event.StatusFailed}, before).
+		// The fee of a failed burn event is not owed, since it fails together with the burn event
+		Where("status = ? OR NOT EXISTS (SELECT 1 FROM fees WHERE fees.burn_event_id = burn_events.id AND fees.status IN ?)", burn_event.StatusFailed, unpaidFeeStatuses).
+		Order("timestamp").
+		Limit(limit).
+		Find(&burnEvents).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return burnEvents, nil
+}
+
+func (r Repository) PruneTransfers(ids []string, file string) error {
+	return r.dbClient.Transaction(func(tx *gorm.DB) error {
+		err := createRecords(tx, ids, archived_record.KindTransfer, file)
+		if err != nil {
+			return err
+		}
+		err = pruneHistory(tx, ids, []string{status_transition.KindTransfer, status_transition.KindTransferSignature, status_transition.KindRefund}, "transfer_id")
+		if err != nil {
+			return err
+		}
+		err = tx.Where("transfer_id IN ?", ids).Delete(&entity.Message{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("transfer_id IN ?", ids).Delete(&entity.Fee{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("transaction_id IN ?", ids).Delete(&entity.Transfer{}).Error
+	})
+}
+
+func (r Repository) PruneBurnEvents(ids []string, file string) error {
+	return r.dbClient.Transaction(func(tx *gorm.DB) error {
+		err := createRecords(tx, ids, archived_record.KindBurnEvent, file)
+		if err != nil {
+			return err
+		}
+		err = pruneHistory(tx, ids, []string{status_transition.KindBurnEvent}, "burn_event_id")
+		if err != nil {
+			return err
+		}
+		err = tx.Where("burn_event_id IN ?", ids).Delete(&entity.Fee{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", ids).Delete(&entity.BurnEvent{}).Error
+	})
+}
+
+// pruneHistory deletes the status transitions of the records and their fees, and the webhook deliveries
+// of the records. The final status of the records and their fees is kept in the archive file
+func pruneHistory(tx *gorm.DB, ids, kinds []string, feeColumn string) error {
+	fees := tx.
+		Model(entity.Fee{}).
+		Select("transaction_id").
+		Where(feeColumn+" IN ?", ids)
+
+	err := tx.
+		Where("kind IN ? AND record_id IN ?", kinds, ids).
+		Or("kind = ? AND record_id IN (?)", status_transition.KindFee, fees).
+		Delete(&entity.StatusTransition{}).
+		Error
+	if err != nil {
+		return err
+	}
+	return tx.Where("record_id IN ?", ids).Delete(&entity.WebhookDelivery{}).Error
+}
+
+// createRecords references the archive file of the records. Records, which are archived again
+// (f.e. after a failed pruning), reference the latest file
+func createRecords(tx *gorm.DB, ids []string, kind, file string) error {
+	archivedAt := time.Now().UnixNano()
+	records := make([]entity.ArchivedRecord, len(ids))
+	for i, id := range ids {
+		records[i] = entity.ArchivedRecord{
+			ID:         id,
+			Kind:       kind,
+			File:       file,
+			ArchivedAt: archivedAt,
+		}
+	}
+
+	return tx.Save(&records).Error
+}
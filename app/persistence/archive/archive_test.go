@@ -0,0 +1,146 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	archived_record "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/archived-record"
+	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/migration"
+	"github.com/limechain/hedera-eth-bridge-validator/test/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+const before = int64(1620000100)
+
+func setup(t *testing.T) (*gorm.DB, *Repository) {
+	db := database.ConnectSQLite(t)
+	err := migration.New(db).Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, NewRepository(db)
+}
+
+func createTransfer(t *testing.T, db *gorm.DB, id string, timestamp int64, feeStatus string) {
+	err := db.Create(&entity.Transfer{
+		TransactionID: id,
+		Status:        transfer.StatusCompleted,
+		Timestamp:     timestamp,
+		Messages: []entity.Message{
+			{Signature: "signature-" + id, Signer: "0xsigner"},
+		},
+		Fee: entity.Fee{
+			TransactionID: "fee-" + id,
+			Status:        feeStatus,
+		},
+	}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_GetArchivableTransfers(t *testing.T) {
+	db, repository := setup(t)
+	createTransfer(t, db, "archivable", before-1, fee.StatusCompleted)
+	createTransfer(t, db, "recent", before, fee.StatusCompleted)
+	createTransfer(t, db, "accrued", before-1, fee.StatusAccrued)
+	createTransfer(t, db, "failed", before-1, fee.StatusFailed)
+
+	transfers, err := repository.GetArchivableTransfers(before, 10)
+
+	assert.Nil(t, err)
+	assert.Len(t, transfers, 1)
+	assert.Equal(t, "archivable", transfers[0].TransactionID)
+	assert.Equal(t, "fee-archivable", transfers[0].Fee.TransactionID)
+	assert.Len(t, transfers[0].Messages, 1)
+}
+
+func Test_PruneTransfers(t *testing.T) {
+	db, repository := setup(t)
+	createTransfer(t, db, "archivable", before-1, fee.StatusCompleted)
+	createTransfer(t, db, "recent", before, fee.StatusCompleted)
+	for _, transition := range []entity.StatusTransition{
+		{Kind: status_transition.KindTransfer, RecordID: "archivable"},
+		{Kind: status_transition.KindFee, RecordID: "fee-archivable"},
+		{Kind: status_transition.KindTransfer, RecordID: "recent"},
+	} {
+		assert.Nil(t, db.Create(&transition).Error)
+	}
+	assert.Nil(t, db.Create(&entity.WebhookDelivery{RecordID: "archivable"}).Error)
+	assert.Nil(t, db.Create(&entity.Equivocation{TransferID: "archivable", FirstSignature: "1", SecondSignature: "2"}).Error)
+
+	err := repository.PruneTransfers([]string{"archivable"}, "transfers-1.jsonl.gz")
+	assert.Nil(t, err)
+	// Pruned again, once archived to another file
+	err = repository.PruneTransfers([]string{"archivable"}, "transfers-2.jsonl.gz")
+	assert.Nil(t, err)
+
+	var transfers, messages, fees int64
+	db.Model(&entity.Transfer{}).Count(&transfers)
+	db.Model(&entity.Message{}).Count(&messages)
+	db.Model(&entity.Fee{}).Count(&fees)
+	assert.Equal(t, int64(1), transfers)
+	assert.Equal(t, int64(1), messages)
+	assert.Equal(t, int64(1), fees)
+
+	var transitions, deliveries, equivocations int64
+	db.Model(&entity.StatusTransition{}).Count(&transitions)
+	db.Model(&entity.WebhookDelivery{}).Count(&deliveries)
+	db.Model(&entity.Equivocation{}).Count(&equivocations)
+	assert.Equal(t, int64(1), transitions)
+	assert.Zero(t, deliveries)
+	// Evidence of misbehaving members is kept
+	assert.Equal(t, int64(1), equivocations)
+
+	record, err := repository.Get("archivable")
+	assert.Nil(t, err)
+	assert.Equal(t, archived_record.KindTransfer, record.Kind)
+	assert.Equal(t, "transfers-2.jsonl.gz", record.File)
+
+	record, err = repository.Get("recent")
+	assert.Nil(t, err)
+	assert.Nil(t, record)
+}
+
+func Test_GetArchivableBurnEvents(t *testing.T) {
+	db, repository := setup(t)
+	err := db.Create(&entity.BurnEvent{
+		Id:            "0xethtxhash-1",
+		Status:        burn_event.StatusFailed,
+		Timestamp:     before - 1,
+		TransactionId: sql.NullString{String: "0.0.1234-1620000000-000000000", Valid: true},
+		Fee:           entity.Fee{TransactionID: "0.0.1234-1620000000-000000000", Status: fee.StatusFailed},
+	}).Error
+	assert.Nil(t, err)
+
+	burnEvents, err := repository.GetArchivableBurnEvents(before, 10)
+	assert.Nil(t, err)
+	assert.Len(t, burnEvents, 1)
+
+	assert.Nil(t, repository.PruneBurnEvents([]string{"0xethtxhash-1"}, "burn-events-1.jsonl.gz"))
+	burnEvents, err = repository.GetArchivableBurnEvents(before, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, burnEvents)
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package persistence_test
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/fee"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/message"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/migration"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/test/database"
+	"gorm.io/gorm"
+)
+
+const (
+	benchmarkSchema = "benchmark"
+	// benchmarkTransfersEnv overrides the number of seeded transfers
+	benchmarkTransfersEnv = "BENCHMARK_TRANSFERS"
+	// Every unprocessedEvery-th transfer and fee are not in a terminal status
+	unprocessedEvery = 1000
+)
+
+// The query benchmarks (`go test ./app/persistence/ -run none -bench .`) run against a history of transfers
+// (1 000 000 by default), each with 3 signatures and a fee. The history is seeded once and kept for the next runs
+func BenchmarkGetUnprocessedTransfers(b *testing.B) {
+	repository := transfer.NewRepository(seed(b))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := repository.GetUnprocessedTransfers()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetInitialAndSignatureSubmittedTx(b *testing.B) {
+	repository := transfer.NewRepository(seed(b))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := repository.GetInitialAndSignatureSubmittedTx()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetTransferWithPreloads(b *testing.B) {
+	db := seed(b)
+	repository := transfer.NewRepository(db)
+	count := transfers(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := repository.GetWithPreloads(transactionID(i%count + 1))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetMessages(b *testing.B) {
+	repository := message.NewRepository(seed(b))
+	count := transfers(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := repository.Get(transactionID(i%count + 1))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetSubmittedFees(b *testing.B) {
+	repository := fee.NewRepository(seed(b))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := repository.GetSubmitted()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// seed migrates the benchmark schema and inserts the history of transfers, unless it is already seeded
+func seed(b *testing.B) *gorm.DB {
+	db := database.Connect(b, benchmarkSchema)
+	err := migration.New(db).Up()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	count := transfers(b)
+	var existing int64
+	err = db.Table("transfers").Count(&existing).Error
+	if err != nil {
+		b.Fatal(err)
+	}
+	if existing == int64(count) {
+		return db
+	}
+
+	statements := []string{
+		"TRUNCATE transfers, messages, fees",
+		fmt.Sprintf(`INSERT INTO transfers (transaction_id, receiver, native_asset, wrapped_asset, amount, router_address, status, signature_msg_status, required_signatures, eligible_signers)
+			SELECT '0.0.1111-' || i || '-0', '0xreceiver', 'HBAR', '0xwrapped', '100', '0xrouter',
+				CASE WHEN i %% %[2]d = 0 THEN 'INITIAL' ELSE 'COMPLETED' END, 'SIGNATURE_MINED', 2, '0xa,0xb,0xc'
+			FROM generate_series(1, %[1]d) AS i`, count, unprocessedEvery),
+		fmt.Sprintf(`INSERT INTO messages (transfer_id, hash, signature, signer, transaction_timestamp)
+			SELECT '0.0.1111-' || i || '-0', 'hash-' || i, 'signature-' || i || '-' || s, 'signer-' || s, i
+			FROM generate_series(1, %d) AS i, generate_series(1, 3) AS s`, count),
+		fmt.Sprintf(`INSERT INTO fees (transaction_id, schedule_id, amount, status, transfer_id, native_asset, batch)
+			SELECT '0.0.2222-' || i || '-0', '0.0.' || i, '1',
+				CASE WHEN i %% %[2]d = 0 THEN 'SUBMITTED' ELSE 'COMPLETED' END, '0.0.1111-' || i || '-0', 'HBAR', 0
+			FROM generate_series(1, %[1]d) AS i`, count, unprocessedEvery),
+		"ANALYZE transfers, messages, fees",
+	}
+	for _, statement := range statements {
+		err = db.Exec(statement).Error
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	return db
+}
+
+func transfers(b *testing.B) int {
+	value := os.Getenv(benchmarkTransfersEnv)
+	if value == "" {
+		return 1000000
+	}
+
+	count, err := strconv.Atoi(value)
+	if err != nil || count <= 0 {
+		b.Fatalf("invalid %s [%s]", benchmarkTransfersEnv, value)
+	}
+	return count
+}
+
+func transactionID(i int) string {
+	return fmt.Sprintf("0.0.1111-%d-0", i)
+}
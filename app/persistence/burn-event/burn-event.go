@@ -19,8 +19,11 @@ package burn_event
 import (
 	"database/sql"
 	"errors"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	model "github.com/limechain/hedera-eth-bridge-validator/app/model/burn-event"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
 	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/burn-event"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/status-transition"
 	"gorm.io/gorm"
 )
 
@@ -34,40 +37,48 @@ func NewRepository(dbClient *gorm.DB) *Repository {
 	}
 }
 
-func (sr Repository) Create(id string, amount int64, recipient string) error {
+func (sr Repository) Create(event *model.BurnEvent) error {
+	return sr.create(event, burn_event.StatusInitial, "")
+}
+
+// CreateHeld creates a BurnEvent record, held for manual review because of the violated asset policy limit
+func (sr Repository) CreateHeld(event *model.BurnEvent, violation string) error {
+	return sr.create(event, burn_event.StatusHeld, violation)
+}
+
+func (sr Repository) create(event *model.BurnEvent, status, violation string) error {
 	return sr.dbClient.Create(&entity.BurnEvent{
-		Id:        id,
-		Amount:    amount,
-		Recipient: recipient,
-		Status:    burn_event.StatusInitial,
+		Id:           event.Id,
+		Amount:       event.Amount.String(),
+		Recipient:    event.Recipient.String(),
+		NativeAsset:  event.NativeAsset,
+		WrappedAsset: event.WrappedAsset,
+		Timestamp:    event.Timestamp,
+		Status:       status,
+		Violation:    violation,
 	}).Error
 }
 
-func (sr Repository) UpdateStatusSubmitted(ethTxHash, scheduleID, transactionId string) error {
-	return sr.dbClient.
-		Model(entity.BurnEvent{}).
-		Where("id = ?", ethTxHash).
-		Updates(entity.BurnEvent{Status: burn_event.StatusSubmitted, ScheduleID: scheduleID, TransactionId: sql.NullString{
-			String: transactionId,
-			Valid:  true,
-		}}).
-		Error
+// UpdateStatusSubmitted records the scheduled transaction of an initial, held or failed burn event
+func (sr Repository) UpdateStatusSubmitted(ethTxHash, scheduleID, transactionId string, cause repository.Cause) error {
+	return status_transition.BurnEventStatus.Update(sr.dbClient, []string{ethTxHash}, burn_event.StatusSubmitted, cause, map[string]interface{}{
+		"schedule_id":    scheduleID,
+		"transaction_id": sql.NullString{String: transactionId, Valid: true},
+	})
 }
 
-func (sr Repository) UpdateStatusCompleted(id string) error {
-	return sr.updateStatus(id, burn_event.StatusCompleted)
+// UpdateStatusCompleted completes a submitted burn event
+func (sr Repository) UpdateStatusCompleted(id string, cause repository.Cause) error {
+	return sr.updateStatus(id, burn_event.StatusCompleted, cause)
 }
 
-func (sr Repository) UpdateStatusFailed(id string) error {
-	return sr.updateStatus(id, burn_event.StatusFailed)
+// UpdateStatusFailed fails a burn event, which is not completed
+func (sr Repository) UpdateStatusFailed(id string, cause repository.Cause) error {
+	return sr.updateStatus(id, burn_event.StatusFailed, cause)
 }
 
-func (sr Repository) updateStatus(id string, status string) error {
-	return sr.dbClient.
-		Model(entity.BurnEvent{}).
-		Where("id = ?", id).
-		UpdateColumn("status", status).
-		Error
+func (sr Repository) updateStatus(id, status string, cause repository.Cause) error {
+	return status_transition.BurnEventStatus.Update(sr.dbClient, []string{id}, status, cause, nil)
 }
 
 func (sr Repository) Get(id string) (*entity.BurnEvent, error) {
@@ -86,3 +97,39 @@ func (sr Repository) Get(id string) (*entity.BurnEvent, error) {
 
 	return burnEvent, nil
 }
+
+// GetUnprocessed returns the BurnEvents, which are initial or submitted
+func (sr Repository) GetUnprocessed() ([]*entity.BurnEvent, error) {
+	var burnEvents []*entity.BurnEvent
+	err := sr.dbClient.
+		Where("status IN ?", []string{burn_event.StatusInitial, burn_event.StatusSubmitted}).
+		Find(&burnEvents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return burnEvents, nil
+}
+
+// CountByStatus returns the number of burn events by status
+func (sr Repository) CountByStatus() (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := sr.dbClient.
+		Model(entity.BurnEvent{}).
+		Select("status, count(*) as count").
+		Group("status").
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package control_action
+
+import (
+	"errors"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	dbClient *gorm.DB
+}
+
+func NewRepository(dbClient *gorm.DB) *Repository {
+	return &Repository{
+		dbClient: dbClient,
+	}
+}
+
+// Create persists the applied control action. Already recorded actions are ignored
+func (r Repository) Create(action *entity.ControlAction) error {
+	return r.dbClient.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(action).
+		Error
+}
+
+// GetLatest returns the last applied control action. Returns nil if none was applied
+func (r Repository) GetLatest() (*entity.ControlAction, error) {
+	action := &entity.ControlAction{}
+	err := r.dbClient.
+		Model(entity.ControlAction{}).
+		Order("nonce desc").
+		First(action).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return action, nil
+}
+
+// CreateSignature persists the signature of a member on a control action. Already recorded signatures are ignored
+func (r Repository) CreateSignature(signature *entity.ControlSignature) error {
+	return r.dbClient.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(signature).
+		Error
+}
+
+// GetSignatures returns the signatures on the control action with the given nonce
+func (r Repository) GetSignatures(nonce int64, action string) ([]entity.ControlSignature, error) {
+	var signatures []entity.ControlSignature
+	err := r.dbClient.
+		Where("nonce = ? AND action = ?", nonce, action).
+		Order("timestamp").
+		Find(&signatures).
+		Error
+	return signatures, err
+}
+
+// GetSignaturesAfter returns the signatures on control actions, newer than the given nonce, ordered by nonce
+func (r Repository) GetSignaturesAfter(nonce int64) ([]entity.ControlSignature, error) {
+	var signatures []entity.ControlSignature
+	err := r.dbClient.
+		Where("nonce > ?", nonce).
+		Order("nonce, timestamp").
+		Find(&signatures).
+		Error
+	return signatures, err
+}
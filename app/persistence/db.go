@@ -18,10 +18,11 @@ package persistence
 
 import (
 	"fmt"
-	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/migration"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"time"
@@ -41,11 +42,29 @@ func NewDatabase(config config.Database) *Database {
 	}
 }
 
-// Establish connection to the Postgres Database
+// Supported database drivers
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// Establish connection to the configured Database
 func Connect(dbConfig config.Database) *gorm.DB {
-	connectionStr := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=disable", dbConfig.Host, dbConfig.Port, dbConfig.Username, dbConfig.Name, dbConfig.Password)
+	var open func(dsn string) gorm.Dialector
+	var connectionStr string
+	switch dbConfig.Driver {
+	case "", DriverPostgres:
+		open = postgres.Open
+		connectionStr = fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=disable", dbConfig.Host, dbConfig.Port, dbConfig.Username, dbConfig.Name, dbConfig.Password)
+	case DriverSQLite:
+		// Foreign keys are not enforced by SQLite, unless enabled. Writers wait for each other, instead of failing
+		open = sqlite.Open
+		connectionStr = fmt.Sprintf("%s?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL", dbConfig.Name)
+	default:
+		log.Fatalf("Unsupported database driver [%s].", dbConfig.Driver)
+	}
 
-	db := tryConnection(connectionStr)
+	db := tryConnection(open, connectionStr)
 	log.Infoln("Successfully connected to Database")
 
 	return db
@@ -53,9 +72,9 @@ func Connect(dbConfig config.Database) *gorm.DB {
 
 // TryConnection, tries to connect to the database associated to the validator node. If it fails, it retries after 10 seconds.
 // This function will try to reconnect until it succeeds or the validator node gets stopped manually
-func tryConnection(connectionStr string) *gorm.DB {
+func tryConnection(open func(dsn string) gorm.Dialector, connectionStr string) *gorm.DB {
 	db, err := gorm.Open(
-		postgres.Open(connectionStr),
+		open(connectionStr),
 		&gorm.Config{
 			Logger: logger.Default.LogMode(logger.Silent),
 		},
@@ -65,21 +84,24 @@ func tryConnection(connectionStr string) *gorm.DB {
 		log.Error(err)
 		time.Sleep(10 * time.Second)
 		log.Infof("Retrying to connect to DB with connection string [%s]", connectionStr)
-		return tryConnection(connectionStr)
+		return tryConnection(open, connectionStr)
 	}
 	return db
 }
 
-// Migrate tables
-func migrateDb(db *gorm.DB) {
-	err := db.AutoMigrate(
-		entity.BurnEvent{},
-		entity.Transfer{},
-		entity.Fee{},
-		entity.Message{},
-		entity.Status{})
+// Applies the pending migrations, if enabled, and refuses to run against a schema of unknown or outdated version
+func migrateDb(db *gorm.DB, autoMigrate bool) {
+	migrator := migration.New(db)
+	if autoMigrate {
+		err := migrator.Up()
+		if err != nil {
+			log.Fatalf("Failed to migrate Database. Error: [%s]", err)
+		}
+	}
+
+	err := migrator.Check()
 	if err != nil {
-		log.Fatal(err)
+		log.Fatalf("Unsupported Database schema. Run the `migrate` command. Error: [%s]", err)
 	}
 	log.Println("Migrations passed successfully")
 }
@@ -87,6 +109,6 @@ func migrateDb(db *gorm.DB) {
 // Connect and Migrate
 func ConnectWithMigration(config config.Database) *gorm.DB {
 	gorm := Connect(config)
-	migrateDb(gorm)
+	migrateDb(gorm, config.AutoMigrate)
 	return gorm
 }
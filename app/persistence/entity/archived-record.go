@@ -0,0 +1,25 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+// ArchivedRecord references the archive file of a terminal record, which is pruned from the database
+type ArchivedRecord struct {
+	ID         string `gorm:"primaryKey"` // id of the transfer or the burn event
+	Kind       string
+	File       string // name of the file in the archive directory
+	ArchivedAt int64  // time (in nanoseconds) at which the record was archived
+}
@@ -23,9 +23,13 @@ import (
 type BurnEvent struct {
 	Id            string `gorm:"primaryKey"` // represents {ethTxHash}-{logIndex}
 	ScheduleID    string
-	Amount        int64
+	Amount        string
 	Recipient     string
-	Status        string
+	NativeAsset   string
+	WrappedAsset  string
+	Timestamp     int64          `gorm:"index"` // timestamp (in seconds) of the block, in which the burn occurred
+	Status        string         `gorm:"index"`
 	TransactionId sql.NullString `gorm:"unique"` // id of the original scheduled transaction
+	Violation     string         // asset policy limit, for which the burn event is held
 	Fee           Fee            `gorm:"foreignKey:BurnEventID"`
 }
@@ -16,6 +16,8 @@
 
 package burn_event
 
+import status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
+
 const (
 	// StatusCompleted is a status set once the BurnEvent operation is successfully completed.
 	// This is a terminal status
@@ -27,4 +29,16 @@ const (
 	StatusInitial = "INITIAL"
 	// StatusSubmitted is set once the Hedera Scheduled Transaction (Create/Sign)
 	StatusSubmitted = "SUBMITTED"
+	// StatusHeld is set when the BurnEvent is outside of the asset policy.
+	// The BurnEvent is held for manual review
+	StatusHeld = "HELD"
 )
+
+// Transitions are the allowed transitions of the Status of a BurnEvent.
+// Failed burn events are submitted again, once they are executed manually
+var Transitions = status_transition.Machine{
+	StatusInitial:   {StatusSubmitted, StatusFailed},
+	StatusHeld:      {StatusSubmitted, StatusFailed},
+	StatusFailed:    {StatusSubmitted, StatusFailed},
+	StatusSubmitted: {StatusCompleted, StatusFailed},
+}
@@ -0,0 +1,27 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+// ControlAction is a signed action of a Bridge member, pausing or resuming the bridge operations, which was applied by the validator
+type ControlAction struct {
+	Nonce     int64 `gorm:"primaryKey;autoIncrement:false"` // time (in nanoseconds) at which the action was issued
+	Action    string
+	Reason    string
+	Signer    string
+	Signature string
+	Timestamp int64 // consensus timestamp of the control message
+}
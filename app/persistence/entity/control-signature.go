@@ -0,0 +1,28 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+// ControlSignature is the signature of a Bridge member on a control action. Actions, which require the signatures of
+// more than one member, are applied once enough of them are collected
+type ControlSignature struct {
+	Nonce     int64  `gorm:"primaryKey;autoIncrement:false"` // time (in nanoseconds) at which the action was proposed
+	Action    string `gorm:"primaryKey"`
+	Signer    string `gorm:"primaryKey"`
+	Reason    string
+	Signature string
+	Timestamp int64 // consensus timestamp of the control message
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+// Equivocation is the evidence of a Bridge member, which signed conflicting data for the same transfer
+type Equivocation struct {
+	ID              uint64 `gorm:"primaryKey"`
+	TransferID      string `gorm:"index"`
+	Signer          string `gorm:"index"`
+	FirstSignature  string `gorm:"uniqueIndex:idx_equivocation_signatures"`
+	FirstHash       string
+	FirstTimestamp  int64
+	SecondSignature string `gorm:"uniqueIndex:idx_equivocation_signatures"`
+	SecondHash      string
+	SecondTimestamp int64
+}
@@ -0,0 +1,26 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+// FeeSettlement is the scheduled transaction, which settles the accrued fees of an asset's batch. Its ID is the memo of the
+// scheduled transaction. Fees accrued for a batch after its settlement are moved to the next batch, which is not settled
+type FeeSettlement struct {
+	ID            string `gorm:"primaryKey"`
+	NativeAsset   string
+	Batch         int64
+	TransactionID string
+}
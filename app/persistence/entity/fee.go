@@ -18,11 +18,18 @@ package entity
 
 import "database/sql"
 
+// Fee represents the fee paid out to the validators for a bridge operation.
+// Fees which are not paid out through their own scheduled transaction (zero and
+// accrued fees) have no ScheduleID and use the ID of the originating operation as TransactionID.
+// Accrued fees are paid out with the settlement of their Batch, referenced by SettlementID (the ID of the FeeSettlement)
 type Fee struct {
-	TransactionID string `gorm:"primaryKey"`
-	ScheduleID    string `gorm:"unique"`
+	TransactionID string         `gorm:"primaryKey"`
+	ScheduleID    sql.NullString `gorm:"unique"`
 	Amount        string
-	Status        string
-	TransferID    sql.NullString
-	BurnEventID   sql.NullString
+	Status        string         `gorm:"index"`
+	TransferID    sql.NullString `gorm:"index"`
+	BurnEventID   sql.NullString `gorm:"index"`
+	NativeAsset   string
+	Batch         int64
+	SettlementID  sql.NullString
 }
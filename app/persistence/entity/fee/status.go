@@ -16,6 +16,8 @@
 
 package fee
 
+import status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
+
 const (
 	// StatusCompleted is a status set once the Fee operation is successfully completed.
 	// This is a terminal status
@@ -25,4 +27,13 @@ const (
 	StatusFailed = "FAILED"
 	// StatusSubmitted is set when a pending Fee operation is created.
 	StatusSubmitted = "SUBMITTED"
+	// StatusAccrued is set when the Fee is owed to the validators and is waiting for the settlement of its batch.
+	StatusAccrued = "ACCRUED"
 )
+
+// Transitions are the allowed transitions of the Status of a Fee. Accrued fees are submitted with their settlement
+// and are accrued again, if the settlement fails
+var Transitions = status_transition.Machine{
+	StatusAccrued:   {StatusSubmitted, StatusFailed},
+	StatusSubmitted: {StatusCompleted, StatusFailed, StatusAccrued},
+}
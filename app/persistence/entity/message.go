@@ -17,8 +17,8 @@
 package entity
 
 type Message struct {
-	TransferID           string
-	Transfer             Transfer `gorm:"foreignKey:TransferID;references:TransactionID;"`
+	TransferID           string   `gorm:"index"`
+	Transfer             Transfer `gorm:"foreignKey:TransferID;references:TransactionID;" json:"-"`
 	Hash                 string
 	Signature            string `gorm:"unique"`
 	Signer               string
@@ -0,0 +1,27 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+// PendingMessage is a signature message, received before its transfer is ready to be processed.
+// It is kept until the transfer is ready or until it expires
+type PendingMessage struct {
+	Signature            string `gorm:"primaryKey"`
+	TransferID           string `gorm:"index"`
+	Message              []byte // the protobuf encoded signature message
+	TransactionTimestamp int64
+	ExpiresAt            int64
+}
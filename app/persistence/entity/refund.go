@@ -0,0 +1,32 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+import "database/sql"
+
+// Refund represents the return of a rejected deposit to the bridge account back to its sender
+type Refund struct {
+	TransferID    string `gorm:"primaryKey"` // id of the rejected deposit
+	Sender        string
+	NativeAsset   string
+	Amount        string // refunded amount, after the refund fee
+	Fee           string
+	Reason        string
+	Status        string
+	ScheduleID    string
+	TransactionID sql.NullString `gorm:"unique"` // id of the scheduled transaction
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+// RejectedMessage is a signature message, which was not accepted by the validator.
+// Kept as a durable trace of validators, submitting invalid signatures
+type RejectedMessage struct {
+	ID                   uint64 `gorm:"primaryKey"`
+	TransferID           string `gorm:"index"`
+	Signature            string
+	Signer               string `gorm:"index"`
+	Reason               string
+	RouterAddress        string
+	Receiver             string
+	Amount               string
+	WrappedAsset         string
+	TransactionTimestamp int64
+}
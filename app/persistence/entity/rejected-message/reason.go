@@ -0,0 +1,30 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rejected_message
+
+const (
+	// ReasonSanityMismatch is set when the signed data does not match the transfer, processed by the validator
+	ReasonSanityMismatch = "SANITY_MISMATCH"
+	// ReasonNonMember is set when the signature is not signed by a Bridge member
+	ReasonNonMember = "NON_MEMBER_SIGNER"
+	// ReasonDecodeFailure is set when the message or its signature could not be decoded
+	ReasonDecodeFailure = "DECODE_FAILURE"
+	// ReasonDuplicate is set when the signature was already received
+	ReasonDuplicate = "DUPLICATE"
+	// ReasonEquivocation is set when the signer already signed different data for the same transfer
+	ReasonEquivocation = "EQUIVOCATION"
+)
@@ -0,0 +1,29 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+// StatusTransition is a change of the status of a transfer, burn event, fee or refund
+type StatusTransition struct {
+	ID        uint64 `gorm:"primaryKey"`
+	Kind      string `gorm:"index:idx_status_transitions_record"`
+	RecordID  string `gorm:"index:idx_status_transitions_record"` // id of the transfer, burn event, fee or refund
+	From      string `gorm:"column:from_status"`
+	To        string `gorm:"column:to_status"`
+	Actor     string // component, which changed the status
+	Reason    string
+	Timestamp int64 // time (in nanoseconds) of the transition
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status_transition
+
+const (
+	// KindTransfer is a transition of the status of a transfer
+	KindTransfer = "TRANSFER"
+	// KindTransferSignature is a transition of the status of the signature message of a transfer
+	KindTransferSignature = "TRANSFER_SIGNATURE"
+	// KindBurnEvent is a transition of the status of a burn event
+	KindBurnEvent = "BURN_EVENT"
+	// KindFee is a transition of the status of a fee
+	KindFee = "FEE"
+	// KindRefund is a transition of the status of a refund
+	KindRefund = "REFUND"
+)
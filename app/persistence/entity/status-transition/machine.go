@@ -0,0 +1,30 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status_transition
+
+// Machine declares the allowed transitions of a status by the status, from which they start
+type Machine map[string][]string
+
+// Allowed returns whether the status can be changed from the given status to the target one
+func (m Machine) Allowed(from, to string) bool {
+	for _, status := range m[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
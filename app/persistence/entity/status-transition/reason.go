@@ -0,0 +1,37 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status_transition
+
+// Reasons of the status transitions
+const (
+	// ReasonScheduledTxSubmitted is a transition once the scheduled transaction of the operation is submitted
+	ReasonScheduledTxSubmitted = "SCHEDULED_TX_SUBMITTED"
+	// ReasonScheduledTxSubmissionFailed is a transition once the scheduled transaction of the operation cannot be submitted
+	ReasonScheduledTxSubmissionFailed = "SCHEDULED_TX_SUBMISSION_FAILED"
+	// ReasonScheduledTxExecuted is a transition once the scheduled transaction of the operation is executed
+	ReasonScheduledTxExecuted = "SCHEDULED_TX_EXECUTED"
+	// ReasonScheduledTxFailed is a transition once the execution of the scheduled transaction of the operation fails
+	ReasonScheduledTxFailed = "SCHEDULED_TX_FAILED"
+	// ReasonSignatureSubmitted is a transition once the signature of the transfer is submitted to the topic
+	ReasonSignatureSubmitted = "SIGNATURE_SUBMITTED"
+	// ReasonSignatureMined is a transition once the submission of the signature is mined
+	ReasonSignatureMined = "SIGNATURE_MINED"
+	// ReasonSignatureFailed is a transition once the submission of the signature fails
+	ReasonSignatureFailed = "SIGNATURE_FAILED"
+	// ReasonMajorityReached is a transition once the transfer has the signatures of the majority of the members
+	ReasonMajorityReached = "MAJORITY_REACHED"
+)
@@ -16,6 +16,8 @@
 
 package entity
 
+// Transfer represents an incoming Hedera transfer. The eligible signers and the number of
+// signatures required for majority are snapshotted once the transfer is created
 type Transfer struct {
 	TransactionID      string `gorm:"primaryKey"`
 	Receiver           string
@@ -23,8 +25,12 @@ type Transfer struct {
 	WrappedAsset       string
 	Amount             string
 	RouterAddress      string
-	Status             string
+	Status             string `gorm:"index"`
 	SignatureMsgStatus string
+	RequiredSignatures int
+	EligibleSigners    string    // comma separated Ethereum addresses of the members
+	Timestamp          int64     `gorm:"index"` // valid start (in seconds) of the transaction
+	Violation          string    // asset policy limit, for which the transfer is held
 	Messages           []Message `gorm:"foreignKey:TransferID"`
 	Fee                Fee       `gorm:"foreignKey:TransferID"`
 }
@@ -1,5 +1,7 @@
 package transfer
 
+import status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
+
 // Transfer Statuses
 const (
 	// StatusInitial is the first status on Transfer Record creation
@@ -13,6 +15,12 @@ const (
 	// StatusRecovered is a status set when a transfer has not been processed yet,
 	// but has been found by the recovery service
 	StatusRecovered = "RECOVERED"
+	// StatusHeld is a status set when a transfer is outside of the asset policy.
+	// The transfer is held for manual review
+	StatusHeld = "HELD"
+	// StatusRejected is a status set when a deposit is not a valid transfer (f.e. invalid memo or unsupported asset).
+	// The deposit is refunded to its sender. This is a terminal status
+	StatusRejected = "REJECTED"
 	// StatusSignatureSubmitted is a SignatureStatus set once the signature is submitted to HCS
 	StatusSignatureSubmitted = "SIGNATURE_SUBMITTED"
 	// StatusSignatureMined is a SignatureStatus set once the signature submission TX is successfully mined.
@@ -22,3 +30,21 @@ const (
 	// This is a terminal status
 	StatusSignatureFailed = "SIGNATURE_FAILED"
 )
+
+// Transitions are the allowed transitions of the Status of a Transfer. Completed transfers are completed again
+// by every signature after the majority
+var Transitions = status_transition.Machine{
+	StatusInitial:    {StatusInProgress, StatusCompleted},
+	StatusRecovered:  {StatusInProgress, StatusCompleted},
+	StatusHeld:       {StatusInProgress, StatusCompleted},
+	StatusInProgress: {StatusInProgress, StatusCompleted},
+	StatusCompleted:  {StatusCompleted},
+}
+
+// SignatureTransitions are the allowed transitions of the SignatureMsgStatus of a Transfer.
+// Failed signatures are submitted again, once the transfer is reprocessed
+var SignatureTransitions = status_transition.Machine{
+	"":                       {StatusSignatureSubmitted},
+	StatusSignatureSubmitted: {StatusSignatureSubmitted, StatusSignatureMined, StatusSignatureFailed},
+	StatusSignatureFailed:    {StatusSignatureSubmitted},
+}
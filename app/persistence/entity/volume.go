@@ -0,0 +1,27 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+// Volume is the amount of an accepted bridge operation, accounted in the daily caps of its native asset and direction
+type Volume struct {
+	ID          string `gorm:"primaryKey"` // id of the transfer or the burn event
+	NativeAsset string `gorm:"index:idx_volume_asset_timestamp"`
+	Direction   string
+	Receiver    string
+	Amount      string
+	Timestamp   int64 `gorm:"index:idx_volume_asset_timestamp"` // timestamp (in seconds) of the operation
+}
@@ -0,0 +1,31 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+// WebhookDelivery is the delivery of a lifecycle event of a transfer or burn event to a webhook subscription
+type WebhookDelivery struct {
+	ID           uint64 `gorm:"primaryKey"`
+	URL          string // url of the subscription
+	Event        string
+	RecordID     string `gorm:"index"` // id of the transfer or burn event
+	Payload      string // the signed JSON body
+	Status       string `gorm:"index"`
+	Attempts     int
+	ResponseCode int    // HTTP status code of the last attempt
+	Error        string // error of the last attempt
+	Timestamp    int64  // time (in nanoseconds) of the event
+}
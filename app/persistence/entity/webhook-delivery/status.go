@@ -0,0 +1,28 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook_delivery
+
+const (
+	// StatusPending is the status of a delivery, which is not yet accepted by the subscription and is retried
+	StatusPending = "PENDING"
+	// StatusDelivered is set once the subscription responds with a successful status code.
+	// This is a terminal status
+	StatusDelivered = "DELIVERED"
+	// StatusFailed is set once all attempts of the delivery fail.
+	// This is a terminal status
+	StatusFailed = "FAILED"
+)
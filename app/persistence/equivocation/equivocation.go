@@ -0,0 +1,63 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package equivocation
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	dbClient *gorm.DB
+}
+
+func NewRepository(dbClient *gorm.DB) *Repository {
+	return &Repository{
+		dbClient: dbClient,
+	}
+}
+
+// Create persists the equivocation. Already recorded equivocations are ignored
+func (r Repository) Create(equivocation *entity.Equivocation) error {
+	return r.dbClient.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(equivocation).
+		Error
+}
+
+// Get returns the equivocations, ordered by the consensus timestamp of the conflicting message.
+// Filters by transfer and signer, unless they are empty
+func (r Repository) Get(transferID, signer string) ([]entity.Equivocation, error) {
+	query := r.dbClient.Model(&entity.Equivocation{})
+	if transferID != "" {
+		query = query.Where("transfer_id = ?", transferID)
+	}
+	if signer != "" {
+		query = query.Where("lower(signer) = lower(?)", signer)
+	}
+
+	var equivocations []entity.Equivocation
+	err := query.
+		Order("second_timestamp").
+		Find(&equivocations).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return equivocations, nil
+}
@@ -17,12 +17,16 @@
 package fee
 
 import (
+	"database/sql"
 	"errors"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/status-transition"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository struct {
@@ -59,22 +63,157 @@ func (r Repository) Create(entity *entity.Fee) error {
 	return r.dbClient.Create(entity).Error
 }
 
-func (r Repository) UpdateStatusCompleted(txId string) error {
-	return r.updateStatus(txId, fee.StatusCompleted)
+// UpdateStatusCompleted completes a submitted fee
+func (r Repository) UpdateStatusCompleted(txId string, cause repository.Cause) error {
+	return r.updateStatus(txId, fee.StatusCompleted, cause)
 }
 
-func (r Repository) UpdateStatusFailed(txId string) error {
-	return r.updateStatus(txId, fee.StatusFailed)
+// UpdateStatusFailed fails a submitted fee
+func (r Repository) UpdateStatusFailed(txId string, cause repository.Cause) error {
+	return r.updateStatus(txId, fee.StatusFailed, cause)
 }
 
-func (r Repository) updateStatus(txId string, status string) error {
+// GetSubmitted returns all fees, which are waiting for the outcome of their or their settlement's scheduled transaction
+func (r Repository) GetSubmitted() ([]*entity.Fee, error) {
+	var fees []*entity.Fee
 	err := r.dbClient.
 		Model(entity.Fee{}).
-		Where("transaction_id = ?", txId).
-		UpdateColumn("status", status).
+		Where("status = ?", fee.StatusSubmitted).
+		Find(&fees).
 		Error
+	if err != nil {
+		return nil, err
+	}
+	return fees, nil
+}
+
+// GetAccruedBatches returns the batches with accrued fees, which started before the given batch, ordered by asset and batch
+func (r Repository) GetAccruedBatches(before int64) ([]repository.FeeBatch, error) {
+	var batches []repository.FeeBatch
+	err := r.dbClient.
+		Model(entity.Fee{}).
+		Select("native_asset, batch").
+		Where("status = ? and batch < ?", fee.StatusAccrued, before).
+		Group("native_asset, batch").
+		Order("native_asset, batch").
+		Scan(&batches).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+// GetAccruedInBatch returns the accrued fees of the asset's batch, ordered by transaction id
+func (r Repository) GetAccruedInBatch(nativeAsset string, batch int64) ([]*entity.Fee, error) {
+	var fees []*entity.Fee
+	err := r.dbClient.
+		Model(entity.Fee{}).
+		Where("status = ? and native_asset = ? and batch = ?", fee.StatusAccrued, nativeAsset, batch).
+		Order("transaction_id").
+		Find(&fees).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return fees, nil
+}
+
+// UpdateSettlementSubmitted marks the accrued fees as submitted with the scheduled transaction settling them
+// and records the settlement of their batch. Already recorded settlements are kept
+func (r Repository) UpdateSettlementSubmitted(txIds []string, settlement *entity.FeeSettlement, cause repository.Cause) error {
+	return r.dbClient.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(settlement).
+			Error
+		if err != nil {
+			return err
+		}
+		return NewRepository(tx).updateSettlement(txIds, settlement.ID, fee.StatusSubmitted, cause)
+	})
+}
+
+// UpdateSettlementAccrued returns the submitted fees of a failed settlement to accrued, so that they are settled again
+func (r Repository) UpdateSettlementAccrued(txIds []string, cause repository.Cause) error {
+	return r.updateSettlement(txIds, "", fee.StatusAccrued, cause)
+}
+
+// GetSettlement returns the settlement with the given id. Returns nil if not found
+func (r Repository) GetSettlement(id string) (*entity.FeeSettlement, error) {
+	settlement := &entity.FeeSettlement{}
+	result := r.dbClient.
+		Where("id = ?", id).
+		First(settlement)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return settlement, nil
+}
+
+// UpdateBatch moves the accrued fees to the given batch
+func (r Repository) UpdateBatch(txIds []string, batch int64) error {
+	err := r.dbClient.
+		Model(entity.Fee{}).
+		Where("transaction_id IN ? AND status = ?", txIds, fee.StatusAccrued).
+		Update("batch", batch).
+		Error
+	if err == nil {
+		r.logger.Debugf("Moved [%d] accrued fees to batch [%d]", len(txIds), batch)
+	}
+	return err
+}
+
+// UpdateSettlementCompleted completes the submitted fees of the settlement
+func (r Repository) UpdateSettlementCompleted(txIds []string, cause repository.Cause) error {
+	err := status_transition.FeeStatus.Update(r.dbClient, txIds, fee.StatusCompleted, cause, nil)
+	if err == nil {
+		r.logger.Debugf("Updated Status of [%d] settled fees to [%s]", len(txIds), fee.StatusCompleted)
+	}
+	return err
+}
+
+// updateSettlement updates the fees of the settlement. Either all of the fees are updated, or none of them is
+func (r Repository) updateSettlement(txIds []string, settlementID, status string, cause repository.Cause) error {
+	err := status_transition.FeeStatus.Update(r.dbClient, txIds, status, cause, map[string]interface{}{
+		"settlement_id": sql.NullString{String: settlementID, Valid: settlementID != ""},
+	})
+	if err == nil {
+		r.logger.Debugf("[%s] - Updated Status of [%d] settled fees to [%s]", settlementID, len(txIds), status)
+	}
+	return err
+}
+
+func (r Repository) updateStatus(txId string, status string, cause repository.Cause) error {
+	err := status_transition.FeeStatus.Update(r.dbClient, []string{txId}, status, cause, nil)
 	if err == nil {
 		r.logger.Debugf("[%s] - Updated Status to [%s]", txId, status)
 	}
 	return err
 }
+
+// CountByStatus returns the number of fees by status
+func (r Repository) CountByStatus() (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := r.dbClient.
+		Model(entity.Fee{}).
+		Select("status, count(*) as count").
+		Group("status").
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
@@ -18,8 +18,10 @@ package message
 
 import (
 	"errors"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository struct {
@@ -55,8 +57,18 @@ func (m Repository) Exist(transferID, signature, hash string) (bool, error) {
 	return true, nil
 }
 
+// Create persists the message. Returns repository.ErrDuplicate, if the signature was already persisted
 func (m Repository) Create(message *entity.Message) error {
-	return m.dbClient.Create(message).Error
+	result := m.dbClient.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(message)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrDuplicate
+	}
+	return nil
 }
 
 func (m Repository) Get(transferID string) ([]entity.Message, error) {
@@ -0,0 +1,220 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Migration is a versioned change of the database schema. Up applies the change and Down reverts it
+type Migration struct {
+	Version     int64
+	Description string
+	Up          string
+	Down        string
+}
+
+// schemaMigration is a record of an applied migration
+type schemaMigration struct {
+	Version     int64 `gorm:"primaryKey;autoIncrement:false"`
+	Description string
+	AppliedAt   int64 // time (in nanoseconds) at which the migration was applied
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint PRIMARY KEY,
+	description text NOT NULL,
+	applied_at bigint NOT NULL
+)`
+
+// Migrator applies and reverts the migrations, recording the applied versions in the `schema_migrations` table
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	logger     *log.Entry
+}
+
+// New returns a migrator of the validator migrations for the dialect of the database
+func New(db *gorm.DB) *Migrator {
+	if db.Dialector.Name() == "sqlite" {
+		return NewWith(db, sqliteMigrations)
+	}
+	return NewWith(db, migrations)
+}
+
+// NewWith returns a migrator of the given migrations, which must be in ascending order of their versions
+func NewWith(db *gorm.DB, migrations []Migration) *Migrator {
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version <= migrations[i-1].Version {
+			log.Fatalf("Migration versions must be ascending: [%d] follows [%d].", migrations[i].Version, migrations[i-1].Version)
+		}
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		logger:     config.GetLoggerFor("Migrator"),
+	}
+}
+
+// Up applies the pending migrations in their order. Every migration is applied in its own transaction
+func (m *Migrator) Up() error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+	pending, err := pendingMigrations(m.migrations, applied)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range pending {
+		migration := migration
+		err = m.db.Transaction(func(tx *gorm.DB) error {
+			err := tx.Exec(migration.Up).Error
+			if err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{
+				Version:     migration.Version,
+				Description: migration.Description,
+				AppliedAt:   time.Now().UnixNano(),
+			}).Error
+		})
+		if err != nil {
+			m.logger.Errorf("Failed to apply migration [%d] [%s]. Error: [%s]", migration.Version, migration.Description, err)
+			return err
+		}
+		m.logger.Infof("Applied migration [%d] [%s]", migration.Version, migration.Description)
+	}
+	return nil
+}
+
+// Down reverts the last `steps` applied migrations in their reverse order
+func (m *Migrator) Down(steps int) error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+	_, err = pendingMigrations(m.migrations, applied)
+	if err != nil {
+		return err
+	}
+
+	for i := len(applied) - 1; i >= 0 && i >= len(applied)-steps; i-- {
+		migration := m.migration(applied[i])
+		err = m.db.Transaction(func(tx *gorm.DB) error {
+			err := tx.Exec(migration.Down).Error
+			if err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			m.logger.Errorf("Failed to revert migration [%d] [%s]. Error: [%s]", migration.Version, migration.Description, err)
+			return err
+		}
+		m.logger.Infof("Reverted migration [%d] [%s]", migration.Version, migration.Description)
+	}
+	return nil
+}
+
+// Version returns the version of the last applied migration. Returns 0, if none is applied
+func (m *Migrator) Version() (int64, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+	if len(applied) == 0 {
+		return 0, nil
+	}
+	return applied[len(applied)-1], nil
+}
+
+// Check returns an error, if the schema has unknown versions applied (f.e. by a newer validator) or pending migrations
+func (m *Migrator) Check() error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+	pending, err := pendingMigrations(m.migrations, applied)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return errors.New(fmt.Sprintf("[%d] pending migrations, latest version is [%d]", len(pending), pending[len(pending)-1].Version))
+	}
+	return nil
+}
+
+// applied returns the ascending versions of the applied migrations
+func (m *Migrator) applied() ([]int64, error) {
+	err := m.db.Exec(createSchemaMigrations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []int64
+	err = m.db.Model(&schemaMigration{}).Order("version").Pluck("version", &versions).Error
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (m *Migrator) migration(version int64) Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return Migration{}
+}
+
+// pendingMigrations returns the migrations, which are not applied. Returns an error, if any of the applied versions is unknown
+func pendingMigrations(migrations []Migration, applied []int64) ([]Migration, error) {
+	known := make(map[int64]bool)
+	for _, migration := range migrations {
+		known[migration.Version] = true
+	}
+
+	isApplied := make(map[int64]bool)
+	for _, version := range applied {
+		if !known[version] {
+			return nil, errors.New(fmt.Sprintf("unknown schema version [%d]", version))
+		}
+		isApplied[version] = true
+	}
+
+	var pending []Migration
+	for _, migration := range migrations {
+		if !isApplied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
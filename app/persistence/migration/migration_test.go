@@ -0,0 +1,270 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migration
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/test/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// testSchema is migrated up and down, so it must not be used by other tests
+const testSchema = "migration_test"
+
+// testDatabases connect to the databases of the supported drivers. Postgres is skipped, unless configured or installed
+var testDatabases = map[string]func(tb testing.TB) *gorm.DB{
+	"postgres": func(tb testing.TB) *gorm.DB {
+		return database.Connect(tb, testSchema)
+	},
+	"sqlite": database.ConnectSQLite,
+}
+
+var testMigrations = []Migration{
+	{Version: 1, Description: "first", Up: "CREATE TABLE first (id text)", Down: "DROP TABLE first"},
+	{Version: 2, Description: "second", Up: "CREATE TABLE second (id text)", Down: "DROP TABLE second"},
+	{Version: 5, Description: "third", Up: "CREATE TABLE third (id text)", Down: "DROP TABLE third"},
+}
+
+func Test_PendingMigrations(t *testing.T) {
+	pending, err := pendingMigrations(testMigrations, []int64{1, 2})
+
+	assert.Nil(t, err)
+	assert.Equal(t, testMigrations[2:], pending)
+}
+
+func Test_PendingMigrationsNone(t *testing.T) {
+	pending, err := pendingMigrations(testMigrations, []int64{1, 2, 5})
+
+	assert.Nil(t, err)
+	assert.Empty(t, pending)
+}
+
+func Test_PendingMigrationsUnknownVersion(t *testing.T) {
+	pending, err := pendingMigrations(testMigrations, []int64{1, 2, 5, 6})
+
+	assert.Nil(t, pending)
+	assert.EqualError(t, err, "unknown schema version [6]")
+}
+
+func Test_Migrations(t *testing.T) {
+	for i := 1; i < len(migrations); i++ {
+		assert.Greater(t, migrations[i].Version, migrations[i-1].Version)
+	}
+	for _, migration := range migrations {
+		assert.NotEmpty(t, migration.Description)
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+	}
+}
+
+func Test_SQLiteMigrations(t *testing.T) {
+	assert.Len(t, sqliteMigrations, len(migrations))
+	for i, migration := range sqliteMigrations {
+		assert.Equal(t, migrations[i].Version, migration.Version)
+		assert.Equal(t, migrations[i].Description, migration.Description)
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+	}
+}
+
+func Test_UpAndDown(t *testing.T) {
+	for name, connect := range testDatabases {
+		t.Run(name, func(t *testing.T) {
+			db := connect(t)
+			migrator := New(db)
+			latest := migrations[len(migrations)-1].Version
+
+			err := migrator.Up()
+			assert.Nil(t, err)
+			assert.Nil(t, migrator.Check())
+			version, err := migrator.Version()
+			assert.Nil(t, err)
+			assert.Equal(t, latest, version)
+
+			// Applying again is a no-op
+			assert.Nil(t, migrator.Up())
+
+			err = migrator.Down(len(migrations))
+			assert.Nil(t, err)
+			version, err = migrator.Version()
+			assert.Nil(t, err)
+			assert.Equal(t, int64(0), version)
+			assert.Error(t, migrator.Check())
+
+			assert.Nil(t, migrator.Up())
+		})
+	}
+}
+
+// Test_SchemaMatchesEntities verifies that the migrated schema has the columns and indexes of the entities
+func Test_SchemaMatchesEntities(t *testing.T) {
+	entities := []interface{}{
+		entity.BurnEvent{},
+		entity.Transfer{},
+		entity.Fee{},
+		entity.FeeSettlement{},
+		entity.Message{},
+		entity.PendingMessage{},
+		entity.RejectedMessage{},
+		entity.Equivocation{},
+		entity.Volume{},
+		entity.Refund{},
+		entity.ControlAction{},
+		entity.ControlSignature{},
+		entity.Status{},
+		entity.ArchivedRecord{},
+		entity.StatusTransition{},
+		entity.WebhookDelivery{},
+	}
+
+	for name, connect := range testDatabases {
+		t.Run(name, func(t *testing.T) {
+			db := connect(t)
+			assert.Nil(t, New(db).Up())
+
+			for _, e := range entities {
+				statement := &gorm.Statement{DB: db}
+				assert.Nil(t, statement.Parse(e))
+				assert.True(t, db.Migrator().HasTable(e), statement.Schema.Table)
+				for _, field := range statement.Schema.Fields {
+					if field.DBName != "" {
+						assert.True(t, db.Migrator().HasColumn(e, field.DBName), "%s.%s", statement.Schema.Table, field.DBName)
+					}
+				}
+				for name := range statement.Schema.ParseIndexes() {
+					assert.True(t, db.Migrator().HasIndex(e, name), "%s.%s", statement.Schema.Table, name)
+				}
+			}
+		})
+	}
+}
+
+// The entities of the released baseline, whose schema was created by their automatic migration
+type baselineBurnEvent struct {
+	Id            string `gorm:"primaryKey"`
+	ScheduleID    string
+	Amount        int64
+	Recipient     string
+	Status        string
+	TransactionId sql.NullString `gorm:"unique"`
+	Fee           baselineFee    `gorm:"foreignKey:BurnEventID"`
+}
+
+func (baselineBurnEvent) TableName() string { return "burn_events" }
+
+type baselineTransfer struct {
+	TransactionID      string `gorm:"primaryKey"`
+	Receiver           string
+	NativeAsset        string
+	WrappedAsset       string
+	Amount             string
+	RouterAddress      string
+	Status             string
+	SignatureMsgStatus string
+	Messages           []baselineMessage `gorm:"foreignKey:TransferID"`
+	Fee                baselineFee       `gorm:"foreignKey:TransferID"`
+}
+
+func (baselineTransfer) TableName() string { return "transfers" }
+
+type baselineFee struct {
+	TransactionID string `gorm:"primaryKey"`
+	ScheduleID    string `gorm:"unique"`
+	Amount        string
+	Status        string
+	TransferID    sql.NullString
+	BurnEventID   sql.NullString
+}
+
+func (baselineFee) TableName() string { return "fees" }
+
+type baselineMessage struct {
+	TransferID           string
+	Transfer             baselineTransfer `gorm:"foreignKey:TransferID;references:TransactionID;"`
+	Hash                 string
+	Signature            string `gorm:"unique"`
+	Signer               string
+	TransactionTimestamp int64
+}
+
+func (baselineMessage) TableName() string { return "messages" }
+
+type baselineStatus struct {
+	Name      string
+	EntityID  string
+	Code      string
+	Timestamp int64
+}
+
+func (baselineStatus) TableName() string { return "statuses" }
+
+// Test_UpgradeBaselineSchema verifies that the migrations upgrade a schema created by the baseline entities
+func Test_UpgradeBaselineSchema(t *testing.T) {
+	for name, connect := range testDatabases {
+		t.Run(name, func(t *testing.T) {
+			db := connect(t)
+			assert.Nil(t, New(db).Down(len(migrations)))
+			assert.Nil(t, db.AutoMigrate(baselineBurnEvent{}, baselineTransfer{}, baselineFee{}, baselineMessage{}, baselineStatus{}))
+
+			burnEvent := baselineBurnEvent{Id: "0xhash-1", Amount: 100, Status: "COMPLETED"}
+			assert.Nil(t, db.Create(&burnEvent).Error)
+			transfer := baselineTransfer{TransactionID: "0.0.1-1614767470-000000001", Amount: "100", Status: "COMPLETED"}
+			assert.Nil(t, db.Create(&transfer).Error)
+			fee := baselineFee{TransactionID: "0.0.1-1614767471-000000001", ScheduleID: "0.0.2", Amount: "10",
+				TransferID: sql.NullString{String: transfer.TransactionID, Valid: true}}
+			assert.Nil(t, db.Create(&fee).Error)
+
+			assert.Nil(t, New(db).Up())
+			assert.Nil(t, New(db).Check())
+
+			var upgradedBurnEvent entity.BurnEvent
+			assert.Nil(t, db.First(&upgradedBurnEvent, "id = ?", burnEvent.Id).Error)
+			assert.Equal(t, "100", upgradedBurnEvent.Amount)
+			var upgradedTransfer entity.Transfer
+			assert.Nil(t, db.First(&upgradedTransfer, "transaction_id = ?", transfer.TransactionID).Error)
+			assert.Equal(t, "100", upgradedTransfer.Amount)
+			assert.Empty(t, upgradedTransfer.EligibleSigners)
+			var upgradedFee entity.Fee
+			assert.Nil(t, db.First(&upgradedFee, "transaction_id = ?", fee.TransactionID).Error)
+			assert.Equal(t, transfer.TransactionID, upgradedFee.TransferID.String)
+
+			// The upgraded schema accepts amounts that do not fit in 64 bits
+			assert.Nil(t, db.Model(&upgradedBurnEvent).Update("amount", "100000000000000000000000").Error)
+		})
+	}
+}
+
+func Test_CheckUnknownVersion(t *testing.T) {
+	for name, connect := range testDatabases {
+		t.Run(name, func(t *testing.T) {
+			db := connect(t)
+			migrator := New(db)
+			assert.Nil(t, migrator.Up())
+
+			err := db.Create(&schemaMigration{Version: 1 << 40, Description: "newer validator"}).Error
+			assert.Nil(t, err)
+			defer db.Delete(&schemaMigration{}, int64(1<<40))
+
+			assert.EqualError(t, migrator.Check(), "unknown schema version [1099511627776]")
+			assert.Error(t, migrator.Up())
+		})
+	}
+}
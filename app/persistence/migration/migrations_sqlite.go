@@ -0,0 +1,315 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migration
+
+// sqliteMigrations are the migrations of the validator schema for SQLite. They have the versions and descriptions
+// of the Postgres migrations and differ only where SQLite does not support the Postgres syntax
+var sqliteMigrations = []Migration{
+	{
+		// The baseline schema. Identity columns are autoincremented integers and binary columns are blobs.
+		// SQLite databases are created by the migrations, never by the automatic migration of the baseline entities,
+		// so `burn_events.amount` is text from the start. SQLite cannot change the type of a column in place
+		Version:     1,
+		Description: "baseline schema",
+		Up: `
+CREATE TABLE IF NOT EXISTS transfers (
+	transaction_id text PRIMARY KEY,
+	receiver text,
+	native_asset text,
+	wrapped_asset text,
+	amount text,
+	router_address text,
+	status text,
+	signature_msg_status text
+);
+
+CREATE TABLE IF NOT EXISTS burn_events (
+	id text PRIMARY KEY,
+	schedule_id text,
+	amount text,
+	recipient text,
+	status text,
+	transaction_id text UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS fees (
+	transaction_id text PRIMARY KEY,
+	schedule_id text UNIQUE,
+	amount text,
+	status text,
+	transfer_id text,
+	burn_event_id text,
+	CONSTRAINT fk_transfers_fee FOREIGN KEY (transfer_id) REFERENCES transfers(transaction_id),
+	CONSTRAINT fk_burn_events_fee FOREIGN KEY (burn_event_id) REFERENCES burn_events(id)
+);
+
+CREATE TABLE IF NOT EXISTS messages (
+	transfer_id text,
+	hash text,
+	signature text UNIQUE,
+	signer text,
+	transaction_timestamp bigint,
+	CONSTRAINT fk_transfers_messages FOREIGN KEY (transfer_id) REFERENCES transfers(transaction_id)
+);
+
+CREATE TABLE IF NOT EXISTS statuses (
+	name text,
+	entity_id text,
+	code text,
+	timestamp bigint
+);`,
+		Down: `
+DROP TABLE IF EXISTS statuses;
+DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS fees;
+DROP TABLE IF EXISTS burn_events;
+DROP TABLE IF EXISTS transfers;`,
+	},
+	{
+		// Columns of the quorum snapshots, the asset and timestamp of burn events and the settlement of fees
+		Version:     2,
+		Description: "operation columns",
+		Up: `
+ALTER TABLE transfers ADD COLUMN required_signatures bigint;
+ALTER TABLE transfers ADD COLUMN eligible_signers text;
+ALTER TABLE burn_events ADD COLUMN native_asset text;
+ALTER TABLE burn_events ADD COLUMN wrapped_asset text;
+ALTER TABLE burn_events ADD COLUMN timestamp bigint;
+ALTER TABLE fees ADD COLUMN native_asset text;
+ALTER TABLE fees ADD COLUMN batch bigint;
+ALTER TABLE fees ADD COLUMN settlement_id text`,
+		Down: `
+ALTER TABLE fees DROP COLUMN settlement_id;
+ALTER TABLE fees DROP COLUMN batch;
+ALTER TABLE fees DROP COLUMN native_asset;
+ALTER TABLE burn_events DROP COLUMN timestamp;
+ALTER TABLE burn_events DROP COLUMN wrapped_asset;
+ALTER TABLE burn_events DROP COLUMN native_asset;
+ALTER TABLE transfers DROP COLUMN eligible_signers;
+ALTER TABLE transfers DROP COLUMN required_signatures`,
+	},
+	{
+		// Pending, rejected and equivocating messages, transfer volumes, refunds and control actions
+		Version:     3,
+		Description: "messages, volumes, refunds and control actions",
+		Up: `
+CREATE TABLE IF NOT EXISTS pending_messages (
+	signature text PRIMARY KEY,
+	transfer_id text,
+	message blob,
+	transaction_timestamp bigint,
+	expires_at bigint
+);
+CREATE INDEX IF NOT EXISTS idx_pending_messages_transfer_id ON pending_messages(transfer_id);
+
+CREATE TABLE IF NOT EXISTS rejected_messages (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	transfer_id text,
+	signature text,
+	signer text,
+	reason text,
+	router_address text,
+	receiver text,
+	amount text,
+	wrapped_asset text,
+	transaction_timestamp bigint
+);
+CREATE INDEX IF NOT EXISTS idx_rejected_messages_transfer_id ON rejected_messages(transfer_id);
+CREATE INDEX IF NOT EXISTS idx_rejected_messages_signer ON rejected_messages(signer);
+
+CREATE TABLE IF NOT EXISTS equivocations (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	transfer_id text,
+	signer text,
+	first_signature text,
+	first_hash text,
+	first_timestamp bigint,
+	second_signature text,
+	second_hash text,
+	second_timestamp bigint
+);
+CREATE INDEX IF NOT EXISTS idx_equivocations_transfer_id ON equivocations(transfer_id);
+CREATE INDEX IF NOT EXISTS idx_equivocations_signer ON equivocations(signer);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_equivocation_signatures ON equivocations(first_signature, second_signature);
+
+CREATE TABLE IF NOT EXISTS volumes (
+	id text PRIMARY KEY,
+	native_asset text,
+	receiver text,
+	amount text,
+	timestamp bigint
+);
+CREATE INDEX IF NOT EXISTS idx_volume_asset_timestamp ON volumes(native_asset, timestamp);
+
+CREATE TABLE IF NOT EXISTS refunds (
+	transfer_id text PRIMARY KEY,
+	sender text,
+	native_asset text,
+	amount text,
+	fee text,
+	reason text,
+	status text,
+	schedule_id text,
+	transaction_id text UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS control_actions (
+	nonce bigint PRIMARY KEY,
+	action text,
+	reason text,
+	signer text,
+	signature text,
+	timestamp bigint
+)`,
+		Down: `
+DROP TABLE IF EXISTS control_actions;
+DROP TABLE IF EXISTS refunds;
+DROP TABLE IF EXISTS volumes;
+DROP TABLE IF EXISTS equivocations;
+DROP TABLE IF EXISTS rejected_messages;
+DROP TABLE IF EXISTS pending_messages`,
+	},
+	{
+		// Indexes of the lookups by status and of the foreign keys, so that they do not scan the whole history.
+		// Lookups by `transaction_id`, `schedule_id` and `signature` are covered by their primary key and unique constraints
+		Version:     4,
+		Description: "status and foreign key indexes",
+		Up: `
+CREATE INDEX IF NOT EXISTS idx_transfers_status ON transfers(status);
+CREATE INDEX IF NOT EXISTS idx_messages_transfer_id ON messages(transfer_id);
+CREATE INDEX IF NOT EXISTS idx_fees_status ON fees(status);
+CREATE INDEX IF NOT EXISTS idx_fees_transfer_id ON fees(transfer_id);
+CREATE INDEX IF NOT EXISTS idx_fees_burn_event_id ON fees(burn_event_id);
+CREATE INDEX IF NOT EXISTS idx_burn_events_status ON burn_events(status);`,
+		Down: `
+DROP INDEX IF EXISTS idx_burn_events_status;
+DROP INDEX IF EXISTS idx_fees_burn_event_id;
+DROP INDEX IF EXISTS idx_fees_transfer_id;
+DROP INDEX IF EXISTS idx_fees_status;
+DROP INDEX IF EXISTS idx_messages_transfer_id;
+DROP INDEX IF EXISTS idx_transfers_status;`,
+	},
+	{
+		// Archival of terminal records. SQLite databases are created with this release, so there are no transfers to backfill
+		Version:     5,
+		Description: "archived records",
+		Up: `
+ALTER TABLE transfers ADD COLUMN timestamp bigint;
+CREATE INDEX IF NOT EXISTS idx_transfers_timestamp ON transfers(timestamp);
+CREATE INDEX IF NOT EXISTS idx_burn_events_timestamp ON burn_events(timestamp);
+
+CREATE TABLE IF NOT EXISTS archived_records (
+	id text PRIMARY KEY,
+	kind text,
+	file text,
+	archived_at bigint
+);`,
+		Down: `
+DROP TABLE IF EXISTS archived_records;
+DROP INDEX IF EXISTS idx_burn_events_timestamp;
+DROP INDEX IF EXISTS idx_transfers_timestamp;
+ALTER TABLE transfers DROP COLUMN timestamp;`,
+	},
+	{
+		// History of the status transitions of transfers, burn events, fees and refunds
+		Version:     6,
+		Description: "status transitions",
+		Up: `
+CREATE TABLE IF NOT EXISTS status_transitions (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	kind text,
+	record_id text,
+	from_status text,
+	to_status text,
+	actor text,
+	reason text,
+	timestamp bigint
+);
+CREATE INDEX IF NOT EXISTS idx_status_transitions_record ON status_transitions(kind, record_id);`,
+		Down: `
+DROP INDEX IF EXISTS idx_status_transitions_record;
+DROP TABLE IF EXISTS status_transitions;`,
+	},
+	{
+		Version:     7,
+		Description: "webhook deliveries",
+		Up: `
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	url text,
+	event text,
+	record_id text,
+	payload text,
+	status text,
+	attempts bigint,
+	response_code bigint,
+	error text,
+	timestamp bigint
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_record_id ON webhook_deliveries(record_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries(status);`,
+		Down: `
+DROP INDEX IF EXISTS idx_webhook_deliveries_status;
+DROP INDEX IF EXISTS idx_webhook_deliveries_record_id;
+DROP TABLE IF EXISTS webhook_deliveries;`,
+	},
+	{
+		// Signatures of the Bridge members on control actions, which are applied once enough of them are collected
+		Version:     8,
+		Description: "control signatures",
+		Up: `
+CREATE TABLE IF NOT EXISTS control_signatures (
+	nonce bigint,
+	action text,
+	signer text,
+	reason text,
+	signature text,
+	timestamp bigint,
+	PRIMARY KEY (nonce, action, signer)
+)`,
+		Down: `
+DROP TABLE IF EXISTS control_signatures`,
+	},
+	{
+		// Settlements of the batches of accrued fees, so that fees accrued late are not settled with a settled batch
+		Version:     9,
+		Description: "fee settlements",
+		Up: `
+CREATE TABLE IF NOT EXISTS fee_settlements (
+	id text PRIMARY KEY,
+	native_asset text,
+	batch bigint,
+	transaction_id text
+)`,
+		Down: `
+DROP TABLE IF EXISTS fee_settlements`,
+	},
+	{
+		// The violations of held operations and the directions of the volumes, accounted separately in the daily caps
+		Version:     10,
+		Description: "held violations and volume directions",
+		Up: `
+ALTER TABLE transfers ADD COLUMN violation text;
+ALTER TABLE burn_events ADD COLUMN violation text;
+ALTER TABLE volumes ADD COLUMN direction text;
+UPDATE volumes SET direction = CASE WHEN id LIKE '0x%' THEN 'EVM_TO_HEDERA' ELSE 'HEDERA_TO_EVM' END`,
+		Down: `
+ALTER TABLE volumes DROP COLUMN direction;
+ALTER TABLE burn_events DROP COLUMN violation;
+ALTER TABLE transfers DROP COLUMN violation`,
+	},
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pending_message
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	dbClient *gorm.DB
+}
+
+func NewRepository(dbClient *gorm.DB) *Repository {
+	return &Repository{
+		dbClient: dbClient,
+	}
+}
+
+// Create persists the pending message. Already pending messages are ignored
+func (r Repository) Create(message *entity.PendingMessage) error {
+	return r.dbClient.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(message).
+		Error
+}
+
+// Pop removes and returns the pending messages of the transfer
+func (r Repository) Pop(transferID string) ([]entity.PendingMessage, error) {
+	var messages []entity.PendingMessage
+	err := r.dbClient.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Where("transfer_id = ?", transferID).
+			Order("transaction_timestamp").
+			Find(&messages).
+			Error
+		if err != nil {
+			return err
+		}
+
+		return tx.
+			Where("transfer_id = ?", transferID).
+			Delete(&entity.PendingMessage{}).
+			Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// DeleteExpired removes and returns the pending messages, which expired before the given timestamp (in seconds)
+func (r Repository) DeleteExpired(now int64) ([]entity.PendingMessage, error) {
+	var messages []entity.PendingMessage
+	err := r.dbClient.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Where("expires_at < ?", now).
+			Find(&messages).
+			Error
+		if err != nil {
+			return err
+		}
+
+		return tx.
+			Where("expires_at < ?", now).
+			Delete(&entity.PendingMessage{}).
+			Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
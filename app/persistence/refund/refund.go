@@ -0,0 +1,94 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package refund
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/refund"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/status-transition"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	dbClient *gorm.DB
+}
+
+func NewRepository(dbClient *gorm.DB) *Repository {
+	return &Repository{
+		dbClient: dbClient,
+	}
+}
+
+func (r Repository) Create(refund *entity.Refund) error {
+	return r.dbClient.Create(refund).Error
+}
+
+// UpdateStatusSubmitted records the scheduled transaction of an initial refund
+func (r Repository) UpdateStatusSubmitted(transferID, scheduleID, transactionID string, cause repository.Cause) error {
+	return status_transition.RefundStatus.Update(r.dbClient, []string{transferID}, refund.StatusSubmitted, cause, map[string]interface{}{
+		"schedule_id":    scheduleID,
+		"transaction_id": sql.NullString{String: transactionID, Valid: true},
+	})
+}
+
+// UpdateStatusCompleted completes a submitted refund
+func (r Repository) UpdateStatusCompleted(transferID string, cause repository.Cause) error {
+	return r.updateStatus(transferID, refund.StatusCompleted, cause)
+}
+
+// UpdateStatusFailed fails an initial or submitted refund
+func (r Repository) UpdateStatusFailed(transferID string, cause repository.Cause) error {
+	return r.updateStatus(transferID, refund.StatusFailed, cause)
+}
+
+func (r Repository) updateStatus(transferID, status string, cause repository.Cause) error {
+	return status_transition.RefundStatus.Update(r.dbClient, []string{transferID}, status, cause, nil)
+}
+
+// Get returns the Refund of the given deposit. Returns nil if not found
+func (r Repository) Get(transferID string) (*entity.Refund, error) {
+	record := &entity.Refund{}
+	err := r.dbClient.
+		Model(entity.Refund{}).
+		Where("transfer_id = ?", transferID).
+		First(record).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return record, nil
+}
+
+// GetInitial returns the Refunds, which are not submitted yet
+func (r Repository) GetInitial() ([]*entity.Refund, error) {
+	var refunds []*entity.Refund
+	err := r.dbClient.
+		Where("status = ?", refund.StatusInitial).
+		Find(&refunds).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return refunds, nil
+}
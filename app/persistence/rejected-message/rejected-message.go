@@ -0,0 +1,77 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rejected_message
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	dbClient *gorm.DB
+}
+
+func NewRepository(dbClient *gorm.DB) *Repository {
+	return &Repository{
+		dbClient: dbClient,
+	}
+}
+
+func (r Repository) Create(message *entity.RejectedMessage) error {
+	return r.dbClient.Create(message).Error
+}
+
+// Get returns the rejected messages, ordered by consensus timestamp.
+// Filters by transfer and signer, unless they are empty
+func (r Repository) Get(transferID, signer string) ([]entity.RejectedMessage, error) {
+	var messages []entity.RejectedMessage
+	err := r.filter(transferID, signer).
+		Order("transaction_timestamp").
+		Find(&messages).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// GetPage returns up to `limit` rejected messages with consensus timestamp after `after`, ordered by consensus timestamp.
+// Filters by transfer and signer, unless they are empty
+func (r Repository) GetPage(transferID, signer string, after int64, limit int) ([]entity.RejectedMessage, error) {
+	var messages []entity.RejectedMessage
+	err := r.filter(transferID, signer).
+		Where("transaction_timestamp > ?", after).
+		Order("transaction_timestamp").
+		Limit(limit).
+		Find(&messages).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r Repository) filter(transferID, signer string) *gorm.DB {
+	query := r.dbClient.Model(&entity.RejectedMessage{})
+	if transferID != "" {
+		query = query.Where("transfer_id = ?", transferID)
+	}
+	if signer != "" {
+		query = query.Where("lower(signer) = lower(?)", signer)
+	}
+	return query
+}
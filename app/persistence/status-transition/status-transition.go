@@ -0,0 +1,209 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status_transition
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/refund"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"gorm.io/gorm"
+)
+
+var logger = config.GetLoggerFor("Status Transitions")
+
+// The status columns of the entities with their allowed transitions
+var (
+	TransferStatus = Status{
+		Kind:    status_transition.KindTransfer,
+		Model:   entity.Transfer{},
+		Key:     "transaction_id",
+		Column:  "status",
+		Machine: transfer.Transitions,
+	}
+	TransferSignatureStatus = Status{
+		Kind:    status_transition.KindTransferSignature,
+		Model:   entity.Transfer{},
+		Key:     "transaction_id",
+		Column:  "signature_msg_status",
+		Machine: transfer.SignatureTransitions,
+	}
+	BurnEventStatus = Status{
+		Kind:    status_transition.KindBurnEvent,
+		Model:   entity.BurnEvent{},
+		Key:     "id",
+		Column:  "status",
+		Machine: burn_event.Transitions,
+	}
+	FeeStatus = Status{
+		Kind:    status_transition.KindFee,
+		Model:   entity.Fee{},
+		Key:     "transaction_id",
+		Column:  "status",
+		Machine: fee.Transitions,
+	}
+	RefundStatus = Status{
+		Kind:    status_transition.KindRefund,
+		Model:   entity.Refund{},
+		Key:     "transfer_id",
+		Column:  "status",
+		Machine: refund.Transitions,
+	}
+)
+
+// Status describes a status column of an entity, which changes only by the transitions, declared by its Machine
+type Status struct {
+	Kind    string      // kind of the recorded transitions
+	Model   interface{} // entity of the status
+	Key     string      // primary key column of the entity
+	Column  string      // status column of the entity
+	Machine status_transition.Machine
+}
+
+// Update changes the status of the records to the given one together with the provided columns and records the transitions.
+// Returns repository.ErrIllegalTransition, if the transition of any record is not allowed, and repository.ErrStaleStatus,
+// if any record is not found or its status is changed concurrently. Either all records are updated, or none of them is.
+// Repeated transitions to the current status are allowed, if declared, but are neither applied, nor recorded
+func (s Status) Update(db *gorm.DB, ids []string, to string, cause repository.Cause, columns map[string]interface{}) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var records []struct {
+			ID     string
+			Status sql.NullString
+		}
+		err := tx.
+			Model(s.Model).
+			Select(fmt.Sprintf("%s AS id, %s AS status", s.Key, s.Column)).
+			Where(fmt.Sprintf("%s IN ?", s.Key), ids).
+			Scan(&records).
+			Error
+		if err != nil {
+			return err
+		}
+		if len(records) != len(ids) {
+			return repository.ErrStaleStatus
+		}
+
+		var from []string
+		byStatus := make(map[string][]string)
+		for _, r := range records {
+			if !s.Machine.Allowed(r.Status.String, to) {
+				logger.Debugf("[%s] - Illegal transition of [%s] from [%s] to [%s].", r.ID, s.Kind, r.Status.String, to)
+				return repository.ErrIllegalTransition
+			}
+			if r.Status.String == to {
+				continue
+			}
+			if _, ok := byStatus[r.Status.String]; !ok {
+				from = append(from, r.Status.String)
+			}
+			byStatus[r.Status.String] = append(byStatus[r.Status.String], r.ID)
+		}
+
+		values := map[string]interface{}{s.Column: to}
+		for column, value := range columns {
+			values[column] = value
+		}
+
+		// The records are updated by their current status, so that concurrent changes are detected
+		timestamp := time.Now().UnixNano()
+		var transitions []entity.StatusTransition
+		for _, status := range from {
+			result := tx.
+				Model(s.Model).
+				Where(fmt.Sprintf("%s IN ? AND %s = ?", s.Key, s.Column), byStatus[status], status).
+				UpdateColumns(values)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected != int64(len(byStatus[status])) {
+				return repository.ErrStaleStatus
+			}
+
+			for _, id := range byStatus[status] {
+				transitions = append(transitions, entity.StatusTransition{
+					Kind:      s.Kind,
+					RecordID:  id,
+					From:      status,
+					To:        to,
+					Actor:     cause.Actor,
+					Reason:    cause.Reason,
+					Timestamp: timestamp,
+				})
+			}
+		}
+
+		if len(transitions) == 0 {
+			return nil
+		}
+		return tx.Create(&transitions).Error
+	})
+}
+
+type Repository struct {
+	dbClient *gorm.DB
+}
+
+func NewRepository(dbClient *gorm.DB) *Repository {
+	return &Repository{
+		dbClient: dbClient,
+	}
+}
+
+// GetForTransfer returns the status transitions of the transfer, its signature, fee and refund, ordered by time
+func (r Repository) GetForTransfer(transferID string) ([]entity.StatusTransition, error) {
+	fees := r.dbClient.
+		Model(entity.Fee{}).
+		Select("transaction_id").
+		Where("transfer_id = ?", transferID)
+
+	return r.get(r.dbClient.
+		Where("kind IN ? AND record_id = ?",
+			[]string{status_transition.KindTransfer, status_transition.KindTransferSignature, status_transition.KindRefund}, transferID).
+		Or("kind = ? AND record_id IN (?)", status_transition.KindFee, fees))
+}
+
+// GetForBurnEvent returns the status transitions of the burn event and its fee, ordered by time
+func (r Repository) GetForBurnEvent(id string) ([]entity.StatusTransition, error) {
+	fees := r.dbClient.
+		Model(entity.Fee{}).
+		Select("transaction_id").
+		Where("burn_event_id = ?", id)
+
+	return r.get(r.dbClient.
+		Where("kind = ? AND record_id = ?", status_transition.KindBurnEvent, id).
+		Or("kind = ? AND record_id IN (?)", status_transition.KindFee, fees))
+}
+
+func (r Repository) get(query *gorm.DB) ([]entity.StatusTransition, error) {
+	var transitions []entity.StatusTransition
+	err := query.
+		Order("timestamp, id").
+		Find(&transitions).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
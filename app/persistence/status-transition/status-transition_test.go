@@ -0,0 +1,138 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status_transition
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/migration"
+	"github.com/limechain/hedera-eth-bridge-validator/test/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var cause = repository.Cause{Actor: "Test", Reason: "TEST"}
+
+func setup(t *testing.T) (*gorm.DB, *Repository) {
+	db := database.ConnectSQLite(t)
+	err := migration.New(db).Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, NewRepository(db)
+}
+
+func create(t *testing.T, db *gorm.DB, value interface{}) {
+	err := db.Create(value).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_UpdateRecordsTransitions(t *testing.T) {
+	db, repository := setup(t)
+	create(t, db, &entity.BurnEvent{Id: "burn", Status: burn_event.StatusInitial})
+
+	err := BurnEventStatus.Update(db, []string{"burn"}, burn_event.StatusSubmitted, cause, map[string]interface{}{
+		"transaction_id": sql.NullString{String: "fee", Valid: true},
+	})
+	assert.Nil(t, err)
+
+	record := &entity.BurnEvent{}
+	db.First(record, "id = ?", "burn")
+	assert.Equal(t, burn_event.StatusSubmitted, record.Status)
+	assert.Equal(t, "fee", record.TransactionId.String)
+
+	transitions, err := repository.GetForBurnEvent("burn")
+	assert.Nil(t, err)
+	assert.Len(t, transitions, 1)
+	assert.Equal(t, status_transition.KindBurnEvent, transitions[0].Kind)
+	assert.Equal(t, "burn", transitions[0].RecordID)
+	assert.Equal(t, burn_event.StatusInitial, transitions[0].From)
+	assert.Equal(t, burn_event.StatusSubmitted, transitions[0].To)
+	assert.Equal(t, cause.Actor, transitions[0].Actor)
+	assert.Equal(t, cause.Reason, transitions[0].Reason)
+	assert.NotZero(t, transitions[0].Timestamp)
+}
+
+func Test_UpdateIllegalTransition(t *testing.T) {
+	db, _ := setup(t)
+	create(t, db, &entity.Fee{TransactionID: "accrued", Status: fee.StatusAccrued})
+	create(t, db, &entity.Fee{TransactionID: "completed", Status: fee.StatusCompleted})
+
+	err := FeeStatus.Update(db, []string{"accrued", "completed"}, fee.StatusSubmitted, cause, nil)
+	assert.Equal(t, repository.ErrIllegalTransition, err)
+
+	record := &entity.Fee{}
+	db.First(record, "transaction_id = ?", "accrued")
+	assert.Equal(t, fee.StatusAccrued, record.Status)
+	var transitions int64
+	db.Model(&entity.StatusTransition{}).Count(&transitions)
+	assert.Equal(t, int64(0), transitions)
+}
+
+func Test_UpdateStaleStatus(t *testing.T) {
+	db, _ := setup(t)
+	create(t, db, &entity.Fee{TransactionID: "accrued", Status: fee.StatusAccrued})
+
+	err := FeeStatus.Update(db, []string{"accrued", "missing"}, fee.StatusSubmitted, cause, nil)
+	assert.Equal(t, repository.ErrStaleStatus, err)
+}
+
+func Test_UpdateToCurrentStatus(t *testing.T) {
+	db, _ := setup(t)
+	create(t, db, &entity.Transfer{TransactionID: "transfer", Status: transfer.StatusCompleted})
+
+	err := TransferStatus.Update(db, []string{"transfer"}, transfer.StatusCompleted, cause, nil)
+	assert.Nil(t, err)
+
+	var transitions int64
+	db.Model(&entity.StatusTransition{}).Count(&transitions)
+	assert.Equal(t, int64(0), transitions)
+}
+
+func Test_GetForTransfer(t *testing.T) {
+	db, repository := setup(t)
+	create(t, db, &entity.Transfer{TransactionID: "transfer", Status: transfer.StatusInitial})
+	create(t, db, &entity.Transfer{TransactionID: "other", Status: transfer.StatusInitial})
+	create(t, db, &entity.Fee{
+		TransactionID: "fee",
+		Status:        fee.StatusSubmitted,
+		TransferID:    sql.NullString{String: "transfer", Valid: true},
+	})
+
+	assert.Nil(t, TransferSignatureStatus.Update(db, []string{"transfer"}, transfer.StatusSignatureSubmitted, cause, nil))
+	assert.Nil(t, TransferStatus.Update(db, []string{"transfer", "other"}, transfer.StatusCompleted, cause, nil))
+	assert.Nil(t, FeeStatus.Update(db, []string{"fee"}, fee.StatusCompleted, cause, nil))
+
+	transitions, err := repository.GetForTransfer("transfer")
+	assert.Nil(t, err)
+	assert.Len(t, transitions, 3)
+	assert.Equal(t, status_transition.KindTransferSignature, transitions[0].Kind)
+	assert.Equal(t, "", transitions[0].From)
+	assert.Equal(t, status_transition.KindTransfer, transitions[1].Kind)
+	assert.Equal(t, "transfer", transitions[1].RecordID)
+	assert.Equal(t, status_transition.KindFee, transitions[2].Kind)
+	assert.Equal(t, "fee", transitions[2].RecordID)
+}
@@ -18,12 +18,17 @@ package transfer
 
 import (
 	"errors"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/helper/hedera"
+	"github.com/limechain/hedera-eth-bridge-validator/app/helper/majority"
 	model "github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/transfer"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/status-transition"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"strconv"
 )
 
 type Repository struct {
@@ -106,9 +111,9 @@ func (tr Repository) GetInitialAndSignatureSubmittedTx() ([]*entity.Transfer, er
 	return transfers, nil
 }
 
-// Create creates new record of Transfer
-func (tr Repository) Create(ct *model.Transfer) (*entity.Transfer, error) {
-	return tr.create(ct, transfer.StatusInitial)
+// Create creates new record of Transfer, snapshotting the eligible signers and the number of required signatures
+func (tr Repository) Create(ct *model.Transfer, signers []string, requiredSignatures int) (*entity.Transfer, error) {
+	return tr.create(ct, transfer.StatusInitial, signers, requiredSignatures, "")
 }
 
 // Save updates the provided Transfer instance
@@ -116,107 +121,131 @@ func (tr Repository) Save(tx *entity.Transfer) error {
 	return tr.dbClient.Save(tx).Error
 }
 
-func (tr *Repository) SaveRecoveredTxn(ct *model.Transfer) error {
-	_, err := tr.create(ct, transfer.StatusRecovered)
+func (tr *Repository) SaveRecoveredTxn(ct *model.Transfer, signers []string, requiredSignatures int) error {
+	_, err := tr.create(ct, transfer.StatusRecovered, signers, requiredSignatures, "")
 	return err
 }
 
-func (tr Repository) UpdateStatusCompleted(txId string) error {
-	return tr.updateStatus(txId, transfer.StatusCompleted)
+// CreateHeld creates new record of Transfer, held for manual review because of the violated asset policy limit
+func (tr Repository) CreateHeld(ct *model.Transfer, violation string) (*entity.Transfer, error) {
+	return tr.create(ct, transfer.StatusHeld, nil, 0, violation)
 }
 
-func (tr Repository) UpdateStatusSignatureSubmitted(txId string) error {
-	err := tr.dbClient.
-		Model(entity.Transfer{}).
-		Where("transaction_id = ?", txId).
-		Updates(entity.Transfer{SignatureMsgStatus: transfer.StatusSignatureSubmitted, Status: transfer.StatusInProgress}).
-		Error
+// CreateRejected creates new record of a rejected deposit
+func (tr Repository) CreateRejected(ct *model.Transfer) (*entity.Transfer, error) {
+	return tr.create(ct, transfer.StatusRejected, nil, 0, "")
+}
+
+// UpdateStatusCompleted completes a transfer, which is not rejected
+func (tr Repository) UpdateStatusCompleted(txId string, cause repository.Cause) error {
+	err := status_transition.TransferStatus.Update(tr.dbClient, []string{txId}, transfer.StatusCompleted, cause, nil)
+	if err == nil {
+		tr.logger.Debugf("Updated Status of TX [%s] to [%s]", txId, transfer.StatusCompleted)
+	}
+	return err
+}
+
+// UpdateStatusSignatureSubmitted records the submission of the signature. Unfinished transfers are moved to in progress,
+// while the status of completed ones is kept
+func (tr Repository) UpdateStatusSignatureSubmitted(txId string, cause repository.Cause) error {
+	err := tr.dbClient.Transaction(func(tx *gorm.DB) error {
+		err := status_transition.TransferSignatureStatus.Update(tx, []string{txId}, transfer.StatusSignatureSubmitted, cause, nil)
+		if err != nil {
+			return err
+		}
+
+		err = status_transition.TransferStatus.Update(tx, []string{txId}, transfer.StatusInProgress, cause, nil)
+		if errors.Is(err, repository.ErrIllegalTransition) {
+			return nil
+		}
+		return err
+	})
 	if err == nil {
 		tr.logger.Debugf("[%s] - Updated Status to [%s] and SignatureMsgStatus to [%s]", txId, transfer.StatusInProgress, transfer.StatusSignatureSubmitted)
 	}
 	return err
 }
 
-func (tr Repository) UpdateStatusSignatureMined(txId string) error {
-	return tr.updateSignatureStatus(txId, transfer.StatusSignatureMined)
+func (tr Repository) UpdateStatusSignatureMined(txId string, cause repository.Cause) error {
+	return tr.updateSignatureStatus(txId, transfer.StatusSignatureMined, cause)
 }
 
-func (tr Repository) UpdateStatusSignatureFailed(txId string) error {
-	return tr.updateSignatureStatus(txId, transfer.StatusSignatureFailed)
+func (tr Repository) UpdateStatusSignatureFailed(txId string, cause repository.Cause) error {
+	return tr.updateSignatureStatus(txId, transfer.StatusSignatureFailed, cause)
 }
 
-func (tr Repository) create(ct *model.Transfer, status string) (*entity.Transfer, error) {
+func (tr Repository) create(ct *model.Transfer, status string, signers []string, requiredSignatures int, violation string) (*entity.Transfer, error) {
 	tx := &entity.Transfer{
-		TransactionID: ct.TransactionId,
-		Receiver:      ct.Receiver,
-		Amount:        ct.Amount,
-		Status:        status,
-		NativeAsset:   ct.NativeAsset,
-		WrappedAsset:  ct.WrappedAsset,
-		RouterAddress: ct.RouterAddress,
+		TransactionID:      ct.TransactionId,
+		Receiver:           ct.Receiver,
+		Amount:             ct.Amount,
+		Status:             status,
+		NativeAsset:        ct.NativeAsset,
+		WrappedAsset:       ct.WrappedAsset,
+		RouterAddress:      ct.RouterAddress,
+		RequiredSignatures: requiredSignatures,
+		EligibleSigners:    majority.Join(signers),
+		Timestamp:          validStart(ct.TransactionId),
+		Violation:          violation,
 	}
 	err := tr.dbClient.Create(tx).Error
 
 	return tx, err
 }
 
-func (tr Repository) updateStatus(txId string, status string) error {
-	// Sanity check
-	if status != transfer.StatusInitial &&
-		status != transfer.StatusInProgress &&
-		status != transfer.StatusCompleted {
-		return errors.New("invalid signature status")
-	}
-
-	err := tr.dbClient.
-		Model(entity.Transfer{}).
-		Where("transaction_id = ?", txId).
-		UpdateColumn("status", status).
-		Error
+func (tr Repository) updateSignatureStatus(txId string, status string, cause repository.Cause) error {
+	err := status_transition.TransferSignatureStatus.Update(tr.dbClient, []string{txId}, status, cause, nil)
 	if err == nil {
-		tr.logger.Debugf("Updated Status of TX [%s] to [%s]", txId, status)
+		tr.logger.Debugf("[%s] - Updated SignatureMsgStatus to [%s]", txId, status)
 	}
 	return err
 }
 
-func (tr Repository) updateSignatureStatus(txId string, status string) error {
-	return tr.baseUpdateStatus("signature_msg_status", txId, status, []string{transfer.StatusSignatureSubmitted, transfer.StatusSignatureMined, transfer.StatusSignatureFailed})
-}
+func (tr *Repository) GetUnprocessedTransfers() ([]*entity.Transfer, error) {
+	var transfers []*entity.Transfer
 
-func (tr Repository) baseUpdateStatus(statusColumn, txId, status string, possibleStatuses []string) error {
-	if !isValidStatus(status, possibleStatuses) {
-		return errors.New("invalid status")
+	err := tr.dbClient.
+		Where("status IN ?", []string{transfer.StatusInitial, transfer.StatusRecovered}).
+		Find(&transfers).Error
+	if err != nil {
+		return nil, err
 	}
 
+	return transfers, nil
+}
+
+// CountByStatus returns the number of transfers by status
+func (tr Repository) CountByStatus() (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
 	err := tr.dbClient.
 		Model(entity.Transfer{}).
-		Where("transaction_id = ?", txId).
-		UpdateColumn(statusColumn, status).
+		Select("status, count(*) as count").
+		Group("status").
+		Scan(&rows).
 		Error
-	if err == nil {
-		tr.logger.Debugf("[%s] - Column [%s] status to [%s]", txId, statusColumn, status)
+	if err != nil {
+		return nil, err
 	}
-	return err
-}
 
-func isValidStatus(status string, possibleStatuses []string) bool {
-	for _, option := range possibleStatuses {
-		if status == option {
-			return true
-		}
+	counts := make(map[string]int64)
+	for _, row := range rows {
+		counts[row.Status] = row.Count
 	}
-	return false
+	return counts, nil
 }
 
-func (tr *Repository) GetUnprocessedTransfers() ([]*entity.Transfer, error) {
-	var transfers []*entity.Transfer
-
-	err := tr.dbClient.
-		Where("status IN ?", []string{transfer.StatusInitial, transfer.StatusRecovered}).
-		Find(&transfers).Error
+// validStart returns the valid start (in seconds) of the transaction with the given mirror node id. Returns 0, if it is invalid
+func validStart(txId string) int64 {
+	id, err := hedera.FromMirrorNodeTransactionID(txId)
 	if err != nil {
-		return nil, err
+		return 0
 	}
-
-	return transfers, nil
+	seconds, err := strconv.ParseInt(id.Seconds, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return seconds
 }
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package unit_of_work
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/persistence/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/equivocation"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/fee"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/message"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/refund"
+	rejected_message "github.com/limechain/hedera-eth-bridge-validator/app/persistence/rejected-message"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/transfer"
+	"gorm.io/gorm"
+)
+
+type UnitOfWork struct {
+	dbClient *gorm.DB
+}
+
+func New(dbClient *gorm.DB) *UnitOfWork {
+	return &UnitOfWork{
+		dbClient: dbClient,
+	}
+}
+
+// Do executes the work in a database transaction. The transaction is committed if the work
+// returns nil and is rolled back otherwise
+func (u UnitOfWork) Do(work func(tx repository.Transaction) error) error {
+	return u.dbClient.Transaction(func(tx *gorm.DB) error {
+		return work(transaction{dbClient: tx})
+	})
+}
+
+// transaction creates the repositories with the database transaction of the unit of work
+type transaction struct {
+	dbClient *gorm.DB
+}
+
+func (t transaction) Transfers() repository.Transfer {
+	return transfer.NewRepository(t.dbClient)
+}
+
+func (t transaction) Messages() repository.Message {
+	return message.NewRepository(t.dbClient)
+}
+
+func (t transaction) RejectedMessages() repository.RejectedMessage {
+	return rejected_message.NewRepository(t.dbClient)
+}
+
+func (t transaction) Equivocations() repository.Equivocation {
+	return equivocation.NewRepository(t.dbClient)
+}
+
+func (t transaction) Fees() repository.Fee {
+	return fee.NewRepository(t.dbClient)
+}
+
+func (t transaction) BurnEvents() repository.BurnEvent {
+	return burn_event.NewRepository(t.dbClient)
+}
+
+func (t transaction) Refunds() repository.Refund {
+	return refund.NewRepository(t.dbClient)
+}
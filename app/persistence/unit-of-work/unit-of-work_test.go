@@ -0,0 +1,229 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package unit_of_work
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/migration"
+	"github.com/limechain/hedera-eth-bridge-validator/test/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var cause = repository.Cause{Actor: "Test", Reason: "TEST"}
+
+func setup(t *testing.T) (*gorm.DB, *UnitOfWork) {
+	db := database.ConnectSQLite(t)
+	err := migration.New(db).Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, New(db)
+}
+
+func create(t *testing.T, db *gorm.DB, value interface{}) {
+	err := db.Create(value).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_DoCommits(t *testing.T) {
+	db, unitOfWork := setup(t)
+	create(t, db, &entity.BurnEvent{Id: "burn", Status: burn_event.StatusInitial})
+
+	err := unitOfWork.Do(func(tx repository.Transaction) error {
+		err := tx.BurnEvents().UpdateStatusSubmitted("burn", "schedule", "fee", cause)
+		if err != nil {
+			return err
+		}
+		return tx.Fees().Create(&entity.Fee{TransactionID: "fee", Status: fee.StatusSubmitted})
+	})
+
+	assert.Nil(t, err)
+	burnEvent := &entity.BurnEvent{}
+	db.First(burnEvent, "id = ?", "burn")
+	assert.Equal(t, burn_event.StatusSubmitted, burnEvent.Status)
+	var fees int64
+	db.Model(&entity.Fee{}).Count(&fees)
+	assert.Equal(t, int64(1), fees)
+}
+
+func Test_DoRollsBack(t *testing.T) {
+	db, unitOfWork := setup(t)
+	create(t, db, &entity.BurnEvent{Id: "burn", Status: burn_event.StatusInitial})
+	expectedErr := errors.New("some-error")
+
+	err := unitOfWork.Do(func(tx repository.Transaction) error {
+		err := tx.BurnEvents().UpdateStatusSubmitted("burn", "schedule", "fee", cause)
+		if err != nil {
+			return err
+		}
+		return expectedErr
+	})
+
+	assert.Equal(t, expectedErr, err)
+	burnEvent := &entity.BurnEvent{}
+	db.First(burnEvent, "id = ?", "burn")
+	assert.Equal(t, burn_event.StatusInitial, burnEvent.Status)
+	var transitions int64
+	db.Model(&entity.StatusTransition{}).Count(&transitions)
+	assert.Equal(t, int64(0), transitions)
+}
+
+func Test_StaleBurnEventStatus(t *testing.T) {
+	_, unitOfWork := setup(t)
+
+	err := unitOfWork.Do(func(tx repository.Transaction) error {
+		return tx.BurnEvents().UpdateStatusFailed("missing", cause)
+	})
+
+	assert.Equal(t, repository.ErrStaleStatus, err)
+}
+
+func Test_IllegalBurnEventTransition(t *testing.T) {
+	db, unitOfWork := setup(t)
+	create(t, db, &entity.BurnEvent{Id: "burn", Status: burn_event.StatusCompleted})
+
+	err := unitOfWork.Do(func(tx repository.Transaction) error {
+		return tx.BurnEvents().UpdateStatusFailed("burn", cause)
+	})
+
+	assert.Equal(t, repository.ErrIllegalTransition, err)
+	burnEvent := &entity.BurnEvent{}
+	db.First(burnEvent, "id = ?", "burn")
+	assert.Equal(t, burn_event.StatusCompleted, burnEvent.Status)
+}
+
+func Test_IllegalSettlementTransition(t *testing.T) {
+	db, unitOfWork := setup(t)
+	create(t, db, &entity.Fee{TransactionID: "accrued", Status: fee.StatusAccrued})
+	create(t, db, &entity.Fee{TransactionID: "settled", Status: fee.StatusSubmitted})
+
+	err := unitOfWork.Do(func(tx repository.Transaction) error {
+		return tx.Fees().UpdateSettlementSubmitted([]string{"accrued", "settled"}, &entity.FeeSettlement{ID: "fees-HBAR-60", TransactionID: "settlement"}, cause)
+	})
+
+	assert.Equal(t, repository.ErrIllegalTransition, err)
+	record := &entity.Fee{}
+	db.First(record, "transaction_id = ?", "accrued")
+	assert.Equal(t, fee.StatusAccrued, record.Status)
+	assert.False(t, record.SettlementID.Valid)
+	var settlements int64
+	db.Model(entity.FeeSettlement{}).Count(&settlements)
+	assert.Zero(t, settlements)
+}
+
+func Test_SettlementFailedAccruesAgain(t *testing.T) {
+	db, unitOfWork := setup(t)
+	create(t, db, &entity.Fee{TransactionID: "accrued", Status: fee.StatusAccrued, NativeAsset: "HBAR", Batch: 60})
+	settlement := &entity.FeeSettlement{ID: "fees-HBAR-60", NativeAsset: "HBAR", Batch: 60, TransactionID: "settlement"}
+
+	err := unitOfWork.Do(func(tx repository.Transaction) error {
+		err := tx.Fees().UpdateSettlementSubmitted([]string{"accrued"}, settlement, cause)
+		if err != nil {
+			return err
+		}
+		err = tx.Fees().UpdateSettlementAccrued([]string{"accrued"}, cause)
+		if err != nil {
+			return err
+		}
+		return tx.Fees().UpdateBatch([]string{"accrued"}, 120)
+	})
+
+	assert.Nil(t, err)
+	record := &entity.Fee{}
+	db.First(record, "transaction_id = ?", "accrued")
+	assert.Equal(t, fee.StatusAccrued, record.Status)
+	assert.Equal(t, int64(120), record.Batch)
+	assert.False(t, record.SettlementID.Valid)
+	recorded := &entity.FeeSettlement{}
+	db.First(recorded, "id = ?", "fees-HBAR-60")
+	assert.Equal(t, *settlement, *recorded)
+}
+
+func Test_AccruedBatches(t *testing.T) {
+	db, unitOfWork := setup(t)
+	create(t, db, &entity.Fee{TransactionID: "3", Status: fee.StatusAccrued, NativeAsset: "HBAR", Batch: 60})
+	create(t, db, &entity.Fee{TransactionID: "1", Status: fee.StatusAccrued, NativeAsset: "HBAR", Batch: 60})
+	create(t, db, &entity.Fee{TransactionID: "2", Status: fee.StatusSubmitted, NativeAsset: "HBAR", Batch: 60})
+	create(t, db, &entity.Fee{TransactionID: "4", Status: fee.StatusAccrued, NativeAsset: "0.0.1", Batch: 120})
+	create(t, db, &entity.Fee{TransactionID: "5", Status: fee.StatusAccrued, NativeAsset: "HBAR", Batch: 180})
+
+	var batches []repository.FeeBatch
+	var fees []*entity.Fee
+	err := unitOfWork.Do(func(tx repository.Transaction) error {
+		var err error
+		batches, err = tx.Fees().GetAccruedBatches(180)
+		if err != nil {
+			return err
+		}
+		fees, err = tx.Fees().GetAccruedInBatch("HBAR", 60)
+		return err
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []repository.FeeBatch{{NativeAsset: "0.0.1", Batch: 120}, {NativeAsset: "HBAR", Batch: 60}}, batches)
+	assert.Len(t, fees, 2)
+	assert.Equal(t, "1", fees[0].TransactionID)
+	assert.Equal(t, "3", fees[1].TransactionID)
+}
+
+func Test_TransferTransitions(t *testing.T) {
+	db, unitOfWork := setup(t)
+	create(t, db, &entity.Transfer{TransactionID: "transfer", Status: transfer.StatusInitial})
+
+	err := unitOfWork.Do(func(tx repository.Transaction) error {
+		return tx.Transfers().UpdateStatusCompleted("transfer", cause)
+	})
+	assert.Nil(t, err)
+
+	err = unitOfWork.Do(func(tx repository.Transaction) error {
+		return tx.Transfers().UpdateStatusCompleted("transfer", cause)
+	})
+	// Completing a completed transfer again leaves it as it is
+	assert.Nil(t, err)
+
+	// The signature of a completed transfer does not move it back to in progress
+	err = unitOfWork.Do(func(tx repository.Transaction) error {
+		return tx.Transfers().UpdateStatusSignatureSubmitted("transfer", cause)
+	})
+	assert.Nil(t, err)
+	record := &entity.Transfer{}
+	db.First(record, "transaction_id = ?", "transfer")
+	assert.Equal(t, transfer.StatusCompleted, record.Status)
+	assert.Equal(t, transfer.StatusSignatureSubmitted, record.SignatureMsgStatus)
+}
+
+func Test_DuplicateMessage(t *testing.T) {
+	db, unitOfWork := setup(t)
+	create(t, db, &entity.Transfer{TransactionID: "transfer", Status: transfer.StatusInitial})
+	create(t, db, &entity.Message{TransferID: "transfer", Signature: "signature", Signer: "0xsigner"})
+
+	err := unitOfWork.Do(func(tx repository.Transaction) error {
+		return tx.Messages().Create(&entity.Message{TransferID: "transfer", Signature: "signature", Signer: "0xsigner"})
+	})
+
+	assert.Equal(t, repository.ErrDuplicate, err)
+}
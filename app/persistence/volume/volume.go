@@ -0,0 +1,74 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volume
+
+import (
+	"errors"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	dbClient *gorm.DB
+}
+
+func NewRepository(dbClient *gorm.DB) *Repository {
+	return &Repository{
+		dbClient: dbClient,
+	}
+}
+
+// Create persists the volume. Already recorded volumes are ignored
+func (r Repository) Create(volume *entity.Volume) error {
+	return r.dbClient.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(volume).
+		Error
+}
+
+// Get returns the volume of the given operation. Returns nil if not found
+func (r Repository) Get(id string) (*entity.Volume, error) {
+	volume := &entity.Volume{}
+	err := r.dbClient.
+		Model(entity.Volume{}).
+		Where("id = ?", id).
+		First(volume).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return volume, nil
+}
+
+// GetBetween returns the volumes of the native asset in the direction with timestamp in the interval (from; to]
+func (r Repository) GetBetween(nativeAsset, direction string, from, to int64) ([]entity.Volume, error) {
+	var volumes []entity.Volume
+	err := r.dbClient.
+		Model(entity.Volume{}).
+		Where("native_asset = ? and direction = ? and timestamp > ? and timestamp <= ?", nativeAsset, direction, from, to).
+		Find(&volumes).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return volumes, nil
+}
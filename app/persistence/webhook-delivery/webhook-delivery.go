@@ -0,0 +1,66 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook_delivery
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	webhook_delivery "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/webhook-delivery"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	dbClient *gorm.DB
+}
+
+func NewRepository(dbClient *gorm.DB) *Repository {
+	return &Repository{
+		dbClient: dbClient,
+	}
+}
+
+// Create records a delivery
+func (r Repository) Create(delivery *entity.WebhookDelivery) error {
+	return r.dbClient.Create(delivery).Error
+}
+
+// UpdateAttempt records the outcome of the latest attempt of the delivery
+func (r Repository) UpdateAttempt(id uint64, status string, attempts, responseCode int, lastError string) error {
+	return r.dbClient.
+		Model(entity.WebhookDelivery{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"status":        status,
+			"attempts":      attempts,
+			"response_code": responseCode,
+			"error":         lastError,
+		}).
+		Error
+}
+
+// GetPending returns the deliveries, which are neither delivered nor failed, ordered by their creation
+func (r Repository) GetPending() ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+	err := r.dbClient.
+		Where("status = ?", webhook_delivery.StatusPending).
+		Order("id").
+		Find(&deliveries).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook_delivery
+
+import (
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	webhook_delivery "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/webhook-delivery"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/migration"
+	"github.com/limechain/hedera-eth-bridge-validator/test/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func setup(t *testing.T) *Repository {
+	db := database.ConnectSQLite(t)
+	err := migration.New(db).Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewRepository(db)
+}
+
+func newDelivery(recordID string) *entity.WebhookDelivery {
+	return &entity.WebhookDelivery{
+		URL:       "http://localhost:8080/webhooks",
+		Event:     "TRANSFER_CREATED",
+		RecordID:  recordID,
+		Payload:   "{}",
+		Status:    webhook_delivery.StatusPending,
+		Timestamp: 1620000000,
+	}
+}
+
+func Test_CreateAssignsID(t *testing.T) {
+	repository := setup(t)
+	first, second := newDelivery("first"), newDelivery("second")
+
+	assert.Nil(t, repository.Create(first))
+	assert.Nil(t, repository.Create(second))
+
+	assert.NotZero(t, first.ID)
+	assert.Greater(t, second.ID, first.ID)
+}
+
+func Test_UpdateAttemptAndGetPending(t *testing.T) {
+	repository := setup(t)
+	delivered, failed, pending := newDelivery("delivered"), newDelivery("failed"), newDelivery("pending")
+	for _, d := range []*entity.WebhookDelivery{delivered, failed, pending} {
+		assert.Nil(t, repository.Create(d))
+	}
+
+	assert.Nil(t, repository.UpdateAttempt(delivered.ID, webhook_delivery.StatusDelivered, 1, 200, ""))
+	assert.Nil(t, repository.UpdateAttempt(failed.ID, webhook_delivery.StatusFailed, 8, 500, "unexpected status code [500]"))
+	assert.Nil(t, repository.UpdateAttempt(pending.ID, webhook_delivery.StatusPending, 2, 502, "unexpected status code [502]"))
+
+	deliveries, err := repository.GetPending()
+	assert.Nil(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, "pending", deliveries[0].RecordID)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.Equal(t, 502, deliveries[0].ResponseCode)
+	assert.Equal(t, "unexpected status code [502]", deliveries[0].Error)
+}
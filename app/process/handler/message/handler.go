@@ -17,21 +17,30 @@
 package message
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/hashgraph/hedera-sdk-go/v2"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
 	"github.com/limechain/hedera-eth-bridge-validator/app/model/message"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/transfer"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	log "github.com/sirupsen/logrus"
 )
 
+// actor is the component recorded in the status transitions of the service
+const actor = "Message Handler"
+
 type Handler struct {
 	transferRepository repository.Transfer
 	messageRepository  repository.Message
-	contracts          service.Contracts
+	quorum             service.Quorum
 	messages           service.Messages
+	pending            service.PendingSignatures
+	pause              service.Pause
+	webhooks           service.Webhooks
 	logger             *log.Entry
 }
 
@@ -39,21 +48,31 @@ func NewHandler(
 	topicId string,
 	transferRepository repository.Transfer,
 	messageRepository repository.Message,
-	contractsService service.Contracts,
+	quorum service.Quorum,
 	messages service.Messages,
+	pending service.PendingSignatures,
+	pause service.Pause,
+	webhooks service.Webhooks,
 ) *Handler {
 	topicID, err := hedera.TopicIDFromString(topicId)
 	if err != nil {
 		log.Fatalf("Invalid topic id: [%v]", topicId)
 	}
 
-	return &Handler{
+	h := &Handler{
 		transferRepository: transferRepository,
 		messageRepository:  messageRepository,
-		contracts:          contractsService,
+		quorum:             quorum,
 		messages:           messages,
+		pending:            pending,
+		pause:              pause,
+		webhooks:           webhooks,
 		logger:             config.GetLoggerFor(fmt.Sprintf("Topic [%s] Handler", topicID.String())),
 	}
+	// Signature messages, received before their transfer, are handled once the transfer is processed
+	pending.Subscribe(h.handleSignatureMessage)
+
+	return h
 }
 
 func (cmh Handler) Handle(payload interface{}) {
@@ -63,12 +82,27 @@ func (cmh Handler) Handle(payload interface{}) {
 		return
 	}
 
+	if m.GetControl() != nil {
+		err := cmh.pause.ProcessControlMessage(*m)
+		if err != nil {
+			cmh.logger.Errorf("Failed to process Control Message [%s]. Error: [%s]", m.GetControl().Action, err)
+		}
+		return
+	}
+
 	cmh.handleSignatureMessage(*m)
 }
 
 // handleSignatureMessage is the main component responsible for the processing of new incoming Signature Messages
 func (cmh Handler) handleSignatureMessage(tsm message.Message) {
 	valid, err := cmh.messages.SanityCheckSignature(tsm)
+	if errors.Is(err, service.ErrNotFound) {
+		err = cmh.pending.Add(tsm)
+		if err != nil {
+			cmh.logger.Errorf("[%s] - Failed to buffer incoming signature [%s].", tsm.TransferID, tsm.GetSignature())
+		}
+		return
+	}
 	if err != nil {
 		cmh.logger.Errorf("[%s] - Failed to perform sanity check on incoming signature [%s].", tsm.TransferID, tsm.GetSignature())
 		return
@@ -84,30 +118,47 @@ func (cmh Handler) handleSignatureMessage(tsm message.Message) {
 		return
 	}
 
-	majorityReached, err := cmh.checkMajority(tsm.TransferID)
+	majorityReached, completed, err := cmh.checkMajority(tsm.TransferID)
 	if err != nil {
 		cmh.logger.Errorf("[%s] - Could not determine whether majority was reached", tsm.TransferID)
 		return
 	}
 
-	if majorityReached {
-		err = cmh.transferRepository.UpdateStatusCompleted(tsm.TransferID)
+	if majorityReached && !completed {
+		err = cmh.transferRepository.UpdateStatusCompleted(tsm.TransferID, repository.Cause{Actor: actor, Reason: status_transition.ReasonMajorityReached})
+		// Signatures reaching the majority concurrently attempt to complete the transfer together
+		if errors.Is(err, repository.ErrStaleStatus) {
+			cmh.logger.Debugf("[%s] - Transfer already completed.", tsm.TransferID)
+			return
+		}
 		if err != nil {
 			cmh.logger.Errorf("[%s] - Failed to complete. Error: [%s]", tsm.TransferID, err)
+			return
 		}
+		cmh.webhooks.Notify(service.WebhookMajorityReached, service.WebhookData{TransferID: tsm.TransferID})
 	}
 }
 
-func (cmh *Handler) checkMajority(transferID string) (majorityReached bool, err error) {
+// checkMajority counts the signatures of the signers eligible at the creation of the transfer
+// and returns whether the transfer is already completed
+func (cmh *Handler) checkMajority(transferID string) (majorityReached, completed bool, err error) {
+	t, err := cmh.transferRepository.GetByTransactionId(transferID)
+	if err != nil {
+		cmh.logger.Errorf("[%s] - Failed to query Transfer. Error: [%s]", transferID, err)
+		return false, false, err
+	}
+	if t == nil {
+		return false, false, errors.New(fmt.Sprintf("transfer [%s] not found", transferID))
+	}
+
 	signatureMessages, err := cmh.messageRepository.Get(transferID)
 	if err != nil {
 		cmh.logger.Errorf("[%s] - Failed to query all Signature Messages. Error: [%s]", transferID, err)
-		return false, err
+		return false, false, err
 	}
 
-	requiredSigCount := len(cmh.contracts.GetMembers())/2 + 1
-	cmh.logger.Infof("[%s] - Collected [%d/%d] Signatures", transferID, len(signatureMessages), len(cmh.contracts.GetMembers()))
+	collected, eligible, reached := cmh.quorum.Reached(t, signatureMessages)
+	cmh.logger.Infof("[%s] - Collected [%d/%d] Signatures", transferID, collected, eligible)
 
-	return len(signatureMessages) >= requiredSigCount,
-		nil
+	return reached, t.Status == transfer.StatusCompleted, nil
 }
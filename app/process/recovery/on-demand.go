@@ -0,0 +1,312 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	hederasdk "github.com/hashgraph/hedera-sdk-go/v2"
+	routerContract "github.com/limechain/hedera-eth-bridge-validator/app/clients/ethereum/contracts/router"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	hederahelper "github.com/limechain/hedera-eth-bridge-validator/app/helper/hedera"
+	"github.com/limechain/hedera-eth-bridge-validator/app/helper/timestamp"
+	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/model/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/volume"
+	"github.com/limechain/hedera-eth-bridge-validator/constants"
+)
+
+// progress tracks the outcomes of the on-demand recovery. Outcomes are not tracked, while it is not running
+type progress struct {
+	mutex   sync.RWMutex
+	current *service.RecoveryProgress
+}
+
+// Recover starts the recovery of the scopes in the interval [from; to) (in nanoseconds) and returns its progress.
+// Records, which already exist, are not processed again. Returns ErrRecoveryInProgress, if a recovery is running
+func (r Recovery) Recover(from, to int64, scopes []string) (*service.RecoveryProgress, error) {
+	err := validateScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]*service.RecoveryResult)
+	for _, scope := range scopes {
+		results[scope] = &service.RecoveryResult{}
+	}
+
+	r.progress.mutex.Lock()
+	if r.progress.current != nil && r.progress.current.Running {
+		r.progress.mutex.Unlock()
+		return nil, service.ErrRecoveryInProgress
+	}
+	r.progress.current = &service.RecoveryProgress{
+		From:      from,
+		To:        to,
+		Scopes:    scopes,
+		StartedAt: time.Now().UnixNano(),
+		Running:   true,
+		Results:   results,
+	}
+	r.progress.mutex.Unlock()
+
+	go r.run(from, to, results)
+
+	return r.Progress(), nil
+}
+
+// Progress returns the progress of the last on-demand recovery. Returns nil, if there is none
+func (r Recovery) Progress() *service.RecoveryProgress {
+	r.progress.mutex.RLock()
+	defer r.progress.mutex.RUnlock()
+
+	if r.progress.current == nil {
+		return nil
+	}
+
+	progress := *r.progress.current
+	progress.Results = make(map[string]*service.RecoveryResult)
+	for scope, result := range r.progress.current.Results {
+		copied := *result
+		progress.Results[scope] = &copied
+	}
+	return &progress
+}
+
+// run recovers the scopes in their order. The transfers are processed once the topic messages are recovered,
+// so that the signatures of the other validators are already recorded
+func (r Recovery) run(from, to int64, scopes map[string]*service.RecoveryResult) {
+	r.logger.Infof("Starting on-demand Recovery with interval [%s; %s)", timestamp.ToHumanReadable(from), timestamp.ToHumanReadable(to))
+
+	var err error
+	var recovered []*transfer.Transfer
+	for _, scope := range service.RecoveryScopes {
+		if _, ok := scopes[scope]; !ok {
+			continue
+		}
+
+		// The mirror node intervals exclude their start
+		switch scope {
+		case service.RecoveryScopeTransfers:
+			recovered, err = r.transfersRecovery(from-1, to)
+		case service.RecoveryScopeMessages:
+			err = r.controlMessagesRecovery(from-1, to)
+			if err == nil {
+				err = r.topicMessagesRecovery(from-1, to)
+			}
+		case service.RecoveryScopeBurns:
+			err = r.burnsRecovery(from, to)
+		}
+		if err != nil {
+			r.logger.Errorf("On-demand Recovery of [%s] failed. Error: [%s]", scope, err)
+			break
+		}
+	}
+
+	if err == nil {
+		for _, t := range recovered {
+			err := r.transfers.ProcessTransfer(*t)
+			if err != nil {
+				r.logger.Errorf("Processing of TX [%s] failed", t.TransactionId)
+			}
+		}
+	}
+
+	r.progress.finish(err)
+	r.logger.Infof("On-demand Recovery with interval [%s; %s) finished", timestamp.ToHumanReadable(from), timestamp.ToHumanReadable(to))
+}
+
+// burnsRecovery processes the burn events of the router contract, emitted in the confirmed blocks between `from` and `to`.
+// Burn events, which are already recorded, are skipped
+func (r Recovery) burnsRecovery(from, to int64) error {
+	fromBlock, err := r.ethClient.BlockAt(toSeconds(from))
+	if err != nil {
+		return err
+	}
+	toBlock, err := r.ethClient.BlockAt(toSeconds(to))
+	if err != nil {
+		return err
+	}
+	if toBlock <= fromBlock {
+		r.logger.Infof("No confirmed blocks found to recover Burn Events")
+		return nil
+	}
+
+	end := toBlock - 1
+	events, err := r.contracts.FilterBurnEventLogs(&bind.FilterOpts{Start: fromBlock, End: &end})
+	if err != nil {
+		return err
+	}
+
+	r.logger.Infof("Found [%d] Burn Events in blocks [%d; %d]", len(events), fromBlock, end)
+	r.progress.found(service.RecoveryScopeBurns, len(events))
+	for _, eventLog := range events {
+		err = r.recoverBurn(eventLog)
+		if err != nil {
+			r.progress.skipped(service.RecoveryScopeBurns)
+			r.skip(service.RecoveryScopeBurns, fmt.Sprintf("%s-%d", eventLog.Raw.TxHash, eventLog.Raw.Index), err.Error())
+		}
+	}
+	return nil
+}
+
+// recoverBurn processes the burn event, unless it is already recorded
+func (r Recovery) recoverBurn(eventLog *routerContract.RouterBurn) error {
+	id := fmt.Sprintf("%s-%d", eventLog.Raw.TxHash, eventLog.Raw.Index)
+	existing, err := r.burnEventRepo.Get(id)
+	// Pruned burn events are looked up in the archive, so that they are not recovered again
+	if err == nil && existing == nil {
+		existing, err = r.archive.BurnEvent(id)
+	}
+	if err != nil {
+		r.logger.Errorf("[%s] - Skipping recovery. Failed to get db record. Error: [%s]", id, err)
+		return err
+	}
+	if existing != nil {
+		r.logger.Debugf("[%s] - Skipping recovery. Burn Event already recorded with status [%s]", id, existing.Status)
+		r.progress.existing(service.RecoveryScopeBurns)
+		r.skip(service.RecoveryScopeBurns, id, fmt.Sprintf("already recorded with status [%s]", existing.Status))
+		return nil
+	}
+
+	recipientAccount, err := hederasdk.AccountIDFromBytes(eventLog.Receiver)
+	if err != nil {
+		r.logger.Errorf("[%s] - Skipping recovery. Failed to parse account from bytes [%v]. Error: [%s]", id, eventLog.Receiver, err)
+		return err
+	}
+	nativeAsset, err := r.contracts.ToNative(eventLog.WrappedAsset)
+	if err != nil {
+		r.logger.Errorf("[%s] - Skipping recovery. Failed to retrieve native asset of [%s]. Error: [%s]", id, eventLog.WrappedAsset, err)
+		return err
+	}
+	if nativeAsset != constants.Hbar && !hederahelper.IsTokenID(nativeAsset) {
+		r.logger.Errorf("[%s] - Skipping recovery. Invalid Native Token [%s]", id, nativeAsset)
+		return errors.New(fmt.Sprintf("invalid native token [%s]", nativeAsset))
+	}
+
+	header, err := r.ethClient.GetClient().HeaderByHash(context.Background(), eventLog.Raw.BlockHash)
+	if err != nil {
+		r.logger.Errorf("[%s] - Skipping recovery. Failed to retrieve block [%s]. Error: [%s]", id, eventLog.Raw.BlockHash, err)
+		return err
+	}
+
+	event := burn_event.BurnEvent{
+		Id:           id,
+		Amount:       eventLog.Amount,
+		Recipient:    recipientAccount,
+		NativeAsset:  nativeAsset,
+		WrappedAsset: eventLog.WrappedAsset.String(),
+		Timestamp:    int64(header.Time),
+	}
+
+	// Limits are in native asset units
+	nativeAmount, _, err := r.decimals.ToNative(nativeAsset, event.WrappedAsset, event.Amount)
+	if err != nil {
+		r.logger.Errorf("[%s] - Skipping recovery. Failed to convert amount to native decimals. Error: [%s]", id, err)
+		return err
+	}
+	check := r.assetPolicy.Check
+	if r.report != nil {
+		check = r.assetPolicy.Preview
+	}
+	violation, err := check(id, volume.DirectionEVMToHedera, nativeAsset, recipientAccount.String(), nativeAmount, event.Timestamp)
+	if err != nil {
+		r.logger.Errorf("[%s] - Skipping recovery. Asset policy check failed. Error: [%s]", id, err)
+		return err
+	}
+
+	if r.report != nil {
+		r.reportBurn(event, violation)
+		return nil
+	}
+
+	if violation != "" {
+		r.burnEvents.HoldEvent(event, violation)
+	} else {
+		r.burnEvents.ProcessEvent(event)
+	}
+	r.logger.Debugf("[%s] - Recovered Burn Event", id)
+	r.progress.recovered(service.RecoveryScopeBurns)
+	return nil
+}
+
+func (p *progress) found(scope string, count int) {
+	p.update(scope, func(result *service.RecoveryResult) { result.Found += count })
+}
+
+func (p *progress) recovered(scope string) {
+	p.update(scope, func(result *service.RecoveryResult) { result.Recovered++ })
+}
+
+func (p *progress) existing(scope string) {
+	p.update(scope, func(result *service.RecoveryResult) { result.Existing++ })
+}
+
+func (p *progress) skipped(scope string) {
+	p.update(scope, func(result *service.RecoveryResult) { result.Skipped++ })
+}
+
+func (p *progress) update(scope string, update func(result *service.RecoveryResult)) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.current == nil || !p.current.Running {
+		return
+	}
+	result, ok := p.current.Results[scope]
+	if !ok {
+		return
+	}
+	update(result)
+}
+
+func (p *progress) finish(err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.current.Running = false
+	p.current.FinishedAt = time.Now().UnixNano()
+	if err != nil {
+		p.current.Error = err.Error()
+	}
+}
+
+func validateScopes(scopes []string) error {
+	for _, scope := range scopes {
+		if !isScope(scope) {
+			return errors.New(fmt.Sprintf("unknown recovery scope [%s]", scope))
+		}
+	}
+	return nil
+}
+
+func isScope(scope string) bool {
+	return contains(service.RecoveryScopes, scope)
+}
+
+// toSeconds returns the first second, which is not before the timestamp (in nanoseconds)
+func toSeconds(ts int64) int64 {
+	seconds := ts / int64(time.Second)
+	if ts%int64(time.Second) > 0 {
+		seconds++
+	}
+	return seconds
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	mirror_node "github.com/limechain/hedera-eth-bridge-validator/app/clients/hedera/mirror-node"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	accountID     = hedera.AccountID{Account: 1111}
+	transactionID = "0.0.1111-1-1"
+)
+
+func setup() *Recovery {
+	mocks.Setup()
+	return &Recovery{
+		transfers:    mocks.MTransferService,
+		transferRepo: mocks.MTransferRepository,
+		archive:      mocks.MArchiveService,
+		mirrorClient: mocks.MHederaMirrorClient,
+		progress:     &progress{},
+		accountID:    accountID,
+		logger:       config.GetLoggerFor("Recovery"),
+	}
+}
+
+// wait returns the progress of the recovery, once it is finished
+func wait(t *testing.T, r *Recovery) *service.RecoveryProgress {
+	for i := 0; i < 100; i++ {
+		progress := r.Progress()
+		if !progress.Running {
+			return progress
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("recovery did not finish")
+	return nil
+}
+
+func Test_RecoverSkipsExisting(t *testing.T) {
+	r := setup()
+	mocks.MHederaMirrorClient.On("GetAccountCreditTransactionsBetween", accountID, int64(99), int64(200)).
+		Return([]mirror_node.Transaction{{TransactionID: transactionID}}, nil)
+	mocks.MTransferRepository.On("GetByTransactionId", transactionID).Return(&entity.Transfer{TransactionID: transactionID}, nil)
+
+	progress, err := r.Recover(100, 200, []string{service.RecoveryScopeTransfers})
+	assert.Nil(t, err)
+	assert.True(t, progress.Running)
+
+	progress = wait(t, r)
+	assert.Empty(t, progress.Error)
+	assert.Equal(t, service.RecoveryResult{Found: 1, Existing: 1}, *progress.Results[service.RecoveryScopeTransfers])
+	mocks.MTransferService.AssertNotCalled(t, "SaveRecoveredTxn", transactionID, "", "", "", "")
+}
+
+func Test_RecoverSkipsArchived(t *testing.T) {
+	r := setup()
+	mocks.MHederaMirrorClient.On("GetAccountCreditTransactionsBetween", accountID, int64(99), int64(200)).
+		Return([]mirror_node.Transaction{{TransactionID: transactionID}}, nil)
+	mocks.MTransferRepository.On("GetByTransactionId", transactionID).Return((*entity.Transfer)(nil), nil)
+	mocks.MArchiveService.On("Transfer", transactionID).Return(&entity.Transfer{TransactionID: transactionID, Status: transfer.StatusCompleted}, nil)
+
+	_, err := r.Recover(100, 200, []string{service.RecoveryScopeTransfers})
+	assert.Nil(t, err)
+
+	progress := wait(t, r)
+	assert.Empty(t, progress.Error)
+	assert.Equal(t, service.RecoveryResult{Found: 1, Existing: 1}, *progress.Results[service.RecoveryScopeTransfers])
+	mocks.MTransferService.AssertNotCalled(t, "SaveRecoveredTxn", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_RecoverInProgress(t *testing.T) {
+	r := setup()
+	r.progress.current = &service.RecoveryProgress{Running: true}
+
+	progress, err := r.Recover(100, 200, []string{service.RecoveryScopeTransfers})
+
+	assert.Nil(t, progress)
+	assert.Equal(t, service.ErrRecoveryInProgress, err)
+}
+
+func Test_RecoverUnknownScope(t *testing.T) {
+	r := setup()
+
+	progress, err := r.Recover(100, 200, []string{"unknown"})
+
+	assert.Nil(t, progress)
+	assert.Error(t, err)
+	assert.Nil(t, r.Progress())
+}
+
+func Test_ToSeconds(t *testing.T) {
+	assert.Equal(t, int64(2), toSeconds(2*int64(time.Second)))
+	assert.Equal(t, int64(3), toSeconds(2*int64(time.Second)+1))
+}
@@ -20,18 +20,30 @@ import (
 	"errors"
 	"fmt"
 	hederasdk "github.com/hashgraph/hedera-sdk-go/v2"
+	mirror_node "github.com/limechain/hedera-eth-bridge-validator/app/clients/hedera/mirror-node"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/client"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	big_numbers "github.com/limechain/hedera-eth-bridge-validator/app/helper/big-numbers"
+	"github.com/limechain/hedera-eth-bridge-validator/app/helper/memo"
 	"github.com/limechain/hedera-eth-bridge-validator/app/helper/timestamp"
 	"github.com/limechain/hedera-eth-bridge-validator/app/model/message"
 	"github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	burn_event_status "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/refund"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/volume"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 	"time"
 )
 
+// actor is the component recorded in the status transitions of the service
+const actor = "Recovery"
+
 type Recovery struct {
 	transfers               service.Transfers
 	messages                service.Messages
@@ -41,6 +53,20 @@ type Recovery struct {
 	transferRepo            repository.Transfer
 	mirrorClient            client.MirrorNode
 	nodeClient              client.HederaNode
+	assetPolicy             service.AssetPolicy
+	refunds                 service.Refunds
+	refundRepo              repository.Refund
+	pause                   service.Pause
+	burnEvents              service.BurnEvent
+	burnEventRepo           repository.BurnEvent
+	ethClient               client.Ethereum
+	decimals                service.Decimals
+	feeRepo                 repository.Fee
+	scheduled               service.Scheduled
+	archive                 service.Archive
+	webhooks                service.Webhooks
+	progress                *progress
+	report                  *service.RecoveryReport
 	accountID               hederasdk.AccountID
 	topicID                 hederasdk.TopicID
 	configRecoveryTimestamp int64
@@ -57,6 +83,18 @@ func NewProcess(
 	transferRepo repository.Transfer,
 	mirrorClient client.MirrorNode,
 	nodeClient client.HederaNode,
+	assetPolicy service.AssetPolicy,
+	refunds service.Refunds,
+	refundRepo repository.Refund,
+	pause service.Pause,
+	burnEvents service.BurnEvent,
+	burnEventRepo repository.BurnEvent,
+	ethClient client.Ethereum,
+	decimals service.Decimals,
+	feeRepo repository.Fee,
+	scheduled service.Scheduled,
+	archive service.Archive,
+	webhooks service.Webhooks,
 ) (*Recovery, error) {
 	account, err := hederasdk.AccountIDFromString(c.Clients.Hedera.BridgeAccount)
 	if err != nil {
@@ -77,6 +115,19 @@ func NewProcess(
 		transferRepo:            transferRepo,
 		mirrorClient:            mirrorClient,
 		nodeClient:              nodeClient,
+		assetPolicy:             assetPolicy,
+		refunds:                 refunds,
+		refundRepo:              refundRepo,
+		pause:                   pause,
+		burnEvents:              burnEvents,
+		burnEventRepo:           burnEventRepo,
+		ethClient:               ethClient,
+		decimals:                decimals,
+		feeRepo:                 feeRepo,
+		scheduled:               scheduled,
+		archive:                 archive,
+		webhooks:                webhooks,
+		progress:                &progress{},
 		accountID:               account,
 		topicID:                 topic,
 		configRecoveryTimestamp: c.Recovery.StartTimestamp,
@@ -118,7 +169,14 @@ func (r Recovery) Start(transfersFrom, messagesFrom, to int64) error {
 	r.logger.Infof("Starting Recovery Process for Transfers with interval [%s; %s]", timestamp.ToHumanReadable(transfersFrom), timestamp.ToHumanReadable(to))
 	r.logger.Infof("Starting Recovery Process for Messages with interval [%s; %s]", timestamp.ToHumanReadable(messagesFrom), timestamp.ToHumanReadable(to))
 
-	err := r.transfersRecovery(transfersFrom, to)
+	// Control actions are applied first, so that operations are not processed while the bridge is paused
+	err := r.controlMessagesRecovery(messagesFrom, to)
+	if err != nil {
+		r.logger.Errorf("Control Messages Recovery failed: [%s]", err)
+		return err
+	}
+
+	_, err = r.transfersRecovery(transfersFrom, to)
 	if err != nil {
 		r.logger.Errorf("Transfers Recovery failed: [%s]", err)
 		return err
@@ -141,46 +199,188 @@ func (r Recovery) Start(transfersFrom, messagesFrom, to int64) error {
 }
 
 // transfersRecovery queries all incoming Transfer Transactions for the specified AccountID occurring between `from` and `to`
-// Performs sanity checks and persists them in the database
-func (r Recovery) transfersRecovery(from int64, to int64) error {
+// Performs sanity checks and persists them in the database. Transactions, which are already recorded, are skipped.
+// Returns the transfers persisted as recovered
+func (r Recovery) transfersRecovery(from int64, to int64) ([]*transfer.Transfer, error) {
 	txns, err := r.mirrorClient.GetAccountCreditTransactionsBetween(r.accountID, from, to)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(txns) == 0 {
 		r.logger.Infof("No Transfers found to recover for Account [%s]", r.accountID)
-		return nil
+		return nil, nil
 	}
 
 	r.logger.Infof("Found [%d] unprocessed TXns for Account [%s]", len(txns), r.accountID)
+	r.progress.found(service.RecoveryScopeTransfers, len(txns))
+	var recovered []*transfer.Transfer
 	for _, tx := range txns {
-		amount, nativeAsset, err := tx.GetIncomingTransfer(r.accountID.String())
+		t, err := r.recoverTransfer(tx)
 		if err != nil {
-			r.logger.Errorf("[%s] - Skipping recovery. Invalid amount. Error: [%s]", tx.TransactionID, err)
+			r.progress.skipped(service.RecoveryScopeTransfers)
+			r.skip(service.RecoveryScopeTransfers, tx.TransactionID, err.Error())
 			continue
 		}
+		if t != nil {
+			recovered = append(recovered, t)
+		}
+	}
 
-		wrappedAsset, err := r.contracts.ToWrapped(nativeAsset)
-		if err != nil {
-			r.logger.Errorf("[%s] - Could not parse native asset [%s] - Error: [%s]", tx.TransactionID, nativeAsset, err)
-			continue
+	r.logger.Infof("[%s] - Successfully recovered [%d] transfer TXns", r.accountID, len(txns))
+	return recovered, nil
+}
+
+// recoverTransfer persists the incoming transaction, unless it is already recorded.
+// Returns the transfer, if it is persisted as recovered
+func (r Recovery) recoverTransfer(tx mirror_node.Transaction) (*transfer.Transfer, error) {
+	existing, err := r.transferRepo.GetByTransactionId(tx.TransactionID)
+	// Pruned transfers are looked up in the archive, so that they are not recovered again
+	if err == nil && existing == nil {
+		existing, err = r.archive.Transfer(tx.TransactionID)
+	}
+	if err != nil {
+		r.logger.Errorf("[%s] - Skipping recovery. Failed to get db record. Error: [%s]", tx.TransactionID, err)
+		return nil, err
+	}
+	if existing != nil {
+		r.logger.Debugf("[%s] - Skipping recovery. Transfer already recorded with status [%s]", tx.TransactionID, existing.Status)
+		r.progress.existing(service.RecoveryScopeTransfers)
+		r.skip(service.RecoveryScopeTransfers, tx.TransactionID, fmt.Sprintf("already recorded with status [%s]", existing.Status))
+		return nil, nil
+	}
+
+	amount, nativeAsset, err := tx.GetIncomingTransfer(r.accountID.String())
+	if err != nil {
+		r.logger.Errorf("[%s] - Skipping recovery. Invalid amount. Error: [%s]", tx.TransactionID, err)
+		return nil, err
+	}
+
+	_, err = memo.Validate(tx.MemoBase64)
+	if err != nil {
+		r.logger.Errorf("[%s] - Skipping recovery. Invalid memo [%s]. Error: [%s]", tx.TransactionID, tx.MemoBase64, err)
+		return nil, r.refund(tx, amount, nativeAsset, refund.ReasonInvalidMemo)
+	}
+
+	wrappedAsset, err := r.contracts.ToWrapped(nativeAsset)
+	if err != nil {
+		r.logger.Errorf("[%s] - Could not parse native asset [%s] - Error: [%s]", tx.TransactionID, nativeAsset, err)
+		if errors.Is(err, service.ErrUnsupportedAsset) {
+			return nil, r.refund(tx, amount, nativeAsset, refund.ReasonUnsupportedAsset)
+		}
+		return nil, err
+	}
+
+	m, err := r.transfers.SanityCheckTransfer(tx)
+	if err != nil {
+		r.logger.Errorf("[%s] - Skipping recovery. Failed sanity check. Error: [%s]", tx.TransactionID, err)
+		if errors.Is(err, service.ErrInvalidStateProof) {
+			return nil, r.refund(tx, amount, nativeAsset, refund.ReasonInvalidStateProof)
 		}
+		return nil, err
+	}
 
-		m, err := r.transfers.SanityCheckTransfer(tx)
+	t := transfer.New(tx.TransactionID, m, nativeAsset, wrappedAsset, amount, r.contracts.Address().String())
+	violation, err := r.checkPolicy(tx, amount, nativeAsset, m)
+	if err != nil {
+		r.logger.Errorf("[%s] - Skipping recovery. Asset policy check failed. Error: [%s]", tx.TransactionID, err)
+		return nil, err
+	}
+	if r.report != nil {
+		r.reportTransfer(*t, tx.ConsensusTimestamp, violation)
+		return nil, nil
+	}
+	if violation != "" {
+		err = r.transfers.HoldTransfer(*t, violation)
 		if err != nil {
-			r.logger.Errorf("[%s] - Skipping recovery. Failed sanity check. Error: [%s]", tx.TransactionID, err)
+			r.logger.Errorf("[%s] - Skipping recovery. Unable to hold TX. Error: [%s]", tx.TransactionID, err)
+			return nil, err
+		}
+		r.progress.recovered(service.RecoveryScopeTransfers)
+		return nil, nil
+	}
+
+	err = r.transfers.SaveRecoveredTxn(tx.TransactionID, amount, nativeAsset, wrappedAsset, m)
+	if err != nil {
+		r.logger.Errorf("[%s] - Skipping recovery. Unable to persist TX. Error: [%s]", tx.TransactionID, err)
+		return nil, err
+	}
+	r.logger.Debugf("[%s] - Recovered transfer", tx.TransactionID)
+	r.progress.recovered(service.RecoveryScopeTransfers)
+	return t, nil
+}
+
+// refund returns the rejected deposit to its sender
+func (r Recovery) refund(tx mirror_node.Transaction, amount, nativeAsset, reason string) error {
+	sender, err := tx.GetSender(nativeAsset)
+	if err != nil {
+		r.logger.Errorf("[%s] - Could not find sender of the deposit. Error: [%s]", tx.TransactionID, err)
+		return err
+	}
+
+	deposit := transfer.New(tx.TransactionID, "", nativeAsset, "", amount, r.contracts.Address().String())
+	if r.report != nil {
+		r.report.Transfers = append(r.report.Transfers, service.RecoveryReportItem{
+			Scope:    service.RecoveryScopeTransfers,
+			ID:       tx.TransactionID,
+			Action:   service.RecoveryActionRefund,
+			Receiver: sender,
+			Asset:    nativeAsset,
+			Amount:   amount,
+			Reason:   reason,
+		})
+		return nil
+	}
+	err = r.refunds.Refund(*deposit, sender, reason)
+	if err != nil {
+		r.logger.Errorf("[%s] - Failed to refund deposit. Error: [%s]", tx.TransactionID, err)
+		return err
+	}
+	r.progress.recovered(service.RecoveryScopeTransfers)
+	return nil
+}
+
+func (r Recovery) checkPolicy(tx mirror_node.Transaction, amount, nativeAsset, receiver string) (string, error) {
+	bigAmount, err := big_numbers.ToBigInt(amount)
+	if err != nil {
+		return "", err
+	}
+
+	consensusTimestamp, err := timestamp.FromString(tx.ConsensusTimestamp)
+	if err != nil {
+		return "", err
+	}
+
+	check := r.assetPolicy.Check
+	if r.report != nil {
+		check = r.assetPolicy.Preview
+	}
+	return check(tx.TransactionID, volume.DirectionHederaToEVM, nativeAsset, receiver, bigAmount, consensusTimestamp/int64(time.Second))
+}
+
+// controlMessagesRecovery applies the control messages submitted to the Bridge topic between `from` and `to`
+func (r Recovery) controlMessagesRecovery(from, to int64) error {
+	if r.report != nil {
+		// Control messages are reported as skipped by the topic messages recovery
+		return nil
+	}
+
+	messages, err := r.mirrorClient.GetMessagesForTopicBetween(r.topicID, from, to)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		m, err := message.FromString(msg.Contents, msg.ConsensusTimestamp)
+		if err != nil || m.GetControl() == nil {
 			continue
 		}
-		err = r.transfers.SaveRecoveredTxn(tx.TransactionID, amount, nativeAsset, wrappedAsset, m)
+
+		err = r.pause.ProcessControlMessage(*m)
 		if err != nil {
-			r.logger.Errorf("[%s] - Skipping recovery. Unable to persist TX. Error: [%s]", tx.TransactionID, err)
-			continue
+			r.logger.Errorf("Skipping recovery of Control Message with timestamp [%s]. Error: [%s]", msg.ConsensusTimestamp, err)
 		}
-		r.logger.Debugf("[%s] - Recovered transfer", tx.TransactionID)
 	}
-
-	r.logger.Infof("[%s] - Successfully recovered [%d] transfer TXns", r.accountID, len(txns))
 	return nil
 }
 
@@ -198,18 +398,55 @@ func (r Recovery) topicMessagesRecovery(from, to int64) error {
 	}
 
 	r.logger.Debugf("Found [%d] unprocessed messages for Topic [%s]", len(messages), r.topicID)
+	r.progress.found(service.RecoveryScopeMessages, len(messages))
 	for _, msg := range messages {
 		m, err := message.FromString(msg.Contents, msg.ConsensusTimestamp)
 		if err != nil {
 			r.logger.Errorf("Skipping recovery of Topic Message with timestamp [%s]. Could not decode message. Error: [%s]", msg.ConsensusTimestamp, err)
+			r.progress.skipped(service.RecoveryScopeMessages)
+			r.skip(service.RecoveryScopeMessages, msg.ConsensusTimestamp, fmt.Sprintf("could not decode message: %s", err))
+			continue
+		}
+		if m.GetControl() != nil {
+			// Already applied by the control messages recovery
+			r.progress.existing(service.RecoveryScopeMessages)
+			r.skip(service.RecoveryScopeMessages, msg.ConsensusTimestamp, fmt.Sprintf("control message [%s] is not applied in dry-run", m.GetControl().Action))
+			continue
+		}
+
+		processed, err := r.messages.Processed(*m)
+		if err != nil {
+			r.logger.Errorf("Skipping recovery of Topic Message with timestamp [%s]. Error: [%s]", msg.ConsensusTimestamp, err)
+			r.progress.skipped(service.RecoveryScopeMessages)
+			r.skip(service.RecoveryScopeMessages, m.TransferID, err.Error())
+			continue
+		}
+		if processed {
+			r.progress.existing(service.RecoveryScopeMessages)
+			r.skip(service.RecoveryScopeMessages, m.TransferID, "signature already processed")
+			continue
+		}
+
+		if r.report != nil {
+			r.report.Signatures = append(r.report.Signatures, service.RecoveryReportItem{
+				Scope:     service.RecoveryScopeMessages,
+				ID:        m.TransferID,
+				Action:    service.RecoveryActionImport,
+				Receiver:  m.Receiver,
+				Asset:     m.WrappedAsset,
+				Amount:    m.Amount,
+				Timestamp: m.TransactionTimestamp,
+			})
 			continue
 		}
 
 		err = r.messages.ProcessSignature(*m)
 		if err != nil {
 			r.logger.Errorf("Error - could not handle recovery payload: [%s]", err)
+			r.progress.skipped(service.RecoveryScopeMessages)
 			continue
 		}
+		r.progress.recovered(service.RecoveryScopeMessages)
 	}
 
 	r.logger.Infof("Successfully recovered [%d] Messages for Topic [%s]", len(messages), r.topicID)
@@ -224,6 +461,11 @@ func (r Recovery) processUnfinishedOperations() error {
 	}
 
 	for _, t := range unprocessedTransfers {
+		if r.report != nil {
+			r.reportUnfinished(service.RecoveryScopeTransfers, t.TransactionID, service.RecoveryActionProcess, t.Status)
+			continue
+		}
+
 		transferMsg := transfer.New(
 			t.TransactionID,
 			t.Receiver,
@@ -238,5 +480,171 @@ func (r Recovery) processUnfinishedOperations() error {
 			continue
 		}
 	}
+
+	err = r.processUnfinishedRefunds()
+	if err != nil {
+		r.logger.Errorf("Failed to get unprocessed refunds. Error: [%s]", err)
+		return err
+	}
+
+	awaited, err := r.processUnfinishedBurnEvents()
+	if err != nil {
+		r.logger.Errorf("Failed to get unprocessed burn events. Error: [%s]", err)
+		return err
+	}
+
+	err = r.processSubmittedFees(awaited)
+	if err != nil {
+		r.logger.Errorf("Failed to get submitted fees. Error: [%s]", err)
+		return err
+	}
+	return nil
+}
+
+// processUnfinishedRefunds schedules again the refunds, which were not submitted,
+// f.e. because they were queued while the bridge was paused
+func (r Recovery) processUnfinishedRefunds() error {
+	refunds, err := r.refundRepo.GetInitial()
+	if err != nil {
+		return err
+	}
+
+	for _, rf := range refunds {
+		if r.report != nil {
+			r.reportUnfinished(service.RecoveryScopeTransfers, rf.TransferID, service.RecoveryActionRefund, rf.Status)
+			continue
+		}
+
+		err = r.refunds.Reconcile(rf.TransferID)
+		if err != nil {
+			r.logger.Errorf("[%s] - Failed to reconcile refund with status [%s]. Error: [%s]", rf.TransferID, rf.Status, err)
+		}
+	}
+	return nil
+}
+
+// processUnfinishedBurnEvents executes again the scheduled transactions of initial burn events and awaits
+// the outcome of submitted ones. Returns the submitted burn events, whose fees are updated with their outcome
+func (r Recovery) processUnfinishedBurnEvents() (map[string]bool, error) {
+	burnEvents, err := r.burnEventRepo.GetUnprocessed()
+	if err != nil {
+		return nil, err
+	}
+
+	awaited := make(map[string]bool)
+	for _, b := range burnEvents {
+		if r.report != nil {
+			action := service.RecoveryActionExecute
+			if b.Status == burn_event_status.StatusSubmitted {
+				action = service.RecoveryActionAwait
+				awaited[b.Id] = true
+			}
+			r.reportUnfinished(service.RecoveryScopeBurns, b.Id, action, b.Status)
+			continue
+		}
+
+		err = r.burnEvents.Reconcile(b.Id)
+		if err != nil {
+			r.logger.Errorf("[%s] - Failed to reconcile burn event with status [%s]. Error: [%s]", b.Id, b.Status, err)
+			continue
+		}
+		if b.Status == burn_event_status.StatusSubmitted {
+			awaited[b.Id] = true
+		}
+	}
+	return awaited, nil
+}
+
+// processSubmittedFees awaits the outcome of the scheduled transactions of submitted fees. Settled fees are awaited
+// with the scheduled transaction of their settlement. Fees of awaited burn events are skipped
+func (r Recovery) processSubmittedFees(awaitedBurnEvents map[string]bool) error {
+	fees, err := r.feeRepo.GetSubmitted()
+	if err != nil {
+		return err
+	}
+
+	settlements := make(map[string][]string)
+	owners := make(map[string][]service.WebhookData)
+	for _, f := range fees {
+		if f.SettlementID.Valid {
+			settlements[f.SettlementID.String] = append(settlements[f.SettlementID.String], f.TransactionID)
+			owners[f.SettlementID.String] = append(owners[f.SettlementID.String], feeOwner(f))
+			continue
+		}
+		if f.BurnEventID.Valid && awaitedBurnEvents[f.BurnEventID.String] {
+			continue
+		}
+		if r.report != nil {
+			r.reportUnfinished(scopeFees, f.TransactionID, service.RecoveryActionAwait, f.Status)
+			continue
+		}
+
+		r.logger.Infof("[%s] Fee - Awaiting submitted scheduled transaction.", f.TransactionID)
+		owner := feeOwner(f)
+		r.scheduled.Await(f.TransactionID, func(transactionID string) { r.onFeeCompleted(transactionID, owner) }, r.onFeeFailed)
+	}
+
+	for settlementID, txIds := range settlements {
+		txIds, settlementOwners := txIds, owners[settlementID]
+		if r.report != nil {
+			r.reportUnfinished(scopeSettlements, settlementID, service.RecoveryActionAwait, fee.StatusSubmitted)
+			continue
+		}
+		settlement, err := r.feeRepo.GetSettlement(settlementID)
+		if err != nil {
+			return err
+		}
+		if settlement == nil {
+			r.logger.Errorf("[%s] Settlement - Settlement of [%d] submitted fees not found.", settlementID, len(txIds))
+			continue
+		}
+		r.logger.Infof("[%s] Settlement - Awaiting submitted scheduled transaction [%s] of [%d] fees.", settlementID, settlement.TransactionID, len(txIds))
+		r.scheduled.Await(settlement.TransactionID,
+			func(transactionID string) {
+				err := r.feeRepo.UpdateSettlementCompleted(txIds, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxExecuted})
+				if err != nil {
+					r.logger.Errorf("[%s] Settlement - Failed to update status completed. Error: [%s]", transactionID, err)
+					return
+				}
+				for _, owner := range settlementOwners {
+					r.notifyFeeCompleted(transactionID, owner)
+				}
+			},
+			func(transactionID string) {
+				err := r.feeRepo.UpdateSettlementAccrued(txIds, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxFailed})
+				if err != nil {
+					r.logger.Errorf("[%s] Settlement - Failed to update status accrued. Error: [%s]", transactionID, err)
+				}
+			})
+	}
 	return nil
 }
+
+func (r Recovery) onFeeCompleted(transactionID string, owner service.WebhookData) {
+	err := r.feeRepo.UpdateStatusCompleted(transactionID, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxExecuted})
+	if err != nil {
+		r.logger.Errorf("[%s] Fee - Failed to update status completed. Error: [%s]", transactionID, err)
+		return
+	}
+	r.notifyFeeCompleted(transactionID, owner)
+}
+
+func (r Recovery) notifyFeeCompleted(transactionID string, owner service.WebhookData) {
+	owner.TransactionID = transactionID
+	r.webhooks.Notify(service.WebhookFeeCompleted, owner)
+}
+
+// feeOwner returns the transfer or burn event, which the fee is charged for
+func feeOwner(f *entity.Fee) service.WebhookData {
+	return service.WebhookData{
+		TransferID:  f.TransferID.String,
+		BurnEventID: f.BurnEventID.String,
+	}
+}
+
+func (r Recovery) onFeeFailed(transactionID string) {
+	err := r.feeRepo.UpdateStatusFailed(transactionID, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxFailed})
+	if err != nil {
+		r.logger.Errorf("[%s] Fee - Failed to update status failed. Error: [%s]", transactionID, err)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recovery
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_ProcessSubmittedFees(t *testing.T) {
+	r := setup()
+	r.feeRepo = mocks.MFeeRepository
+	r.scheduled = mocks.MScheduledService
+
+	mocks.MFeeRepository.On("GetSubmitted").Return([]*entity.Fee{
+		{TransactionID: "0.0.1-1-1", Status: fee.StatusSubmitted, TransferID: sql.NullString{String: "transfer", Valid: true}},
+		{TransactionID: "0.0.1-2-2", Status: fee.StatusSubmitted, BurnEventID: sql.NullString{String: "awaited", Valid: true}},
+		{TransactionID: "0.0.1-3-3", Status: fee.StatusSubmitted, BurnEventID: sql.NullString{String: "completed", Valid: true}},
+		{TransactionID: "transfer-1", Status: fee.StatusSubmitted, SettlementID: sql.NullString{String: "fees-HBAR-60", Valid: true}},
+		{TransactionID: "transfer-2", Status: fee.StatusSubmitted, SettlementID: sql.NullString{String: "fees-HBAR-60", Valid: true}},
+	}, nil)
+	mocks.MFeeRepository.On("GetSettlement", "fees-HBAR-60").Return(&entity.FeeSettlement{ID: "fees-HBAR-60", TransactionID: "0.0.1-4-4"}, nil)
+	mocks.MScheduledService.On("Await", "0.0.1-1-1").Return()
+	mocks.MScheduledService.On("Await", "0.0.1-3-3").Return()
+	mocks.MScheduledService.On("Await", "0.0.1-4-4").Return()
+
+	err := r.processSubmittedFees(map[string]bool{"awaited": true})
+
+	assert.Nil(t, err)
+	mocks.MScheduledService.AssertNumberOfCalls(t, "Await", 3)
+	mocks.MScheduledService.AssertNotCalled(t, "Await", "0.0.1-2-2")
+	mocks.MScheduledService.AssertNotCalled(t, "Await", "transfer-1")
+}
+
+func Test_OnFeeCompleted(t *testing.T) {
+	r := setup()
+	r.feeRepo = mocks.MFeeRepository
+	r.webhooks = mocks.MWebhooksService
+	owner := feeOwner(&entity.Fee{TransferID: sql.NullString{String: "transfer", Valid: true}})
+
+	mocks.MFeeRepository.On("UpdateStatusCompleted", "0.0.1-1-1", mock.Anything).Return(nil)
+	mocks.MWebhooksService.On("Notify", service.WebhookFeeCompleted, mock.Anything).Return()
+
+	r.onFeeCompleted("0.0.1-1-1", owner)
+
+	mocks.MWebhooksService.AssertCalled(t, "Notify", service.WebhookFeeCompleted, service.WebhookData{TransferID: "transfer", TransactionID: "0.0.1-1-1"})
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recovery
+
+import (
+	"time"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/helper/timestamp"
+	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/model/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
+)
+
+const (
+	// scopeFees and scopeSettlements are reported for the submitted fees, which are awaited
+	scopeFees        = "fees"
+	scopeSettlements = "settlements"
+)
+
+// Report returns what the recovery of the scopes in the interval [from; to) (in nanoseconds) and the restart of
+// the unfinished operations would do. Nothing is persisted and no transactions are submitted
+func (r Recovery) Report(from, to int64, scopes []string) (*service.RecoveryReport, error) {
+	err := validateScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	// The mirror node intervals exclude their start
+	return r.dryRun(from-1, from-1, from, to, scopes)
+}
+
+// DryRun returns what Start would do for the computed intervals. Nothing is persisted and no transactions are submitted
+func (r Recovery) DryRun(transfersFrom, messagesFrom, to int64) (*service.RecoveryReport, error) {
+	return r.dryRun(transfersFrom, messagesFrom, 0, to, []string{service.RecoveryScopeTransfers, service.RecoveryScopeMessages})
+}
+
+func (r Recovery) dryRun(transfersFrom, messagesFrom, burnsFrom, to int64, scopes []string) (*service.RecoveryReport, error) {
+	r.logger.Infof("Starting Recovery dry-run with interval [%s; %s)", timestamp.ToHumanReadable(transfersFrom), timestamp.ToHumanReadable(to))
+
+	// The copy reports the operations instead of processing them and does not track the progress of the on-demand recovery
+	r.report = &service.RecoveryReport{
+		TransfersFrom: transfersFrom,
+		MessagesFrom:  messagesFrom,
+		To:            to,
+		Transfers:     []service.RecoveryReportItem{},
+		Signatures:    []service.RecoveryReportItem{},
+		Burns:         []service.RecoveryReportItem{},
+		Unfinished:    []service.RecoveryReportItem{},
+		Skipped:       []service.RecoveryReportItem{},
+	}
+	r.progress = &progress{}
+
+	var err error
+	for _, scope := range service.RecoveryScopes {
+		if !contains(scopes, scope) {
+			continue
+		}
+
+		switch scope {
+		case service.RecoveryScopeTransfers:
+			_, err = r.transfersRecovery(transfersFrom, to)
+		case service.RecoveryScopeMessages:
+			err = r.topicMessagesRecovery(messagesFrom, to)
+		case service.RecoveryScopeBurns:
+			err = r.burnsRecovery(burnsFrom, to)
+		}
+		if err != nil {
+			r.logger.Errorf("Recovery dry-run of [%s] failed. Error: [%s]", scope, err)
+			return nil, err
+		}
+	}
+
+	err = r.processUnfinishedOperations()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.report, nil
+}
+
+// reportTransfer reports the transfer as recovered or held, if it violates the asset policy
+func (r Recovery) reportTransfer(t transfer.Transfer, consensusTimestamp, violation string) {
+	action := service.RecoveryActionRecover
+	if violation != "" {
+		action = service.RecoveryActionHold
+	}
+	ts, _ := timestamp.FromString(consensusTimestamp)
+
+	r.report.Transfers = append(r.report.Transfers, service.RecoveryReportItem{
+		Scope:     service.RecoveryScopeTransfers,
+		ID:        t.TransactionId,
+		Action:    action,
+		Receiver:  t.Receiver,
+		Asset:     t.NativeAsset,
+		Amount:    t.Amount,
+		Timestamp: ts,
+		Reason:    violation,
+	})
+}
+
+// reportBurn reports the burn event as processed or held, if it violates the asset policy
+func (r Recovery) reportBurn(event burn_event.BurnEvent, violation string) {
+	action := service.RecoveryActionProcess
+	if violation != "" {
+		action = service.RecoveryActionHold
+	}
+
+	r.report.Burns = append(r.report.Burns, service.RecoveryReportItem{
+		Scope:     service.RecoveryScopeBurns,
+		ID:        event.Id,
+		Action:    action,
+		Receiver:  event.Recipient.String(),
+		Asset:     event.WrappedAsset,
+		Amount:    event.Amount.String(),
+		Timestamp: event.Timestamp * int64(time.Second),
+		Reason:    violation,
+	})
+}
+
+// reportUnfinished reports the operation, which is driven again on recovery
+func (r Recovery) reportUnfinished(scope, id, action, status string) {
+	r.report.Unfinished = append(r.report.Unfinished, service.RecoveryReportItem{
+		Scope:  scope,
+		ID:     id,
+		Action: action,
+		Status: status,
+	})
+}
+
+// skip reports the operation as skipped, if the recovery is a dry-run
+func (r Recovery) skip(scope, id, reason string) {
+	if r.report == nil {
+		return
+	}
+
+	r.report.Skipped = append(r.report.Skipped, service.RecoveryReportItem{
+		Scope:  scope,
+		ID:     id,
+		Action: service.RecoveryActionSkip,
+		Reason: reason,
+	})
+}
+
+func contains(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recovery
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	mirror_node "github.com/limechain/hedera-eth-bridge-validator/app/clients/hedera/mirror-node"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	burn_event_status "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/refund"
+	transfer_status "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/constants"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Report(t *testing.T) {
+	r := setup()
+	r.burnEventRepo = mocks.MBurnEventRepository
+	r.feeRepo = mocks.MFeeRepository
+	r.refundRepo = mocks.MRefundRepository
+	r.scheduled = mocks.MScheduledService
+
+	mocks.MHederaMirrorClient.On("GetAccountCreditTransactionsBetween", accountID, int64(99), int64(200)).
+		Return([]mirror_node.Transaction{{TransactionID: transactionID}}, nil)
+	mocks.MTransferRepository.On("GetByTransactionId", transactionID).
+		Return(&entity.Transfer{TransactionID: transactionID, Status: transfer_status.StatusCompleted}, nil)
+	mocks.MTransferRepository.On("GetUnprocessedTransfers").
+		Return([]*entity.Transfer{{TransactionID: "0.0.2222-2-2", Status: transfer_status.StatusRecovered}}, nil)
+	mocks.MRefundRepository.On("GetInitial").
+		Return([]*entity.Refund{{TransferID: "0.0.3333-3-3", Status: refund.StatusInitial}}, nil)
+	mocks.MBurnEventRepository.On("GetUnprocessed").
+		Return([]*entity.BurnEvent{{Id: "0xab-1", Status: burn_event_status.StatusSubmitted}}, nil)
+	mocks.MFeeRepository.On("GetSubmitted").Return([]*entity.Fee{}, nil)
+
+	report, err := r.Report(100, 200, []string{service.RecoveryScopeTransfers})
+
+	assert.Nil(t, err)
+	assert.Empty(t, report.Transfers)
+	assert.Equal(t, []service.RecoveryReportItem{
+		{Scope: service.RecoveryScopeTransfers, ID: transactionID, Action: service.RecoveryActionSkip, Reason: "already recorded with status [COMPLETED]"},
+	}, report.Skipped)
+	assert.Equal(t, []service.RecoveryReportItem{
+		{Scope: service.RecoveryScopeTransfers, ID: "0.0.2222-2-2", Action: service.RecoveryActionProcess, Status: transfer_status.StatusRecovered},
+		{Scope: service.RecoveryScopeTransfers, ID: "0.0.3333-3-3", Action: service.RecoveryActionRefund, Status: refund.StatusInitial},
+		{Scope: service.RecoveryScopeBurns, ID: "0xab-1", Action: service.RecoveryActionAwait, Status: burn_event_status.StatusSubmitted},
+	}, report.Unfinished)
+	mocks.MTransferService.AssertNotCalled(t, "ProcessTransfer")
+	mocks.MScheduledService.AssertNotCalled(t, "Await")
+	assert.Nil(t, r.Progress())
+}
+
+func Test_ReportUnknownScope(t *testing.T) {
+	r := setup()
+
+	report, err := r.Report(100, 200, []string{"unknown"})
+
+	assert.Nil(t, report)
+	assert.Error(t, err)
+}
+
+// deposit returns an hbar deposit with a valid memo
+func deposit() mirror_node.Transaction {
+	return mirror_node.Transaction{
+		TransactionID: transactionID,
+		MemoBase64:    base64.StdEncoding.EncodeToString([]byte("0x0000000000000000000000000000000000000001")),
+		Transfers: []mirror_node.Transfer{
+			{Account: "0.0.2222", Amount: -100},
+			{Account: accountID.String(), Amount: 100},
+		},
+	}
+}
+
+func Test_ReportRefundsInvalidStateProof(t *testing.T) {
+	r := setup()
+	r.contracts = mocks.MBridgeContractService
+	r.report = &service.RecoveryReport{}
+	tx := deposit()
+
+	mocks.MTransferRepository.On("GetByTransactionId", transactionID).Return((*entity.Transfer)(nil), nil)
+	mocks.MArchiveService.On("Transfer", transactionID).Return((*entity.Transfer)(nil), nil)
+	mocks.MBridgeContractService.On("ToWrapped", constants.Hbar).Return("0xwrapped", nil)
+	mocks.MTransferService.On("SanityCheckTransfer", tx).Return(nil, service.ErrInvalidStateProof)
+
+	recovered, err := r.recoverTransfer(tx)
+
+	assert.Nil(t, err)
+	assert.Nil(t, recovered)
+	assert.Equal(t, []service.RecoveryReportItem{
+		{Scope: service.RecoveryScopeTransfers, ID: transactionID, Action: service.RecoveryActionRefund, Receiver: "0.0.2222", Asset: constants.Hbar, Amount: "100", Reason: refund.ReasonInvalidStateProof},
+	}, r.report.Transfers)
+}
+
+func Test_ReportSkipsUnavailableStateProof(t *testing.T) {
+	r := setup()
+	r.contracts = mocks.MBridgeContractService
+	r.report = &service.RecoveryReport{}
+	tx := deposit()
+
+	mocks.MTransferRepository.On("GetByTransactionId", transactionID).Return((*entity.Transfer)(nil), nil)
+	mocks.MArchiveService.On("Transfer", transactionID).Return((*entity.Transfer)(nil), nil)
+	mocks.MBridgeContractService.On("ToWrapped", constants.Hbar).Return("0xwrapped", nil)
+	mocks.MTransferService.On("SanityCheckTransfer", tx).Return(nil, errors.New("Could not GET state proof"))
+
+	recovered, err := r.recoverTransfer(tx)
+
+	assert.Error(t, err)
+	assert.Nil(t, recovered)
+	assert.Empty(t, r.report.Transfers)
+}
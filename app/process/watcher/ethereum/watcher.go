@@ -17,7 +17,12 @@
 package ethereum
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/hashgraph/hedera-sdk-go/v2"
 	routerContract "github.com/limechain/hedera-eth-bridge-validator/app/clients/ethereum/contracts/router"
 	"github.com/limechain/hedera-eth-bridge-validator/app/core/pair"
@@ -25,34 +30,60 @@ import (
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
 	hederahelper "github.com/limechain/hedera-eth-bridge-validator/app/helper/hedera"
 	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/model/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/volume"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	c "github.com/limechain/hedera-eth-bridge-validator/config"
 	"github.com/limechain/hedera-eth-bridge-validator/constants"
 	log "github.com/sirupsen/logrus"
+	"strings"
 )
 
 type Watcher struct {
-	config    config.Ethereum
-	contracts service.Contracts
-	ethClient client.Ethereum
-	logger    *log.Entry
+	config      config.Ethereum
+	contracts   service.Contracts
+	ethClient   client.Ethereum
+	burnEvents  service.BurnEvent
+	assetPolicy service.AssetPolicy
+	decimals    service.Decimals
+	webhooks    service.Webhooks
+	logger      *log.Entry
 }
 
-func NewWatcher(contracts service.Contracts, ethClient client.Ethereum, config config.Ethereum) *Watcher {
+func NewWatcher(
+	contracts service.Contracts,
+	ethClient client.Ethereum,
+	config config.Ethereum,
+	burnEvents service.BurnEvent,
+	assetPolicy service.AssetPolicy,
+	decimals service.Decimals,
+	webhooks service.Webhooks,
+) *Watcher {
 	return &Watcher{
-		config:    config,
-		contracts: contracts,
-		ethClient: ethClient,
-		logger:    c.GetLoggerFor(fmt.Sprintf("Ethereum Router Watcher [%s]", config.RouterContractAddress)),
+		config:      config,
+		contracts:   contracts,
+		ethClient:   ethClient,
+		burnEvents:  burnEvents,
+		assetPolicy: assetPolicy,
+		decimals:    decimals,
+		webhooks:    webhooks,
+		logger:      c.GetLoggerFor(fmt.Sprintf("Ethereum Router Watcher [%s]", config.RouterContractAddress)),
 	}
 }
 
 func (ew *Watcher) Watch(queue *pair.Queue) {
-	go ew.listenForEvents(queue)
+	previous := make(chan struct{})
+	close(previous)
+	go ew.listenForEvents(queue, previous)
+	// Mint events are only observed to notify the webhook subscriptions
+	if ew.webhooks.Enabled() {
+		go ew.listenForMintEvents()
+	}
 	ew.logger.Infof("Listening for events at contract [%s]", ew.config.RouterContractAddress)
 }
 
-func (ew *Watcher) listenForEvents(q *pair.Queue) {
+// listenForEvents subscribes for burn events. The asset policy checks are ordered like the events,
+// so that the daily caps are evaluated equally by all validators. previous is closed, once the last received event is checked
+func (ew *Watcher) listenForEvents(q *pair.Queue, previous <-chan struct{}) {
 	events := make(chan *routerContract.RouterBurn)
 	sub, err := ew.contracts.WatchBurnEventLogs(nil, events)
 	if err != nil {
@@ -64,15 +95,22 @@ func (ew *Watcher) listenForEvents(q *pair.Queue) {
 		select {
 		case err := <-sub.Err():
 			ew.logger.Errorf("Burn Event Logs subscription failed. Error: [%s].", err)
-			go ew.listenForEvents(q)
+			go ew.listenForEvents(q, previous)
 			return
 		case eventLog := <-events:
-			go ew.handleLog(eventLog, q)
+			done := make(chan struct{})
+			go ew.handleLog(eventLog, q, previous, done)
+			previous = done
 		}
 	}
 }
 
-func (ew *Watcher) handleLog(eventLog *routerContract.RouterBurn, q *pair.Queue) {
+func (ew *Watcher) handleLog(eventLog *routerContract.RouterBurn, q *pair.Queue, previous <-chan struct{}, done chan<- struct{}) {
+	defer func() {
+		// Skipped operations wait for the preceding ones as well, so that the order is kept
+		<-previous
+		close(done)
+	}()
 	ew.logger.Debugf("[%s] - New Burn Event Log received. Waiting block confirmations", eventLog.Raw.TxHash)
 
 	if eventLog.Raw.Removed {
@@ -97,7 +135,7 @@ func (ew *Watcher) handleLog(eventLog *routerContract.RouterBurn, q *pair.Queue)
 	}
 
 	burnEvent := &burn_event.BurnEvent{
-		Amount:       eventLog.Amount.Int64(),
+		Amount:       eventLog.Amount,
 		Id:           fmt.Sprintf("%s-%d", eventLog.Raw.TxHash, eventLog.Raw.Index),
 		Recipient:    recipientAccount,
 		NativeAsset:  nativeAsset,
@@ -110,6 +148,31 @@ func (ew *Watcher) handleLog(eventLog *routerContract.RouterBurn, q *pair.Queue)
 		return
 	}
 
+	header, err := ew.ethClient.GetClient().HeaderByHash(context.Background(), eventLog.Raw.BlockHash)
+	if err != nil {
+		ew.logger.Errorf("[%s] - Failed to retrieve block [%s]. Error: [%s].", eventLog.Raw.TxHash, eventLog.Raw.BlockHash, err)
+		return
+	}
+	burnEvent.Timestamp = int64(header.Time)
+
+	// Limits are in native asset units
+	nativeAmount, _, err := ew.decimals.ToNative(nativeAsset, burnEvent.WrappedAsset, burnEvent.Amount)
+	if err != nil {
+		ew.logger.Errorf("[%s] - Failed to convert amount to native decimals. Error: [%s].", eventLog.Raw.TxHash, err)
+		return
+	}
+
+	<-previous
+	violation, err := ew.assetPolicy.Check(burnEvent.Id, volume.DirectionEVMToHedera, nativeAsset, recipientAccount.String(), nativeAmount, burnEvent.Timestamp)
+	if err != nil {
+		ew.logger.Errorf("[%s] - Asset policy check failed. Error: [%s].", eventLog.Raw.TxHash, err)
+		return
+	}
+	if violation != "" {
+		ew.burnEvents.HoldEvent(*burnEvent, violation)
+		return
+	}
+
 	ew.logger.Infof("[%s] - New Burn Event Log from [%s], with Amount [%s], Receiver Address [%s] has been found.",
 		eventLog.Raw.TxHash.String(),
 		eventLog.Account.Hex(),
@@ -118,3 +181,93 @@ func (ew *Watcher) handleLog(eventLog *routerContract.RouterBurn, q *pair.Queue)
 
 	q.Push(&pair.Message{Payload: burnEvent})
 }
+
+func (ew *Watcher) listenForMintEvents() {
+	events := make(chan *routerContract.RouterMint)
+	sub, err := ew.contracts.WatchMintEventLogs(nil, events)
+	if err != nil {
+		ew.logger.Errorf("Failed to subscribe for Mint Event Logs for contract address [%s]. Error [%s].", ew.config.RouterContractAddress, err)
+		return
+	}
+
+	for {
+		select {
+		case err := <-sub.Err():
+			ew.logger.Errorf("Mint Event Logs subscription failed. Error: [%s].", err)
+			go ew.listenForMintEvents()
+			return
+		case eventLog := <-events:
+			go ew.handleMintLog(eventLog)
+		}
+	}
+}
+
+func (ew *Watcher) handleMintLog(eventLog *routerContract.RouterMint) {
+	ew.logger.Debugf("[%s] - New Mint Event Log received. Waiting block confirmations", eventLog.Raw.TxHash)
+
+	if eventLog.Raw.Removed {
+		ew.logger.Debugf("[%s] - Uncle block transaction was removed.", eventLog.Raw.TxHash)
+		return
+	}
+
+	err := ew.ethClient.WaitForConfirmations(eventLog.Raw)
+	if err != nil {
+		ew.logger.Errorf("[%s] - Failed waiting for confirmation before processing. Error: %s", eventLog.Raw.TxHash, err)
+		return
+	}
+
+	data := service.WebhookData{
+		TransactionID: eventLog.Raw.TxHash.String(),
+		Receiver:      eventLog.Account.String(),
+		Amount:        eventLog.Amount.String(),
+		WrappedAsset:  eventLog.WrappedAsset.String(),
+	}
+	// The transfer id is indexed as its hash, so it is read from the input of the mint transaction instead
+	transferID, err := ew.mintTransferID(eventLog)
+	if err != nil {
+		ew.logger.Warnf("[%s] - Failed to resolve transfer of Mint Event Log. Error: [%s].", eventLog.Raw.TxHash, err)
+	} else {
+		data.TransferID = transferID
+	}
+
+	ew.logger.Infof("[%s] - New Mint Event Log for transfer [%s], with Amount [%s], Receiver Address [%s] has been found.",
+		eventLog.Raw.TxHash.String(),
+		data.TransferID,
+		eventLog.Amount.String(),
+		eventLog.Account.Hex())
+
+	ew.webhooks.Notify(service.WebhookMintObserved, data)
+}
+
+// mintTransferID decodes the transfer id from the input of the transaction, which called the mint function of the Router contract
+func (ew *Watcher) mintTransferID(eventLog *routerContract.RouterMint) (string, error) {
+	tx, _, err := ew.ethClient.GetClient().TransactionByHash(context.Background(), eventLog.Raw.TxHash)
+	if err != nil {
+		return "", err
+	}
+	if tx.To() == nil || *tx.To() != common.HexToAddress(ew.config.RouterContractAddress) || len(tx.Data()) < 4 {
+		return "", errors.New("transaction is not a call to the Router contract")
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(routerContract.RouterABI))
+	if err != nil {
+		return "", err
+	}
+	method, err := parsed.MethodById(tx.Data()[:4])
+	if err != nil {
+		return "", err
+	}
+	if method.Name != "mint" {
+		return "", errors.New(fmt.Sprintf("unexpected method [%s]", method.Name))
+	}
+
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		return "", err
+	}
+	transactionId, ok := args[0].([]byte)
+	if !ok || crypto.Keccak256Hash(transactionId) != eventLog.TransactionId {
+		return "", errors.New("transaction id does not match the Mint Event Log")
+	}
+	return string(transactionId), nil
+}
@@ -25,8 +25,12 @@ import (
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/client"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	big_numbers "github.com/limechain/hedera-eth-bridge-validator/app/helper/big-numbers"
+	"github.com/limechain/hedera-eth-bridge-validator/app/helper/memo"
 	"github.com/limechain/hedera-eth-bridge-validator/app/helper/timestamp"
 	"github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/refund"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/volume"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -42,6 +46,8 @@ type Watcher struct {
 	startTimestamp   int64
 	logger           *log.Entry
 	contractService  service.Contracts
+	assetPolicy      service.AssetPolicy
+	refunds          service.Refunds
 }
 
 func NewWatcher(
@@ -52,6 +58,8 @@ func NewWatcher(
 	repository repository.Status,
 	startTimestamp int64,
 	contractService service.Contracts,
+	assetPolicy service.AssetPolicy,
+	refunds service.Refunds,
 ) *Watcher {
 	id, err := hedera.AccountIDFromString(accountID)
 	if err != nil {
@@ -67,6 +75,8 @@ func NewWatcher(
 		startTimestamp:   startTimestamp,
 		logger:           config.GetLoggerFor(fmt.Sprintf("[%s] Transfer Watcher", accountID)),
 		contractService:  contractService,
+		assetPolicy:      assetPolicy,
+		refunds:          refunds,
 	}
 }
 
@@ -92,7 +102,9 @@ func (ctw Watcher) Watch(q *pair.Queue) {
 		ctw.updateStatusTimestamp(ctw.startTimestamp)
 	}
 
-	go ctw.beginWatching(q)
+	previous := make(chan struct{})
+	close(previous)
+	go ctw.beginWatching(q, previous)
 	ctw.logger.Infof("Watching for Transfers after Timestamp [%s]", timestamp.ToHumanReadable(ctw.startTimestamp))
 }
 
@@ -104,26 +116,30 @@ func (ctw Watcher) updateStatusTimestamp(ts int64) {
 	ctw.logger.Tracef("Updated Transfer Watcher timestamp to [%s]", timestamp.ToHumanReadable(ts))
 }
 
-func (ctw Watcher) beginWatching(q *pair.Queue) {
-	milestoneTimestamp, err := ctw.statusRepository.GetLastFetchedTimestamp(ctw.accountID.String())
-	if err != nil {
-		ctw.logger.Fatalf("Failed to retrieve Transfer Watcher Status timestamp. Error [%s]", err)
-	}
-
+// beginWatching polls for new transactions. The asset policy checks are ordered by the consensus timestamps of the transactions,
+// so that the daily caps are evaluated equally by all validators. previous is closed, once the last polled transaction is checked
+func (ctw Watcher) beginWatching(q *pair.Queue, previous <-chan struct{}) {
 	for {
+		// The timestamp is read on every poll, so that it can be reset by the operators
+		milestoneTimestamp, err := ctw.statusRepository.GetLastFetchedTimestamp(ctw.accountID.String())
+		if err != nil {
+			ctw.logger.Fatalf("Failed to retrieve Transfer Watcher Status timestamp. Error [%s]", err)
+		}
+
 		transactions, e := ctw.client.GetAccountCreditTransactionsAfterTimestamp(ctw.accountID, milestoneTimestamp)
 		if e != nil {
 			ctw.logger.Errorf("Suddenly stopped monitoring account - [%s]", e)
-			go ctw.beginWatching(q)
+			go ctw.beginWatching(q, previous)
 			return
 		}
 
 		ctw.logger.Tracef("Polling found [%d] Transactions", len(transactions.Transactions))
 		if len(transactions.Transactions) > 0 {
 			for _, tx := range transactions.Transactions {
-				go ctw.processTransaction(tx, q)
+				done := make(chan struct{})
+				go ctw.processTransaction(tx, q, previous, done)
+				previous = done
 			}
-			var err error
 			milestoneTimestamp, err = timestamp.FromString(transactions.Transactions[len(transactions.Transactions)-1].ConsensusTimestamp)
 			if err != nil {
 				ctw.logger.Errorf("Unable to parse latest transfer timestamp. Error - [%s].", err)
@@ -136,7 +152,12 @@ func (ctw Watcher) beginWatching(q *pair.Queue) {
 	}
 }
 
-func (ctw Watcher) processTransaction(tx mirror_node.Transaction, q *pair.Queue) {
+func (ctw Watcher) processTransaction(tx mirror_node.Transaction, q *pair.Queue, previous <-chan struct{}, done chan<- struct{}) {
+	defer func() {
+		// Skipped operations wait for the preceding ones as well, so that the order is kept
+		<-previous
+		close(done)
+	}()
 	ctw.logger.Infof("New Transaction with ID: [%s]", tx.TransactionID)
 	amount, nativeAsset, err := tx.GetIncomingTransfer(ctw.accountID.String())
 	if err != nil {
@@ -144,18 +165,75 @@ func (ctw Watcher) processTransaction(tx mirror_node.Transaction, q *pair.Queue)
 		return
 	}
 
+	_, err = memo.Validate(tx.MemoBase64)
+	if err != nil {
+		ctw.logger.Errorf("[%s] - Invalid memo [%s]. Error: [%s]", tx.TransactionID, tx.MemoBase64, err)
+		ctw.refund(tx, amount, nativeAsset, refund.ReasonInvalidMemo)
+		return
+	}
+
 	wrappedAsset, err := ctw.contractService.ToWrapped(nativeAsset)
 	if err != nil {
 		ctw.logger.Errorf("[%s] - Could not parse native asset [%s] - Error: [%s]", tx.TransactionID, nativeAsset, err)
+		if errors.Is(err, service.ErrUnsupportedAsset) {
+			ctw.refund(tx, amount, nativeAsset, refund.ReasonUnsupportedAsset)
+		}
 		return
 	}
 
 	ethAddress, err := ctw.transfers.SanityCheckTransfer(tx)
 	if err != nil {
 		ctw.logger.Errorf("[%s] - Sanity check failed. Error: [%s]", tx.TransactionID, err)
+		if errors.Is(err, service.ErrInvalidStateProof) {
+			ctw.refund(tx, amount, nativeAsset, refund.ReasonInvalidStateProof)
+		}
 		return
 	}
 
 	transferMessage := transfer.New(tx.TransactionID, ethAddress, nativeAsset, wrappedAsset, amount, ctw.contractService.Address().String())
+
+	<-previous
+	violation, err := ctw.checkPolicy(tx, *transferMessage)
+	if err != nil {
+		ctw.logger.Errorf("[%s] - Asset policy check failed. Error: [%s]", tx.TransactionID, err)
+		return
+	}
+	if violation != "" {
+		err = ctw.transfers.HoldTransfer(*transferMessage, violation)
+		if err != nil {
+			ctw.logger.Errorf("[%s] - Failed to hold transfer. Error: [%s]", tx.TransactionID, err)
+		}
+		return
+	}
+
 	q.Push(&pair.Message{Payload: transferMessage})
 }
+
+// refund returns the rejected deposit to its sender
+func (ctw Watcher) refund(tx mirror_node.Transaction, amount, nativeAsset, reason string) {
+	sender, err := tx.GetSender(nativeAsset)
+	if err != nil {
+		ctw.logger.Errorf("[%s] - Could not find sender of the deposit. Error: [%s]", tx.TransactionID, err)
+		return
+	}
+
+	deposit := transfer.New(tx.TransactionID, "", nativeAsset, "", amount, ctw.contractService.Address().String())
+	err = ctw.refunds.Refund(*deposit, sender, reason)
+	if err != nil {
+		ctw.logger.Errorf("[%s] - Failed to refund deposit. Error: [%s]", tx.TransactionID, err)
+	}
+}
+
+func (ctw Watcher) checkPolicy(tx mirror_node.Transaction, tm transfer.Transfer) (string, error) {
+	amount, err := big_numbers.ToBigInt(tm.Amount)
+	if err != nil {
+		return "", err
+	}
+
+	consensusTimestamp, err := timestamp.FromString(tx.ConsensusTimestamp)
+	if err != nil {
+		return "", err
+	}
+
+	return ctw.assetPolicy.Check(tm.TransactionId, volume.DirectionHederaToEVM, tm.NativeAsset, tm.Receiver, amount, consensusTimestamp/int64(time.Second))
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	log "github.com/sirupsen/logrus"
+)
+
+const apiAdmin = "/api/admin"
+
+// APIKeyHeader is the header of the API key, authenticating requests to the admin API
+const APIKeyHeader = "X-API-Key"
+
+// AdminRouter is the admin API namespace for operators. Its requests are authenticated
+// with API keys and/or client certificates, if served on its own TLS port
+type AdminRouter struct {
+	Router *chi.Mux
+	config config.Admin
+}
+
+func NewAdminRouter(cfg config.Admin) *AdminRouter {
+	if cfg.TLS.ClientCAFile != "" && (cfg.Port == "" || cfg.TLS.CertFile == "" || cfg.TLS.KeyFile == "") {
+		log.Fatalf("Admin client CA requires admin port, certificate and key.")
+	}
+
+	router := chi.NewRouter()
+	if len(cfg.APIKeys) > 0 {
+		router.Use(apiKeyAuth(cfg.APIKeys))
+	}
+
+	return &AdminRouter{
+		Router: router,
+		config: cfg,
+	}
+}
+
+// Enabled returns whether requests to the admin API are authenticated with API keys or client certificates
+func (admin *AdminRouter) Enabled() bool {
+	return len(admin.config.APIKeys) > 0 || admin.config.TLS.ClientCAFile != ""
+}
+
+// Standalone returns whether the admin API is served on its own port
+func (admin *AdminRouter) Standalone() bool {
+	return admin.config.Port != ""
+}
+
+func (admin *AdminRouter) AddRouter(path string, router http.Handler) {
+	admin.Router.Mount(path, router)
+}
+
+// AddAdminRouter serves the admin API namespace together with the public API
+func (api *APIRouter) AddAdminRouter(admin *AdminRouter) {
+	api.Router.Mount(apiAdmin, admin.Router)
+}
+
+// Handler returns the handler serving the admin API namespace on its own port
+func (admin *AdminRouter) Handler() http.Handler {
+	router := chi.NewRouter()
+	router.Use(
+		render.SetContentType(render.ContentTypeJSON),
+		middleware.AllowContentType("application/json"),
+		middleware.RequestLogger(&middleware.DefaultLogFormatter{Logger: log.StandardLogger()}),
+		middleware.RedirectSlashes,
+		middleware.Recoverer,
+		middleware.NoCache)
+	router.Mount(apiAdmin, admin.Router)
+	return router
+}
+
+// apiKeyAuth responds with 401, unless the request has one of the API keys
+func apiKeyAuth(apiKeys []string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := []byte(r.Header.Get(APIKeyHeader))
+			for _, apiKey := range apiKeys {
+				if apiKey != "" && subtle.ConstantTimeCompare(key, []byte(apiKey)) == 1 {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		})
+	}
+}
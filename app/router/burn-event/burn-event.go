@@ -39,8 +39,67 @@ func getTxID(burnService service.BurnEvent) func(w http.ResponseWriter, r *http.
 	}
 }
 
+// GET: .../events/:id/timeline
+func getTimeline(burnService service.BurnEvent) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		eventID := chi.URLParam(r, "id")
+
+		timeline, err := burnService.Timeline(eventID)
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			switch err {
+			case service.ErrNotFound:
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorResponse(err))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			}
+
+			return
+		}
+
+		render.JSON(w, r, timeline)
+	}
+}
+
+// POST: .../events/:id/execute
+func execute(burnService service.BurnEvent) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		eventID := chi.URLParam(r, "id")
+
+		err := burnService.Execute(eventID)
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			switch err {
+			case service.ErrNotFound:
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorResponse(err))
+			case service.ErrInvalidStatus:
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.ErrorResponse(err))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			}
+
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
 func NewRouter(service service.BurnEvent) chi.Router {
 	r := chi.NewRouter()
 	r.Get("/{id}/tx", getTxID(service))
+	r.Get("/{id}/timeline", getTimeline(service))
+	return r
+}
+
+// NewAdminRouter returns the router for the operator actions on burn events
+func NewAdminRouter(service service.BurnEvent) chi.Router {
+	r := chi.NewRouter()
+	r.Post("/{id}/execute", execute(service))
 	return r
 }
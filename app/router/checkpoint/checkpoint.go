@@ -0,0 +1,77 @@
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/router/response"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"net/http"
+)
+
+var (
+	Route  = "/checkpoints"
+	logger = config.GetLoggerFor(fmt.Sprintf("Router [%s]", Route))
+
+	errInvalidRequest = errors.New("INVALID_REQUEST")
+)
+
+type resetRequest struct {
+	Timestamp int64 `json:"timestamp"`
+}
+
+// GET: .../checkpoints
+func getCheckpoints(checkpoints service.Checkpoints) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := checkpoints.Get()
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			return
+		}
+
+		render.JSON(w, r, result)
+	}
+}
+
+// PUT: .../checkpoints/:watcher
+func resetCheckpoint(checkpoints service.Checkpoints) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		watcher := chi.URLParam(r, "watcher")
+
+		var request resetRequest
+		err := render.DecodeJSON(r.Body, &request)
+		if err != nil || request.Timestamp <= 0 {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorResponse(errInvalidRequest))
+			return
+		}
+
+		err = checkpoints.Reset(watcher, request.Timestamp)
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			switch err {
+			case service.ErrNotFound:
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorResponse(err))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			}
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewRouter(service service.Checkpoints) chi.Router {
+	r := chi.NewRouter()
+	r.Get("/", getCheckpoints(service))
+	r.Put("/{watcher}", resetCheckpoint(service))
+	return r
+}
@@ -0,0 +1,38 @@
+package configuration
+
+import (
+	"fmt"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/limechain/hedera-eth-bridge-validator/app/router/response"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"gopkg.in/yaml.v2"
+	"net/http"
+)
+
+var (
+	Route  = "/config"
+	logger = config.GetLoggerFor(fmt.Sprintf("Router [%s]", Route))
+)
+
+// GET: .../config
+func getConfig(cfg config.Config) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := yaml.Marshal(cfg.Redacted())
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-yaml")
+		w.Write(body)
+	}
+}
+
+func NewRouter(cfg config.Config) chi.Router {
+	r := chi.NewRouter()
+	r.Get("/", getConfig(cfg))
+	return r
+}
@@ -0,0 +1,40 @@
+package equivocation
+
+import (
+	"fmt"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/router/response"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"net/http"
+)
+
+var (
+	Route  = "/equivocations"
+	logger = config.GetLoggerFor(fmt.Sprintf("Router [%s]", Route))
+)
+
+// GET: .../equivocations?transferId=&signer=
+func getEquivocations(messagesService service.Messages) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		transferID := r.URL.Query().Get("transferId")
+		signer := r.URL.Query().Get("signer")
+
+		equivocations, err := messagesService.Equivocations(transferID, signer)
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			return
+		}
+
+		render.JSON(w, r, equivocations)
+	}
+}
+
+func NewRouter(service service.Messages) chi.Router {
+	r := chi.NewRouter()
+	r.Get("/", getEquivocations(service))
+	return r
+}
@@ -0,0 +1,17 @@
+package metrics
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	Route = "/metrics"
+)
+
+// NewRouter exposes the Prometheus metrics of the validator
+func NewRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Handle("/", promhttp.Handler())
+	return r
+}
@@ -0,0 +1,72 @@
+package pause
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	control_action "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/control-action"
+	"github.com/limechain/hedera-eth-bridge-validator/app/router/response"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"io"
+	"net/http"
+)
+
+var (
+	Route  = "/pause"
+	logger = config.GetLoggerFor(fmt.Sprintf("Router [%s]", Route))
+
+	errInvalidRequest = errors.New("INVALID_REQUEST")
+)
+
+type controlRequest struct {
+	Reason string `json:"reason"`
+	// Nonce of the proposed action to sign. A new action is proposed, if omitted
+	Nonce int64 `json:"nonce"`
+}
+
+type controlResponse struct {
+	Action string `json:"action"`
+	Nonce  int64  `json:"nonce"`
+}
+
+// GET: .../pause
+func getStatus(pauseService service.Pause) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, pauseService.Status())
+	}
+}
+
+// POST: .../pause (pause), DELETE: .../pause (resume)
+func submit(pauseService service.Pause, action string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request controlRequest
+		err := render.DecodeJSON(r.Body, &request)
+		if err != nil && err != io.EOF {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorResponse(errInvalidRequest))
+			return
+		}
+
+		nonce, err := pauseService.Submit(action, request.Reason, request.Nonce)
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			return
+		}
+
+		// The action is applied once the control messages with the required signatures reach consensus
+		render.Status(r, http.StatusAccepted)
+		render.JSON(w, r, controlResponse{Action: action, Nonce: nonce})
+	}
+}
+
+func NewRouter(service service.Pause) chi.Router {
+	r := chi.NewRouter()
+	r.Get("/", getStatus(service))
+	r.Post("/", submit(service, control_action.ActionPause))
+	r.Delete("/", submit(service, control_action.ActionResume))
+	return r
+}
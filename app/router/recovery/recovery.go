@@ -0,0 +1,127 @@
+package recovery
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/router/response"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"net/http"
+	"time"
+)
+
+var (
+	Route  = "/recovery"
+	logger = config.GetLoggerFor(fmt.Sprintf("Router [%s]", Route))
+
+	errInvalidRequest = errors.New("INVALID_REQUEST")
+)
+
+// recoveryRequest is the interval [from; to) (in nanoseconds) to be recovered. All scopes are recovered, unless specified
+type recoveryRequest struct {
+	From   int64    `json:"from"`
+	To     int64    `json:"to"`
+	Scopes []string `json:"scopes"`
+}
+
+// GET: .../recovery
+func getProgress(recovery service.Recovery) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		progress := recovery.Progress()
+		if progress == nil {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorResponse(service.ErrNotFound))
+			return
+		}
+
+		render.JSON(w, r, progress)
+	}
+}
+
+// POST: .../recovery
+func startRecovery(recovery service.Recovery) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request recoveryRequest
+		err := render.DecodeJSON(r.Body, &request)
+		if err != nil || !valid(request) {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorResponse(errInvalidRequest))
+			return
+		}
+		if len(request.Scopes) == 0 {
+			request.Scopes = service.RecoveryScopes
+		}
+
+		progress, err := recovery.Recover(request.From, request.To, request.Scopes)
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			switch err {
+			case service.ErrRecoveryInProgress:
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.ErrorResponse(err))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			}
+
+			return
+		}
+
+		// The recovery of the interval can take a while, so its progress is polled
+		render.Status(r, http.StatusAccepted)
+		render.JSON(w, r, progress)
+	}
+}
+
+// POST: .../recovery/dry-run
+func dryRun(recovery service.Recovery) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request recoveryRequest
+		err := render.DecodeJSON(r.Body, &request)
+		if err != nil || !valid(request) {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorResponse(errInvalidRequest))
+			return
+		}
+		if len(request.Scopes) == 0 {
+			request.Scopes = service.RecoveryScopes
+		}
+
+		report, err := recovery.Report(request.From, request.To, request.Scopes)
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			return
+		}
+
+		render.JSON(w, r, report)
+	}
+}
+
+func valid(request recoveryRequest) bool {
+	if request.From <= 0 || request.To <= request.From || request.To > time.Now().UnixNano() {
+		return false
+	}
+
+	for _, scope := range request.Scopes {
+		known := false
+		for _, s := range service.RecoveryScopes {
+			known = known || s == scope
+		}
+		if !known {
+			return false
+		}
+	}
+	return true
+}
+
+func NewRouter(service service.Recovery) chi.Router {
+	r := chi.NewRouter()
+	r.Get("/", getProgress(service))
+	r.Post("/", startRecovery(service))
+	r.Post("/dry-run", dryRun(service))
+	return r
+}
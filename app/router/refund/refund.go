@@ -0,0 +1,46 @@
+package refund
+
+import (
+	"fmt"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/router/response"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"net/http"
+)
+
+var (
+	Route  = "/refunds"
+	logger = config.GetLoggerFor(fmt.Sprintf("Router [%s]", Route))
+)
+
+// GET: .../refunds/:id
+func getRefund(refundService service.Refunds) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		transferID := chi.URLParam(r, "id")
+
+		refundData, err := refundService.RefundData(transferID)
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			switch err {
+			case service.ErrNotFound:
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorResponse(err))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			}
+
+			return
+		}
+
+		render.JSON(w, r, refundData)
+	}
+}
+
+func NewRouter(service service.Refunds) chi.Router {
+	r := chi.NewRouter()
+	r.Get("/{id}", getRefund(service))
+	return r
+}
@@ -0,0 +1,76 @@
+package rejected_message
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/router/response"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"net/http"
+	"strconv"
+)
+
+var (
+	Route  = "/rejected-messages"
+	logger = config.GetLoggerFor(fmt.Sprintf("Router [%s]", Route))
+
+	errInvalidRequest = errors.New("INVALID_REQUEST")
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// GET: .../rejected-messages?transferId=&signer=&after=&limit=
+// Messages are paged by their consensus timestamp. The next page is requested with `after` set to the timestamp of the last message
+func getRejectedMessages(messagesService service.Messages) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		transferID := r.URL.Query().Get("transferId")
+		signer := r.URL.Query().Get("signer")
+		after, limit, err := page(r)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorResponse(errInvalidRequest))
+			return
+		}
+
+		messages, err := messagesService.RejectedMessages(transferID, signer, after, limit)
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			return
+		}
+
+		render.JSON(w, r, messages)
+	}
+}
+
+// page returns the consensus timestamp, after which messages are returned, and the maximum number of messages
+func page(r *http.Request) (int64, int, error) {
+	after := int64(0)
+	limit := defaultLimit
+	var err error
+	if value := r.URL.Query().Get("after"); value != "" {
+		after, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || after < 0 {
+			return 0, 0, errInvalidRequest
+		}
+	}
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > maxLimit {
+			return 0, 0, errInvalidRequest
+		}
+	}
+	return after, limit, nil
+}
+
+func NewRouter(service service.Messages) chi.Router {
+	r := chi.NewRouter()
+	r.Get("/", getRejectedMessages(service))
+	return r
+}
@@ -39,8 +39,68 @@ func getTransfer(transfersService service.Transfers) func(w http.ResponseWriter,
 	}
 }
 
+// GET: .../transfers/:id/timeline
+func getTimeline(transfersService service.Transfers) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		transferID := chi.URLParam(r, "id")
+
+		timeline, err := transfersService.Timeline(transferID)
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			switch err {
+			case service.ErrNotFound:
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorResponse(err))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			}
+
+			return
+		}
+
+		render.JSON(w, r, timeline)
+	}
+}
+
+// POST: .../transfers/:id/process (reprocess), POST: .../transfers/:id/fee/execute (execute fee)
+func execute(operation func(transferID string) error) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		transferID := chi.URLParam(r, "id")
+
+		err := operation(transferID)
+		if err != nil {
+			logger.Errorf("Router resolved with an error. Error [%s].", err)
+			switch err {
+			case service.ErrNotFound:
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorResponse(err))
+			case service.ErrInvalidStatus:
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.ErrorResponse(err))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.ErrorResponse(response.ErrorInternalServerError))
+			}
+
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
 func NewRouter(service service.Transfers) chi.Router {
 	r := chi.NewRouter()
 	r.Get("/{id}", getTransfer(service))
+	r.Get("/{id}/timeline", getTimeline(service))
+	return r
+}
+
+// NewAdminRouter returns the router for the operator actions on transfers
+func NewAdminRouter(service service.Transfers) chi.Router {
+	r := chi.NewRouter()
+	r.Post("/{id}/process", execute(service.ReprocessTransfer))
+	r.Post("/{id}/fee/execute", execute(service.ExecuteFee))
 	return r
 }
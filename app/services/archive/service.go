@@ -0,0 +1,276 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	archived_record "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/archived-record"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	day = int64(24 * time.Hour / time.Second)
+	// maxLineSize is the max size of a single archived record
+	maxLineSize = 10 * 1024 * 1024
+)
+
+type Service struct {
+	enabled       bool
+	directory     string
+	retentionDays int64
+	interval      time.Duration
+	batchSize     int
+	repository    repository.Archive
+	logger        *log.Entry
+}
+
+func New(archive config.Archive, repository repository.Archive) *Service {
+	if archive.Enabled {
+		if archive.Directory == "" {
+			log.Fatalf("Archive directory is not set.")
+		}
+		if archive.RetentionDays <= 0 {
+			log.Fatalf("Invalid archive retention days: [%d].", archive.RetentionDays)
+		}
+		if archive.Interval <= 0 {
+			log.Fatalf("Invalid archive interval: [%d].", archive.Interval)
+		}
+		if archive.BatchSize <= 0 {
+			log.Fatalf("Invalid archive batch size: [%d].", archive.BatchSize)
+		}
+	}
+
+	return &Service{
+		enabled:       archive.Enabled,
+		directory:     archive.Directory,
+		retentionDays: archive.RetentionDays,
+		interval:      archive.Interval,
+		batchSize:     archive.BatchSize,
+		repository:    repository,
+		logger:        config.GetLoggerFor("Archive Service"),
+	}
+}
+
+// Start periodically archives the records, older than the retention period
+func (s *Service) Start() {
+	if !s.enabled {
+		return
+	}
+
+	go func() {
+		for {
+			err := s.Archive()
+			if err != nil {
+				s.logger.Errorf("Failed to archive records. Error: [%s].", err)
+			}
+			time.Sleep(s.interval * time.Second)
+		}
+	}()
+	s.logger.Infof("Archiving records older than [%d] days every [%d] seconds.", s.retentionDays, s.interval)
+}
+
+// Archive exports the completed transfers and the completed or failed burn events, older than the retention
+// period, to compressed JSONL files (one record per line) and prunes them from the database
+func (s *Service) Archive() error {
+	err := os.MkdirAll(s.directory, 0755)
+	if err != nil {
+		return err
+	}
+
+	before := time.Now().Unix() - s.retentionDays*day
+	for {
+		transfers, err := s.repository.GetArchivableTransfers(before, s.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(transfers) == 0 {
+			break
+		}
+
+		ids := make([]string, len(transfers))
+		records := make([]interface{}, len(transfers))
+		for i, t := range transfers {
+			ids[i] = t.TransactionID
+			records[i] = t
+		}
+		err = s.export(archived_record.KindTransfer, ids, records, s.repository.PruneTransfers)
+		if err != nil {
+			return err
+		}
+		if len(transfers) < s.batchSize {
+			break
+		}
+	}
+
+	for {
+		burnEvents, err := s.repository.GetArchivableBurnEvents(before, s.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(burnEvents) == 0 {
+			break
+		}
+
+		ids := make([]string, len(burnEvents))
+		records := make([]interface{}, len(burnEvents))
+		for i, b := range burnEvents {
+			ids[i] = b.Id
+			records[i] = b
+		}
+		err = s.export(archived_record.KindBurnEvent, ids, records, s.repository.PruneBurnEvents)
+		if err != nil {
+			return err
+		}
+		if len(burnEvents) < s.batchSize {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Transfer returns the archived transfer with its fee and signature messages. Returns nil if not archived
+func (s *Service) Transfer(id string) (*entity.Transfer, error) {
+	t := &entity.Transfer{}
+	found, err := s.lookup(id, archived_record.KindTransfer, t, func() string { return t.TransactionID })
+	if err != nil || !found {
+		return nil, err
+	}
+	return t, nil
+}
+
+// BurnEvent returns the archived burn event with its fee. Returns nil if not archived
+func (s *Service) BurnEvent(id string) (*entity.BurnEvent, error) {
+	b := &entity.BurnEvent{}
+	found, err := s.lookup(id, archived_record.KindBurnEvent, b, func() string { return b.Id })
+	if err != nil || !found {
+		return nil, err
+	}
+	return b, nil
+}
+
+// export writes the records to a new archive file and prunes them. The file is written under a temporary
+// name and renamed once complete, so that only complete archive files are referenced
+func (s *Service) export(kind string, ids []string, records []interface{}, prune func(ids []string, file string) error) error {
+	name := fmt.Sprintf("%s-%d.jsonl.gz", fileName(kind), time.Now().UnixNano())
+	path := filepath.Join(s.directory, name)
+
+	err := write(path+".tmp", records)
+	if err != nil {
+		os.Remove(path + ".tmp")
+		return err
+	}
+	err = os.Rename(path+".tmp", path)
+	if err != nil {
+		return err
+	}
+
+	err = prune(ids, name)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("Archived [%d] records of kind [%s] to [%s].", len(records), kind, name)
+	return nil
+}
+
+// lookup decodes the archived record with the given id into the provided entity
+func (s *Service) lookup(id, kind string, into interface{}, recordID func() string) (bool, error) {
+	record, err := s.repository.Get(id)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to query archived record. Error: [%s].", id, err)
+		return false, err
+	}
+	if record == nil || record.Kind != kind {
+		return false, nil
+	}
+
+	file, err := os.Open(filepath.Join(s.directory, record.File))
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to open archive file [%s]. Error: [%s].", id, record.File, err)
+		return false, err
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to read archive file [%s]. Error: [%s].", id, record.File, err)
+		return false, err
+	}
+	defer reader.Close()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !bytes.Contains(line, []byte(id)) {
+			continue
+		}
+		err = json.Unmarshal(line, into)
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to decode archived record in [%s]. Error: [%s].", id, record.File, err)
+			return false, err
+		}
+		if recordID() == id {
+			return true, nil
+		}
+	}
+	if scanner.Err() != nil {
+		return false, scanner.Err()
+	}
+
+	return false, errors.New(fmt.Sprintf("archived record [%s] is missing in [%s]", id, record.File))
+}
+
+func write(path string, records []interface{}) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := gzip.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, r := range records {
+		err = encoder.Encode(r)
+		if err != nil {
+			return err
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+func fileName(kind string) string {
+	return strings.ReplaceAll(strings.ToLower(kind), "_", "-") + "s"
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"database/sql"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	archived_record "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/archived-record"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	s        = &Service{}
+	transfer = &entity.Transfer{
+		TransactionID: "0.0.1234-1620000000-000000000",
+		Receiver:      "0xsomeethaddress",
+		Amount:        "100",
+		Status:        "COMPLETED",
+		Timestamp:     1620000000,
+		Messages: []entity.Message{
+			{TransferID: "0.0.1234-1620000000-000000000", Signature: "signature", Signer: "0xsigner"},
+		},
+		Fee: entity.Fee{
+			TransactionID: "0.0.5678-1620000001-000000000",
+			Amount:        "10",
+			Status:        "COMPLETED",
+			TransferID:    sql.NullString{String: "0.0.1234-1620000000-000000000", Valid: true},
+		},
+	}
+	burnEvent = &entity.BurnEvent{
+		Id:            "0xethtxhash-1",
+		Amount:        "100",
+		Status:        "COMPLETED",
+		Timestamp:     1620000000,
+		TransactionId: sql.NullString{String: "0.0.5678-1620000002-000000000", Valid: true},
+	}
+)
+
+func setup(t *testing.T) func() {
+	mocks.Setup()
+	directory, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s = &Service{
+		enabled:       true,
+		directory:     directory,
+		retentionDays: 30,
+		interval:      3600,
+		batchSize:     2,
+		repository:    mocks.MArchiveRepository,
+		logger:        config.GetLoggerFor("Archive Service"),
+	}
+	return func() {
+		os.RemoveAll(directory)
+	}
+}
+
+func Test_Archive(t *testing.T) {
+	defer setup(t)()
+
+	mocks.MArchiveRepository.On("GetArchivableTransfers", mock.Anything, 2).Return([]*entity.Transfer{transfer}, nil)
+	mocks.MArchiveRepository.On("GetArchivableBurnEvents", mock.Anything, 2).Return([]*entity.BurnEvent{burnEvent}, nil)
+	mocks.MArchiveRepository.On("PruneTransfers", []string{transfer.TransactionID}, mock.Anything).Return(nil)
+	mocks.MArchiveRepository.On("PruneBurnEvents", []string{burnEvent.Id}, mock.Anything).Return(nil)
+
+	err := s.Archive()
+
+	assert.Nil(t, err)
+	transferFile := mocks.MArchiveRepository.Calls[1].Arguments.String(1)
+	burnEventFile := mocks.MArchiveRepository.Calls[3].Arguments.String(1)
+	assert.Regexp(t, `^transfers-[0-9]+\.jsonl\.gz$`, transferFile)
+	assert.Regexp(t, `^burn-events-[0-9]+\.jsonl\.gz$`, burnEventFile)
+	assert.FileExists(t, filepath.Join(s.directory, transferFile))
+	assert.FileExists(t, filepath.Join(s.directory, burnEventFile))
+
+	mocks.MArchiveRepository.On("Get", transfer.TransactionID).Return(&entity.ArchivedRecord{ID: transfer.TransactionID, Kind: archived_record.KindTransfer, File: transferFile}, nil)
+	mocks.MArchiveRepository.On("Get", burnEvent.Id).Return(&entity.ArchivedRecord{ID: burnEvent.Id, Kind: archived_record.KindBurnEvent, File: burnEventFile}, nil)
+
+	actualTransfer, err := s.Transfer(transfer.TransactionID)
+	assert.Nil(t, err)
+	assert.Equal(t, transfer, actualTransfer)
+
+	actualBurnEvent, err := s.BurnEvent(burnEvent.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, burnEvent, actualBurnEvent)
+}
+
+func Test_ArchiveBatches(t *testing.T) {
+	defer setup(t)()
+
+	other := &entity.Transfer{TransactionID: "0.0.1234-1620000001-000000000", Status: "COMPLETED"}
+	mocks.MArchiveRepository.On("GetArchivableTransfers", mock.Anything, 2).Return([]*entity.Transfer{transfer, other}, nil).Once()
+	mocks.MArchiveRepository.On("GetArchivableTransfers", mock.Anything, 2).Return([]*entity.Transfer{}, nil).Once()
+	mocks.MArchiveRepository.On("GetArchivableBurnEvents", mock.Anything, 2).Return([]*entity.BurnEvent{}, nil)
+	mocks.MArchiveRepository.On("PruneTransfers", []string{transfer.TransactionID, other.TransactionID}, mock.Anything).Return(nil)
+
+	err := s.Archive()
+
+	assert.Nil(t, err)
+	mocks.MArchiveRepository.AssertNumberOfCalls(t, "GetArchivableTransfers", 2)
+	mocks.MArchiveRepository.AssertNumberOfCalls(t, "PruneTransfers", 1)
+	mocks.MArchiveRepository.AssertNotCalled(t, "PruneBurnEvents", mock.Anything, mock.Anything)
+}
+
+func Test_ArchivePruneFails(t *testing.T) {
+	defer setup(t)()
+
+	expectedErr := errors.New("connection-refused")
+	mocks.MArchiveRepository.On("GetArchivableTransfers", mock.Anything, 2).Return([]*entity.Transfer{transfer}, nil)
+	mocks.MArchiveRepository.On("PruneTransfers", []string{transfer.TransactionID}, mock.Anything).Return(expectedErr)
+
+	err := s.Archive()
+
+	assert.Equal(t, expectedErr, err)
+	mocks.MArchiveRepository.AssertNotCalled(t, "GetArchivableBurnEvents", mock.Anything, mock.Anything)
+}
+
+func Test_TransferNotArchived(t *testing.T) {
+	defer setup(t)()
+
+	mocks.MArchiveRepository.On("Get", transfer.TransactionID).Return(nil, nil)
+
+	actual, err := s.Transfer(transfer.TransactionID)
+
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+}
+
+func Test_TransferMissingFile(t *testing.T) {
+	defer setup(t)()
+
+	mocks.MArchiveRepository.On("Get", transfer.TransactionID).Return(&entity.ArchivedRecord{ID: transfer.TransactionID, Kind: archived_record.KindTransfer, File: "transfers-1.jsonl.gz"}, nil)
+
+	actual, err := s.Transfer(transfer.TransactionID)
+
+	assert.Error(t, err)
+	assert.Nil(t, actual)
+}
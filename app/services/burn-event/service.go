@@ -18,25 +18,40 @@ package burn_event
 
 import (
 	"database/sql"
+	"math/big"
+	"time"
+
 	"github.com/hashgraph/hedera-sdk-go/v2"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	big_numbers "github.com/limechain/hedera-eth-bridge-validator/app/helper/big-numbers"
 	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/model/burn-event"
 	"github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	burn_event_status "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/burn-event"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	log "github.com/sirupsen/logrus"
-	"strconv"
 )
 
+// actor is the component recorded in the status transitions of the service
+const actor = "Burn Event Service"
+
 type Service struct {
 	bridgeAccount      hedera.AccountID
 	feeRepository      repository.Fee
 	repository         repository.BurnEvent
+	unitOfWork         repository.UnitOfWork
+	statusTransitions  repository.StatusTransition
 	distributorService service.Distributor
 	feeService         service.Fee
 	scheduledService   service.Scheduled
+	feeSettlement      service.FeeSettlement
+	decimals           service.Decimals
+	pause              service.Pause
+	archive            service.Archive
+	webhooks           service.Webhooks
 	logger             *log.Entry
 }
 
@@ -44,9 +59,16 @@ func NewService(
 	bridgeAccount string,
 	repository repository.BurnEvent,
 	feeRepository repository.Fee,
+	unitOfWork repository.UnitOfWork,
+	statusTransitions repository.StatusTransition,
 	distributor service.Distributor,
 	scheduled service.Scheduled,
-	feeService service.Fee) *Service {
+	feeService service.Fee,
+	feeSettlement service.FeeSettlement,
+	decimals service.Decimals,
+	pause service.Pause,
+	archive service.Archive,
+	webhooks service.Webhooks) *Service {
 
 	bridgeAcc, err := hedera.AccountIDFromString(bridgeAccount)
 	if err != nil {
@@ -57,56 +79,266 @@ func NewService(
 		bridgeAccount:      bridgeAcc,
 		feeRepository:      feeRepository,
 		repository:         repository,
+		unitOfWork:         unitOfWork,
+		statusTransitions:  statusTransitions,
 		distributorService: distributor,
 		feeService:         feeService,
 		scheduledService:   scheduled,
+		feeSettlement:      feeSettlement,
+		decimals:           decimals,
+		pause:              pause,
+		archive:            archive,
+		webhooks:           webhooks,
 		logger:             config.GetLoggerFor("Burn Event Service"),
 	}
 }
 
 func (s Service) ProcessEvent(event burn_event.BurnEvent) {
-	err := s.repository.Create(event.Id, event.Amount, event.Recipient.String())
+	err := s.repository.Create(&event)
 	if err != nil {
 		s.logger.Errorf("[%s] - Failed to create a burn event record. Error [%s].", event.Id, err)
 		return
 	}
 
+	s.schedule(event)
+}
+
+// schedule prepares and executes the scheduled transaction of the burn event. The event is queued, while the bridge is paused
+func (s Service) schedule(event burn_event.BurnEvent) {
+	if s.pause.Queue(event.Id, "", func() { s.schedule(event) }) {
+		return
+	}
+
 	_, feeAmount, transfers, err := s.prepareTransfers(event)
 	if err != nil {
 		s.logger.Errorf("[%s] - Failed to prepare transfers. Error [%s].", event.Id, err)
 		return
 	}
 
-	onExecutionSuccess, onExecutionFail := s.scheduledTxExecutionCallbacks(event.Id, strconv.FormatInt(feeAmount, 10))
+	if s.feeSettlement.Enabled() {
+		err = s.createAccruedFeeRecord(event, feeAmount)
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to create accrued fee record. Error [%s].", event.Id, err)
+			return
+		}
+	}
+
+	onExecutionSuccess, onExecutionFail := s.scheduledTxExecutionCallbacks(event.Id, feeAmount.String())
 	onSuccess, onFail := s.scheduledTxMinedCallbacks(event.Id)
 
 	s.scheduledService.Execute(event.Id, event.NativeAsset, transfers, onExecutionSuccess, onExecutionFail, onSuccess, onFail)
 }
 
-func (s *Service) prepareTransfers(event burn_event.BurnEvent) (recipientAmount int64, feeAmount int64, transfers []transfer.Hedera, err error) {
-	fee, remainder := s.feeService.CalculateFee(event.Amount)
+// HoldEvent stores the burn event, which is outside of the asset policy, as held for manual review
+func (s Service) HoldEvent(event burn_event.BurnEvent, violation string) {
+	err := s.repository.CreateHeld(&event, violation)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to create a held burn event record. Error [%s].", event.Id, err)
+		return
+	}
+	s.logger.Warnf("[%s] - Burn of [%s] [%s] to [%s] held for manual review. Violation: [%s]", event.Id, event.Amount, event.WrappedAsset, event.Recipient, violation)
+}
+
+// Execute executes again the scheduled transaction of the stored burn event, if it is initial,
+// held for manual review or failed
+func (s Service) Execute(id string) error {
+	record, err := s.repository.Get(id)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to get burn event record. Error [%s].", id, err)
+		return err
+	}
+	if record == nil {
+		return service.ErrNotFound
+	}
+
+	switch record.Status {
+	case burn_event_status.StatusInitial, burn_event_status.StatusHeld, burn_event_status.StatusFailed:
+	default:
+		s.logger.Errorf("[%s] - Cannot execute burn event with status [%s].", id, record.Status)
+		return service.ErrInvalidStatus
+	}
+	if record.NativeAsset == "" {
+		s.logger.Errorf("[%s] - Cannot execute burn event without recorded assets.", id)
+		return service.ErrInvalidStatus
+	}
+
+	event, err := s.event(record)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("[%s] - Executing burn event with status [%s].", id, record.Status)
+	s.schedule(*event)
+	return nil
+}
+
+// event returns the burn event of the record
+func (s Service) event(record *entity.BurnEvent) (*burn_event.BurnEvent, error) {
+	recipient, err := hedera.AccountIDFromString(record.Recipient)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to parse recipient [%s]. Error [%s].", record.Id, record.Recipient, err)
+		return nil, err
+	}
+	amount, err := big_numbers.ToBigInt(record.Amount)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to parse amount [%s]. Error [%s].", record.Id, record.Amount, err)
+		return nil, err
+	}
+
+	return &burn_event.BurnEvent{
+		Id:           record.Id,
+		Amount:       amount,
+		Recipient:    recipient,
+		NativeAsset:  record.NativeAsset,
+		WrappedAsset: record.WrappedAsset,
+		Timestamp:    record.Timestamp,
+	}, nil
+}
+
+// Reconcile resumes the burn event, left unfinished by a restart. The scheduled transaction of an
+// initial burn event is executed again, unless it was already executed, while the outcome of a submitted one
+// is awaited on the mirror node
+func (s Service) Reconcile(id string) error {
+	record, err := s.repository.Get(id)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to get burn event record. Error [%s].", id, err)
+		return err
+	}
+	if record == nil {
+		return service.ErrNotFound
+	}
+
+	switch record.Status {
+	case burn_event_status.StatusInitial:
+		return s.reconcileInitial(record)
+	case burn_event_status.StatusSubmitted:
+		s.logger.Infof("[%s] - Awaiting submitted scheduled transaction [%s].", id, record.TransactionId.String)
+		onSuccess, onFail := s.scheduledTxMinedCallbacks(id)
+		s.scheduledService.Await(record.TransactionId.String, onSuccess, onFail)
+		return nil
+	default:
+		s.logger.Errorf("[%s] - Cannot reconcile burn event with status [%s].", id, record.Status)
+		return service.ErrInvalidStatus
+	}
+}
+
+// reconcileInitial executes the scheduled transaction of the initial burn event, unless the mirror node has the one
+// executed with its memo. It is one, whose submission was not recorded before a restart. Executing it again,
+// once its schedule has expired, would create a new schedule and unlock the amount twice
+func (s Service) reconcileInitial(record *entity.BurnEvent) error {
+	transactionID, scheduleID, err := s.scheduledService.Find(record.Id, time.Unix(record.Timestamp, 0).UnixNano())
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to look up the scheduled transaction. Error [%s].", record.Id, err)
+		return err
+	}
+	if transactionID == "" {
+		return s.Execute(record.Id)
+	}
+
+	event, err := s.event(record)
+	if err != nil {
+		return err
+	}
+	_, feeAmount, _, err := s.prepareTransfers(*event)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to prepare transfers. Error [%s].", record.Id, err)
+		return err
+	}
+
+	s.logger.Infof("[%s] - Found executed scheduled transaction [%s] of schedule [%s].", record.Id, transactionID, scheduleID)
+	onExecutionSuccess, _ := s.scheduledTxExecutionCallbacks(record.Id, feeAmount.String())
+	onExecutionSuccess(transactionID, scheduleID)
+
+	onSuccess, onFail := s.scheduledTxMinedCallbacks(record.Id)
+	s.scheduledService.Await(transactionID, onSuccess, onFail)
+	return nil
+}
+
+func (s *Service) prepareTransfers(event burn_event.BurnEvent) (recipientAmount *big.Int, feeAmount *big.Int, transfers []transfer.Hedera, err error) {
+	amount, dust, err := s.decimals.ToNative(event.NativeAsset, event.WrappedAsset, event.Amount)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if dust.Sign() > 0 {
+		// The dust cannot be represented with the native asset decimals, so it cannot be unlocked
+		s.logger.Warnf("[%s] - Dust [%s] of burned amount [%s] cannot be unlocked.", event.Id, dust, event.Amount)
+	}
+
+	fee, remainder := s.feeService.CalculateFee(event.NativeAsset, event.Recipient.String(), amount, event.Timestamp)
 
-	validFee := s.distributorService.ValidAmount(fee)
-	if validFee != fee {
-		remainder += fee - validFee
+	// Hedera transfers are in int64 units, so amounts exceeding them cannot be unlocked
+	hederaRemainder, err := big_numbers.ToInt64(remainder)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Accrued fees remain in the bridge account until their batch is settled
+	if s.feeSettlement.Enabled() {
+		transfers = append(transfers,
+			transfer.Hedera{
+				AccountID: event.Recipient,
+				Amount:    hederaRemainder,
+			},
+			transfer.Hedera{
+				AccountID: s.bridgeAccount,
+				Amount:    -hederaRemainder,
+			})
+		return remainder, fee, transfers, nil
 	}
 
-	transfers, err = s.distributorService.CalculateMemberDistribution(validFee)
+	hederaAmount, err := big_numbers.ToInt64(amount)
 	if err != nil {
-		return 0, 0, nil, err
+		return nil, nil, nil, err
+	}
+
+	if fee.Sign() > 0 {
+		transfers, err = s.distributorService.CalculateMemberDistribution(event.Id, fee)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
 	transfers = append(transfers,
 		transfer.Hedera{
 			AccountID: event.Recipient,
-			Amount:    remainder,
+			Amount:    hederaRemainder,
 		},
 		transfer.Hedera{
 			AccountID: s.bridgeAccount,
-			Amount:    -event.Amount,
+			Amount:    -hederaAmount,
 		})
 
-	return remainder, validFee, transfers, nil
+	return remainder, fee, transfers, nil
+}
+
+// createAccruedFeeRecord persists the fee of the burn event as owed to the members. It is paid out
+// with the settlement of the batch, corresponding to the timestamp of the burn event.
+func (s *Service) createAccruedFeeRecord(event burn_event.BurnEvent, feeAmount *big.Int) error {
+	// The record is already present, if the burn event is executed again
+	existing, err := s.feeRepository.Get(event.Id)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+
+	status := fee.StatusAccrued
+	if feeAmount.Sign() == 0 {
+		status = fee.StatusCompleted
+	}
+
+	return s.feeRepository.Create(&entity.Fee{
+		TransactionID: event.Id,
+		Amount:        feeAmount.String(),
+		Status:        status,
+		BurnEventID: sql.NullString{
+			String: event.Id,
+			Valid:  true,
+		},
+		NativeAsset: event.NativeAsset,
+		Batch:       s.feeSettlement.Batch(event.Timestamp),
+	})
 }
 
 // TransactionID returns the corresponding Scheduled Transaction paying out the
@@ -118,6 +350,15 @@ func (s *Service) TransactionID(id string) (string, error) {
 		return "", err
 	}
 
+	// Pruned events are looked up in the archive
+	if event == nil {
+		event, err = s.archive.BurnEvent(id)
+		if err != nil {
+			s.logger.Errorf("[%s] - failed to get archived event.", id)
+			return "", err
+		}
+	}
+
 	if event == nil {
 		return "", service.ErrNotFound
 	}
@@ -125,56 +366,131 @@ func (s *Service) TransactionID(id string) (string, error) {
 	return event.TransactionId.String, nil
 }
 
+// Timeline returns the status transitions of the given burn event and its fee, ordered by time
+func (s *Service) Timeline(id string) ([]service.StatusTransition, error) {
+	transitions, err := s.statusTransitions.GetForBurnEvent(id)
+	if err != nil {
+		s.logger.Errorf("[%s] - failed to get status transitions.", id)
+		return nil, err
+	}
+
+	// The timeline of an event, which status has not changed yet, is empty
+	if len(transitions) == 0 {
+		event, err := s.repository.Get(id)
+		if err != nil {
+			s.logger.Errorf("[%s] - failed to get event.", id)
+			return nil, err
+		}
+		if event == nil {
+			event, err = s.archive.BurnEvent(id)
+			if err != nil {
+				s.logger.Errorf("[%s] - failed to get archived event.", id)
+				return nil, err
+			}
+		}
+		if event == nil {
+			return nil, service.ErrNotFound
+		}
+	}
+
+	result := make([]service.StatusTransition, len(transitions))
+	for i, t := range transitions {
+		result[i] = service.StatusTransition{
+			Kind:      t.Kind,
+			RecordID:  t.RecordID,
+			From:      t.From,
+			To:        t.To,
+			Actor:     t.Actor,
+			Reason:    t.Reason,
+			Timestamp: t.Timestamp,
+		}
+	}
+	return result, nil
+}
+
+// scheduledTxExecutionCallbacks update the burn event together with its fee, so that they are
+// either both recorded or none of them is
 func (s *Service) scheduledTxExecutionCallbacks(id string, feeAmount string) (onExecutionSuccess func(transactionID, scheduleID string), onExecutionFail func(transactionID string)) {
 	onExecutionSuccess = func(transactionID, scheduleID string) {
 		s.logger.Debugf("[%s] - Updating db status to Submitted with TransactionID [%s].",
 			id,
 			transactionID)
-		err := s.repository.UpdateStatusSubmitted(id, scheduleID, transactionID)
+		err := s.unitOfWork.Do(func(tx repository.Transaction) error {
+			err := tx.BurnEvents().UpdateStatusSubmitted(id, scheduleID, transactionID, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxSubmitted})
+			if err != nil {
+				s.logger.Errorf(
+					"[%s] - Failed to update submitted status with TransactionID [%s], ScheduleID [%s]. Error [%s].",
+					id, transactionID, scheduleID, err)
+				return err
+			}
+
+			// Accrued fees are recorded before the execution and are updated with their settlement
+			if s.feeSettlement.Enabled() {
+				return nil
+			}
+
+			err = tx.Fees().Create(&entity.Fee{
+				TransactionID: transactionID,
+				ScheduleID: sql.NullString{
+					String: scheduleID,
+					Valid:  true,
+				},
+				Amount: feeAmount,
+				Status: fee.StatusSubmitted,
+				BurnEventID: sql.NullString{
+					String: id,
+					Valid:  true,
+				},
+			})
+			if err != nil {
+				s.logger.Errorf(
+					"[%s] - Failed to create Fee Record [%s]. Error [%s].",
+					transactionID, id, err)
+			}
+			return err
+		})
 		if err != nil {
-			s.logger.Errorf(
-				"[%s] - Failed to update submitted status with TransactionID [%s], ScheduleID [%s]. Error [%s].",
-				id, transactionID, scheduleID, err)
+			s.logger.Errorf("[%s] - Failed to record submitted scheduled transaction [%s]. Error [%s].", id, transactionID, err)
 			return
 		}
-		err = s.feeRepository.Create(&entity.Fee{
+		s.webhooks.Notify(service.WebhookBurnScheduled, service.WebhookData{
+			BurnEventID:   id,
 			TransactionID: transactionID,
 			ScheduleID:    scheduleID,
-			Amount:        feeAmount,
-			Status:        fee.StatusSubmitted,
-			BurnEventID: sql.NullString{
-				String: id,
-				Valid:  true,
-			},
 		})
-		if err != nil {
-			s.logger.Errorf(
-				"[%s] - Failed to create Fee Record [%s]. Error [%s].",
-				transactionID, id, err)
-			return
-		}
 	}
 
 	onExecutionFail = func(transactionID string) {
-		err := s.repository.UpdateStatusFailed(id)
-		if err != nil {
-			s.logger.Errorf("[%s] - Failed to update status failed. Error [%s].", id, err)
-			return
-		}
+		err := s.unitOfWork.Do(func(tx repository.Transaction) error {
+			err := tx.BurnEvents().UpdateStatusFailed(id, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxSubmissionFailed})
+			if err != nil {
+				s.logger.Errorf("[%s] - Failed to update status failed. Error [%s].", id, err)
+				return err
+			}
 
-		err = s.feeRepository.Create(&entity.Fee{
-			TransactionID: transactionID,
-			Amount:        feeAmount,
-			Status:        fee.StatusFailed,
-			BurnEventID: sql.NullString{
-				String: id,
-				Valid:  true,
-			},
+			if s.feeSettlement.Enabled() {
+				return nil
+			}
+
+			err = tx.Fees().Create(&entity.Fee{
+				TransactionID: transactionID,
+				Amount:        feeAmount,
+				Status:        fee.StatusFailed,
+				BurnEventID: sql.NullString{
+					String: id,
+					Valid:  true,
+				},
+			})
+			if err != nil {
+				s.logger.Errorf("[%s] Fee - Failed to create failed record. Error [%s].", transactionID, err)
+			}
+			return err
 		})
 		if err != nil {
-			s.logger.Errorf("[%s] Fee - Failed to create failed record. Error [%s].", transactionID, err)
+			s.logger.Errorf("[%s] - Failed to record failed execution. Error [%s].", id, err)
 			return
 		}
+		s.webhooks.Notify(service.WebhookBurnFailed, service.WebhookData{BurnEventID: id})
 	}
 
 	return onExecutionSuccess, onExecutionFail
@@ -183,32 +499,59 @@ func (s *Service) scheduledTxExecutionCallbacks(id string, feeAmount string) (on
 func (s *Service) scheduledTxMinedCallbacks(id string) (onSuccess, onFail func(transactionID string)) {
 	onSuccess = func(transactionID string) {
 		s.logger.Debugf("[%s] - Scheduled TX execution successful.", id)
-		err := s.repository.UpdateStatusCompleted(id)
-		if err != nil {
-			s.logger.Errorf("[%s] - Failed to update status completed. Error [%s].", id, err)
-			return
-		}
+		err := s.unitOfWork.Do(func(tx repository.Transaction) error {
+			err := tx.BurnEvents().UpdateStatusCompleted(id, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxExecuted})
+			if err != nil {
+				s.logger.Errorf("[%s] - Failed to update status completed. Error [%s].", id, err)
+				return err
+			}
+
+			if s.feeSettlement.Enabled() {
+				return nil
+			}
 
-		err = s.feeRepository.UpdateStatusCompleted(transactionID)
+			err = tx.Fees().UpdateStatusCompleted(transactionID, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxExecuted})
+			if err != nil {
+				s.logger.Errorf("[%s] Fee - Failed to update status completed. Error [%s].", transactionID, err)
+			}
+			return err
+		})
 		if err != nil {
-			s.logger.Errorf("[%s] Fee - Failed to update status completed. Error [%s].", transactionID, err)
+			s.logger.Errorf("[%s] - Failed to record completed scheduled transaction [%s]. Error [%s].", id, transactionID, err)
 			return
 		}
+		data := service.WebhookData{BurnEventID: id, TransactionID: transactionID}
+		s.webhooks.Notify(service.WebhookBurnCompleted, data)
+		// Accrued fees are completed with the settlement of their batch instead
+		if !s.feeSettlement.Enabled() {
+			s.webhooks.Notify(service.WebhookFeeCompleted, data)
+		}
 	}
 
 	onFail = func(transactionID string) {
 		s.logger.Debugf("[%s] - Scheduled TX execution has failed.", id)
-		err := s.repository.UpdateStatusFailed(id)
-		if err != nil {
-			s.logger.Errorf("[%s] - Failed to update status signature failed. Error [%s].", id, err)
-			return
-		}
+		err := s.unitOfWork.Do(func(tx repository.Transaction) error {
+			err := tx.BurnEvents().UpdateStatusFailed(id, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxFailed})
+			if err != nil {
+				s.logger.Errorf("[%s] - Failed to update status signature failed. Error [%s].", id, err)
+				return err
+			}
+
+			if s.feeSettlement.Enabled() {
+				return nil
+			}
 
-		err = s.feeRepository.UpdateStatusFailed(transactionID)
+			err = tx.Fees().UpdateStatusFailed(transactionID, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxFailed})
+			if err != nil {
+				s.logger.Errorf("[%s] Fee - Failed to update status failed. Error [%s].", transactionID, err)
+			}
+			return err
+		})
 		if err != nil {
-			s.logger.Errorf("[%s] Fee - Failed to update status failed. Error [%s].", transactionID, err)
+			s.logger.Errorf("[%s] - Failed to record failed scheduled transaction [%s]. Error [%s].", id, transactionID, err)
 			return
 		}
+		s.webhooks.Notify(service.WebhookBurnFailed, service.WebhookData{BurnEventID: id, TransactionID: transactionID})
 	}
 
 	return onSuccess, onFail
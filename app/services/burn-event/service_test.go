@@ -4,13 +4,18 @@ import (
 	"database/sql"
 	"errors"
 	"github.com/hashgraph/hedera-sdk-go/v2"
+	domainService "github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
 	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/model/burn-event"
 	"github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	burn_event_status "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/burn-event"
 	feeRepo "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks/service"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"math/big"
 	"testing"
 )
 
@@ -21,7 +26,7 @@ var (
 		Account: 222222,
 	}
 	burnEvent = burn_event.BurnEvent{
-		Amount: 111,
+		Amount: big.NewInt(111),
 		Recipient: hedera.AccountID{
 			Shard:   0,
 			Realm:   0,
@@ -42,50 +47,214 @@ var (
 func Test_ProcessEvent(t *testing.T) {
 	setup()
 
-	mockFee := int64(12)
-	mockRemainder := int64(1)
-	mockValidFee := int64(11)
+	mockFee := big.NewInt(12)
+	mockRemainder := big.NewInt(1)
 	mockTransfersAfterPreparation := []transfer.Hedera{
 		{
 			AccountID: burnEvent.Recipient,
-			Amount:    mockRemainder + (mockFee - mockValidFee),
+			Amount:    mockRemainder.Int64(),
 		},
 		{
 			AccountID: s.bridgeAccount,
-			Amount:    -burnEvent.Amount,
+			Amount:    -burnEvent.Amount.Int64(),
 		},
 	}
 
-	mocks.MBurnEventRepository.On("Create", burnEvent.Id, burnEvent.Amount, burnEvent.Recipient.String()).Return(nil)
-	mocks.MFeeService.On("CalculateFee", burnEvent.Amount).Return(mockFee, mockRemainder)
-	mocks.MDistributorService.On("ValidAmount", mockFee).Return(mockValidFee)
-	mocks.MDistributorService.On("CalculateMemberDistribution", mockValidFee).Return([]transfer.Hedera{}, nil)
+	mocks.MBurnEventRepository.On("Create", &burnEvent).Return(nil)
+	mocks.MFeeService.On("CalculateFee", burnEvent.NativeAsset, burnEvent.Recipient.String(), burnEvent.Amount, burnEvent.Timestamp).Return(mockFee, mockRemainder)
+	mocks.MDistributorService.On("CalculateMemberDistribution", burnEvent.Id, mockFee).Return([]transfer.Hedera{}, nil)
 	mocks.MScheduledService.On("Execute", burnEvent.Id, burnEvent.NativeAsset, mockTransfersAfterPreparation).Return()
 
 	s.ProcessEvent(burnEvent)
 }
 
+func Test_ProcessEventQueuedWhilePaused(t *testing.T) {
+	mocks.Setup()
+	mocks.MPauseService.On("Queue", burnEvent.Id, "", mock.Anything).Return(true)
+	s = &Service{
+		repository: mocks.MBurnEventRepository,
+		pause:      mocks.MPauseService,
+		logger:     config.GetLoggerFor("Burn Event Service"),
+	}
+	mocks.MBurnEventRepository.On("Create", &burnEvent).Return(nil)
+
+	s.ProcessEvent(burnEvent)
+
+	mocks.MBurnEventRepository.AssertCalled(t, "Create", &burnEvent)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_HoldEvent(t *testing.T) {
+	setup()
+	mocks.MBurnEventRepository.On("CreateHeld", &burnEvent, "DAILY_CAP_EXCEEDED").Return(nil)
+
+	s.HoldEvent(burnEvent, "DAILY_CAP_EXCEEDED")
+
+	mocks.MBurnEventRepository.AssertCalled(t, "CreateHeld", &burnEvent, "DAILY_CAP_EXCEEDED")
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_Execute(t *testing.T) {
+	setup()
+
+	mockFee := big.NewInt(12)
+	mockRemainder := big.NewInt(1)
+	mockTransfersAfterPreparation := []transfer.Hedera{
+		{
+			AccountID: burnEvent.Recipient,
+			Amount:    mockRemainder.Int64(),
+		},
+		{
+			AccountID: s.bridgeAccount,
+			Amount:    -burnEvent.Amount.Int64(),
+		},
+	}
+
+	mocks.MBurnEventRepository.On("Get", burnEvent.Id).Return(&entity.BurnEvent{
+		Id:           burnEvent.Id,
+		Amount:       burnEvent.Amount.String(),
+		Recipient:    burnEvent.Recipient.String(),
+		NativeAsset:  burnEvent.NativeAsset,
+		WrappedAsset: burnEvent.WrappedAsset,
+		Status:       burn_event_status.StatusFailed,
+	}, nil)
+	mocks.MFeeService.On("CalculateFee", burnEvent.NativeAsset, burnEvent.Recipient.String(), burnEvent.Amount, burnEvent.Timestamp).Return(mockFee, mockRemainder)
+	mocks.MDistributorService.On("CalculateMemberDistribution", burnEvent.Id, mockFee).Return([]transfer.Hedera{}, nil)
+	mocks.MScheduledService.On("Execute", burnEvent.Id, burnEvent.NativeAsset, mockTransfersAfterPreparation).Return()
+
+	err := s.Execute(burnEvent.Id)
+
+	assert.Nil(t, err)
+	mocks.MScheduledService.AssertCalled(t, "Execute", burnEvent.Id, burnEvent.NativeAsset, mockTransfersAfterPreparation)
+}
+
+func Test_ExecuteNotFound(t *testing.T) {
+	setup()
+	mocks.MBurnEventRepository.On("Get", burnEvent.Id).Return(nil, nil)
+
+	err := s.Execute(burnEvent.Id)
+
+	assert.Equal(t, domainService.ErrNotFound, err)
+}
+
+func Test_ExecuteCompleted(t *testing.T) {
+	setup()
+	mocks.MBurnEventRepository.On("Get", burnEvent.Id).Return(&entity.BurnEvent{
+		Id:          burnEvent.Id,
+		NativeAsset: burnEvent.NativeAsset,
+		Status:      burn_event_status.StatusCompleted,
+	}, nil)
+
+	err := s.Execute(burnEvent.Id)
+
+	assert.Equal(t, domainService.ErrInvalidStatus, err)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_ReconcileSubmitted(t *testing.T) {
+	setup()
+	mocks.MBurnEventRepository.On("Get", burnEvent.Id).Return(&entity.BurnEvent{
+		Id:            burnEvent.Id,
+		Status:        burn_event_status.StatusSubmitted,
+		TransactionId: sql.NullString{String: txId, Valid: true},
+	}, nil)
+	mocks.MScheduledService.On("Await", txId).Return()
+
+	err := s.Reconcile(burnEvent.Id)
+
+	assert.Nil(t, err)
+	mocks.MScheduledService.AssertCalled(t, "Await", txId)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// initialRecord returns the record of the burn event, whose scheduled transaction is not recorded as submitted
+func initialRecord() *entity.BurnEvent {
+	return &entity.BurnEvent{
+		Id:           burnEvent.Id,
+		Amount:       burnEvent.Amount.String(),
+		Recipient:    burnEvent.Recipient.String(),
+		NativeAsset:  burnEvent.NativeAsset,
+		WrappedAsset: burnEvent.WrappedAsset,
+		Timestamp:    1620000000,
+		Status:       burn_event_status.StatusInitial,
+	}
+}
+
+func Test_ReconcileInitial(t *testing.T) {
+	setup()
+	mocks.MBurnEventRepository.On("Get", burnEvent.Id).Return(initialRecord(), nil)
+	mocks.MScheduledService.On("Find", burnEvent.Id, int64(1620000000000000000)).Return("", "", nil)
+	mocks.MFeeService.On("CalculateFee", burnEvent.NativeAsset, burnEvent.Recipient.String(), burnEvent.Amount, int64(1620000000)).Return(big.NewInt(12), big.NewInt(1))
+	mocks.MDistributorService.On("CalculateMemberDistribution", burnEvent.Id, big.NewInt(12)).Return([]transfer.Hedera{}, nil)
+	mocks.MScheduledService.On("Execute", burnEvent.Id, burnEvent.NativeAsset, mock.Anything).Return()
+
+	err := s.Reconcile(burnEvent.Id)
+
+	assert.Nil(t, err)
+	mocks.MScheduledService.AssertCalled(t, "Execute", burnEvent.Id, burnEvent.NativeAsset, mock.Anything)
+}
+
+func Test_ReconcileInitialAlreadyExecuted(t *testing.T) {
+	setup()
+	mocks.MBurnEventRepository.On("Get", burnEvent.Id).Return(initialRecord(), nil)
+	mocks.MScheduledService.On("Find", burnEvent.Id, int64(1620000000000000000)).Return(txId, scheduleId, nil)
+	mocks.MFeeService.On("CalculateFee", burnEvent.NativeAsset, burnEvent.Recipient.String(), burnEvent.Amount, int64(1620000000)).Return(big.NewInt(12), big.NewInt(1))
+	mocks.MDistributorService.On("CalculateMemberDistribution", burnEvent.Id, big.NewInt(12)).Return([]transfer.Hedera{}, nil)
+	mocks.MBurnEventRepository.On("UpdateStatusSubmitted", burnEvent.Id, scheduleId, txId, mock.Anything).Return(nil)
+	mocks.MFeeRepository.On("Create", mock.Anything).Return(nil)
+	mocks.MScheduledService.On("Await", txId).Return()
+
+	err := s.Reconcile(burnEvent.Id)
+
+	assert.Nil(t, err)
+	mocks.MBurnEventRepository.AssertCalled(t, "UpdateStatusSubmitted", burnEvent.Id, scheduleId, txId, mock.Anything)
+	mocks.MScheduledService.AssertCalled(t, "Await", txId)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_ReconcileInitialLookupFails(t *testing.T) {
+	setup()
+	mocks.MBurnEventRepository.On("Get", burnEvent.Id).Return(initialRecord(), nil)
+	mocks.MScheduledService.On("Find", burnEvent.Id, int64(1620000000000000000)).Return("", "", errors.New("connection refused"))
+
+	err := s.Reconcile(burnEvent.Id)
+
+	assert.Error(t, err)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_ReconcileCompleted(t *testing.T) {
+	setup()
+	mocks.MBurnEventRepository.On("Get", burnEvent.Id).Return(&entity.BurnEvent{
+		Id:     burnEvent.Id,
+		Status: burn_event_status.StatusCompleted,
+	}, nil)
+
+	err := s.Reconcile(burnEvent.Id)
+
+	assert.Equal(t, domainService.ErrInvalidStatus, err)
+	mocks.MScheduledService.AssertNotCalled(t, "Await", mock.Anything)
+}
+
 func Test_ProcessEventCreateFail(t *testing.T) {
 	setup()
 
-	mockFee := int64(11)
-	mockRemainder := int64(1)
-	mockValidFee := int64(11)
+	mockFee := big.NewInt(11)
+	mockRemainder := big.NewInt(1)
 	mockTransfersAfterPreparation := []transfer.Hedera{
 		{
 			AccountID: burnEvent.Recipient,
-			Amount:    mockRemainder,
+			Amount:    mockRemainder.Int64(),
 		},
 		{
 			AccountID: s.bridgeAccount,
-			Amount:    -burnEvent.Amount,
+			Amount:    -burnEvent.Amount.Int64(),
 		},
 	}
 
-	mocks.MBurnEventRepository.On("Create", burnEvent.Id, burnEvent.Amount, burnEvent.Recipient.String()).Return(errors.New("invalid-result"))
-	mocks.MFeeService.AssertNotCalled(t, "CalculateFee", burnEvent.Amount)
-	mocks.MDistributorService.AssertNotCalled(t, "ValidAmount", mockFee)
-	mocks.MDistributorService.AssertNotCalled(t, "CalculateMemberDistribution", mockValidFee)
+	mocks.MBurnEventRepository.On("Create", &burnEvent).Return(errors.New("invalid-result"))
+	mocks.MFeeService.AssertNotCalled(t, "CalculateFee", burnEvent.NativeAsset, burnEvent.Recipient.String(), burnEvent.Amount, burnEvent.Timestamp)
+	mocks.MDistributorService.AssertNotCalled(t, "CalculateMemberDistribution", burnEvent.Id, mockFee)
 	mocks.MScheduledService.AssertNotCalled(t, "Execute", burnEvent.Id, burnEvent.NativeAsset, mockTransfersAfterPreparation)
 
 	s.ProcessEvent(burnEvent)
@@ -94,32 +263,76 @@ func Test_ProcessEventCreateFail(t *testing.T) {
 func Test_ProcessEventCalculateMemberDistributionFails(t *testing.T) {
 	setup()
 
-	mockFee := int64(11)
-	mockRemainder := int64(1)
-	mockValidFee := int64(11)
+	mockFee := big.NewInt(11)
+	mockRemainder := big.NewInt(1)
 	mockTransfersAfterPreparation := []transfer.Hedera{
 		{
 			AccountID: burnEvent.Recipient,
-			Amount:    mockRemainder,
+			Amount:    mockRemainder.Int64(),
 		},
 		{
 			AccountID: s.bridgeAccount,
-			Amount:    -burnEvent.Amount,
+			Amount:    -burnEvent.Amount.Int64(),
 		},
 	}
 
-	mocks.MBurnEventRepository.On("Create", burnEvent.Id, burnEvent.Amount, burnEvent.Recipient.String()).Return(nil)
-	mocks.MFeeService.On("CalculateFee", burnEvent.Amount).Return(mockFee, mockRemainder)
-	mocks.MDistributorService.On("ValidAmount", mockFee).Return(mockValidFee)
-	mocks.MDistributorService.On("CalculateMemberDistribution", mockValidFee).Return(nil, errors.New("invalid-result"))
+	mocks.MBurnEventRepository.On("Create", &burnEvent).Return(nil)
+	mocks.MFeeService.On("CalculateFee", burnEvent.NativeAsset, burnEvent.Recipient.String(), burnEvent.Amount, burnEvent.Timestamp).Return(mockFee, mockRemainder)
+	mocks.MDistributorService.On("CalculateMemberDistribution", burnEvent.Id, mockFee).Return(nil, errors.New("invalid-result"))
 	mocks.MScheduledService.AssertNotCalled(t, "Execute", burnEvent.Id, burnEvent.NativeAsset, mockTransfersAfterPreparation)
 
 	s.ProcessEvent(burnEvent)
 }
 
+func Test_ProcessEventConvertsDecimals(t *testing.T) {
+	setup()
+	mocks.MDecimalsService = &service.MockDecimalsService{}
+	s.decimals = mocks.MDecimalsService
+
+	event := burnEvent
+	event.Amount, _ = new(big.Int).SetString("1110000000005", 10)
+	nativeAmount := big.NewInt(111)
+	mockFee := big.NewInt(12)
+	mockRemainder := big.NewInt(99)
+	mockTransfersAfterPreparation := []transfer.Hedera{
+		{
+			AccountID: event.Recipient,
+			Amount:    mockRemainder.Int64(),
+		},
+		{
+			AccountID: s.bridgeAccount,
+			Amount:    -nativeAmount.Int64(),
+		},
+	}
+
+	mocks.MBurnEventRepository.On("Create", &event).Return(nil)
+	mocks.MDecimalsService.On("ToNative", event.NativeAsset, event.WrappedAsset, event.Amount).Return(nativeAmount, big.NewInt(5), nil)
+	mocks.MFeeService.On("CalculateFee", event.NativeAsset, event.Recipient.String(), nativeAmount, event.Timestamp).Return(mockFee, mockRemainder)
+	mocks.MDistributorService.On("CalculateMemberDistribution", event.Id, mockFee).Return([]transfer.Hedera{}, nil)
+	mocks.MScheduledService.On("Execute", event.Id, event.NativeAsset, mockTransfersAfterPreparation).Return()
+
+	s.ProcessEvent(event)
+
+	mocks.MScheduledService.AssertCalled(t, "Execute", event.Id, event.NativeAsset, mockTransfersAfterPreparation)
+}
+
+func Test_ProcessEventConvertDecimalsFails(t *testing.T) {
+	setup()
+	mocks.MDecimalsService = &service.MockDecimalsService{}
+	s.decimals = mocks.MDecimalsService
+
+	mocks.MBurnEventRepository.On("Create", &burnEvent).Return(nil)
+	mocks.MDecimalsService.On("ToNative", burnEvent.NativeAsset, burnEvent.WrappedAsset, burnEvent.Amount).Return(nil, nil, errors.New("invalid-token"))
+
+	s.ProcessEvent(burnEvent)
+
+	mocks.MFeeService.AssertNotCalled(t, "CalculateFee", burnEvent.NativeAsset, burnEvent.Recipient.String(), burnEvent.Amount, burnEvent.Timestamp)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func Test_New(t *testing.T) {
 	setup()
-	actualService := NewService(hederaAccount.String(), mocks.MBurnEventRepository, mocks.MFeeRepository, mocks.MDistributorService, mocks.MScheduledService, mocks.MFeeService)
+	actualService := NewService(hederaAccount.String(), mocks.MBurnEventRepository, mocks.MFeeRepository, mocks.MUnitOfWork, mocks.MStatusTransitionRepository, mocks.MDistributorService, mocks.MScheduledService, mocks.MFeeService, mocks.MFeeSettlementService, mocks.MDecimalsService, mocks.MPauseService, mocks.MArchiveService, mocks.MWebhooksService)
 	assert.Equal(t, s, actualService)
 }
 
@@ -155,31 +368,105 @@ func Test_TransactionIDNotFound(t *testing.T) {
 
 	expectedError := errors.New("not found")
 	mocks.MBurnEventRepository.On("Get", mockBurnEventId).Return(nil, nil)
+	mocks.MArchiveService.On("BurnEvent", mockBurnEventId).Return(nil, nil)
 
 	actualTransactionId, err := s.TransactionID(mockBurnEventId)
 	assert.Error(t, expectedError, err)
 	assert.Empty(t, actualTransactionId)
 }
 
+func Test_TransactionIDArchived(t *testing.T) {
+	setup()
+
+	expectedTransactionId := "0.0.123123-123412.123412"
+	mockBurnEventRecord := &entity.BurnEvent{
+		TransactionId: sql.NullString{String: expectedTransactionId, Valid: true},
+	}
+
+	mocks.MBurnEventRepository.On("Get", mockBurnEventId).Return(nil, nil)
+	mocks.MArchiveService.On("BurnEvent", mockBurnEventId).Return(mockBurnEventRecord, nil)
+
+	actualTransactionId, err := s.TransactionID(mockBurnEventId)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedTransactionId, actualTransactionId)
+}
+
+func Test_Timeline(t *testing.T) {
+	setup()
+
+	transitions := []entity.StatusTransition{
+		{Kind: "BURN_EVENT", RecordID: mockBurnEventId, From: "INITIAL", To: "SUBMITTED", Actor: "Burn Event Service", Reason: "SCHEDULED_TX_SUBMITTED", Timestamp: 1},
+		{Kind: "FEE", RecordID: "0.0.123123-123412.123412", From: "SUBMITTED", To: "COMPLETED", Actor: "Burn Event Service", Reason: "SCHEDULED_TX_EXECUTED", Timestamp: 2},
+	}
+	mocks.MStatusTransitionRepository.On("GetForBurnEvent", mockBurnEventId).Return(transitions, nil)
+
+	timeline, err := s.Timeline(mockBurnEventId)
+	assert.Nil(t, err)
+	assert.Equal(t, []domainService.StatusTransition{
+		{Kind: "BURN_EVENT", RecordID: mockBurnEventId, From: "INITIAL", To: "SUBMITTED", Actor: "Burn Event Service", Reason: "SCHEDULED_TX_SUBMITTED", Timestamp: 1},
+		{Kind: "FEE", RecordID: "0.0.123123-123412.123412", From: "SUBMITTED", To: "COMPLETED", Actor: "Burn Event Service", Reason: "SCHEDULED_TX_EXECUTED", Timestamp: 2},
+	}, timeline)
+	mocks.MBurnEventRepository.AssertNotCalled(t, "Get", mockBurnEventId)
+}
+
+func Test_TimelineEmpty(t *testing.T) {
+	setup()
+
+	mocks.MStatusTransitionRepository.On("GetForBurnEvent", mockBurnEventId).Return([]entity.StatusTransition{}, nil)
+	mocks.MBurnEventRepository.On("Get", mockBurnEventId).Return(&entity.BurnEvent{Id: mockBurnEventId}, nil)
+
+	timeline, err := s.Timeline(mockBurnEventId)
+	assert.Nil(t, err)
+	assert.Empty(t, timeline)
+}
+
+func Test_TimelineNotFound(t *testing.T) {
+	setup()
+
+	mocks.MStatusTransitionRepository.On("GetForBurnEvent", mockBurnEventId).Return([]entity.StatusTransition{}, nil)
+	mocks.MBurnEventRepository.On("Get", mockBurnEventId).Return(nil, nil)
+	mocks.MArchiveService.On("BurnEvent", mockBurnEventId).Return(nil, nil)
+
+	timeline, err := s.Timeline(mockBurnEventId)
+	assert.Equal(t, domainService.ErrNotFound, err)
+	assert.Nil(t, timeline)
+}
+
+func Test_TimelineRepositoryError(t *testing.T) {
+	setup()
+
+	expectedError := errors.New("connection-refused")
+	mocks.MStatusTransitionRepository.On("GetForBurnEvent", mockBurnEventId).Return(nil, expectedError)
+
+	timeline, err := s.Timeline(mockBurnEventId)
+	assert.Equal(t, expectedError, err)
+	assert.Nil(t, timeline)
+}
+
 func Test_ScheduledExecutionSuccessCallback(t *testing.T) {
 	setup()
 
 	mockEntityFee := &entity.Fee{
 		TransactionID: txId,
-		ScheduleID:    scheduleId,
-		Amount:        feeAmount,
-		Status:        feeRepo.StatusSubmitted,
+		ScheduleID: sql.NullString{
+			String: scheduleId,
+			Valid:  true,
+		},
+		Amount: feeAmount,
+		Status: feeRepo.StatusSubmitted,
 		BurnEventID: sql.NullString{
 			String: id,
 			Valid:  true,
 		},
 	}
 
-	mocks.MBurnEventRepository.On("UpdateStatusSubmitted", id, scheduleId, txId).Return(nil)
+	mocks.MBurnEventRepository.On("UpdateStatusSubmitted", id, scheduleId, txId, mock.Anything).Return(nil)
 	mocks.MFeeRepository.On("Create", mockEntityFee).Return(nil)
 
 	onSuccess, _ := s.scheduledTxExecutionCallbacks(id, feeAmount)
 	onSuccess(txId, scheduleId)
+
+	mocks.MWebhooksService.AssertCalled(t, "Notify", domainService.WebhookBurnScheduled, domainService.WebhookData{BurnEventID: id, TransactionID: txId, ScheduleID: scheduleId})
 }
 
 func Test_ScheduledExecutionUpdateStatusFails(t *testing.T) {
@@ -187,20 +474,25 @@ func Test_ScheduledExecutionUpdateStatusFails(t *testing.T) {
 
 	mockEntityFee := &entity.Fee{
 		TransactionID: txId,
-		ScheduleID:    scheduleId,
-		Amount:        feeAmount,
-		Status:        feeRepo.StatusSubmitted,
+		ScheduleID: sql.NullString{
+			String: scheduleId,
+			Valid:  true,
+		},
+		Amount: feeAmount,
+		Status: feeRepo.StatusSubmitted,
 		BurnEventID: sql.NullString{
 			String: id,
 			Valid:  true,
 		},
 	}
 
-	mocks.MBurnEventRepository.On("UpdateStatusSubmitted", id, scheduleId, txId).Return(errors.New("update-status-failed"))
+	mocks.MBurnEventRepository.On("UpdateStatusSubmitted", id, scheduleId, txId, mock.Anything).Return(errors.New("update-status-failed"))
 	mocks.MFeeRepository.AssertNotCalled(t, "Create", mockEntityFee)
 
 	onSuccess, _ := s.scheduledTxExecutionCallbacks(id, feeAmount)
 	onSuccess(txId, scheduleId)
+
+	mocks.MWebhooksService.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
 }
 
 func Test_ScheduledExecutionCreateFeeFails(t *testing.T) {
@@ -208,16 +500,19 @@ func Test_ScheduledExecutionCreateFeeFails(t *testing.T) {
 
 	mockEntityFee := &entity.Fee{
 		TransactionID: txId,
-		ScheduleID:    scheduleId,
-		Amount:        feeAmount,
-		Status:        feeRepo.StatusSubmitted,
+		ScheduleID: sql.NullString{
+			String: scheduleId,
+			Valid:  true,
+		},
+		Amount: feeAmount,
+		Status: feeRepo.StatusSubmitted,
 		BurnEventID: sql.NullString{
 			String: id,
 			Valid:  true,
 		},
 	}
 
-	mocks.MBurnEventRepository.On("UpdateStatusSubmitted", id, scheduleId, txId).Return(nil)
+	mocks.MBurnEventRepository.On("UpdateStatusSubmitted", id, scheduleId, txId, mock.Anything).Return(nil)
 	mocks.MFeeRepository.On("Create", mockEntityFee).Return(errors.New("create-failed"))
 
 	onSuccess, _ := s.scheduledTxExecutionCallbacks(id, feeAmount)
@@ -237,11 +532,13 @@ func Test_ScheduledExecutionFailCallback(t *testing.T) {
 		},
 	}
 
-	mocks.MBurnEventRepository.On("UpdateStatusFailed", id).Return(nil)
+	mocks.MBurnEventRepository.On("UpdateStatusFailed", id, mock.Anything).Return(nil)
 	mocks.MFeeRepository.On("Create", mockEntityFee).Return(nil)
 
 	_, onError := s.scheduledTxExecutionCallbacks(id, feeAmount)
 	onError(txId)
+
+	mocks.MWebhooksService.AssertCalled(t, "Notify", domainService.WebhookBurnFailed, domainService.WebhookData{BurnEventID: id})
 }
 
 func Test_ScheduledExecutionFailedUpdateStatusFails(t *testing.T) {
@@ -249,16 +546,19 @@ func Test_ScheduledExecutionFailedUpdateStatusFails(t *testing.T) {
 
 	mockEntityFee := &entity.Fee{
 		TransactionID: txId,
-		ScheduleID:    scheduleId,
-		Amount:        feeAmount,
-		Status:        feeRepo.StatusFailed,
+		ScheduleID: sql.NullString{
+			String: scheduleId,
+			Valid:  true,
+		},
+		Amount: feeAmount,
+		Status: feeRepo.StatusFailed,
 		BurnEventID: sql.NullString{
 			String: id,
 			Valid:  true,
 		},
 	}
 
-	mocks.MBurnEventRepository.On("UpdateStatusFailed", id).Return(errors.New("update-status-failed"))
+	mocks.MBurnEventRepository.On("UpdateStatusFailed", id, mock.Anything).Return(errors.New("update-status-failed"))
 	mocks.MFeeRepository.AssertNotCalled(t, "Create", mockEntityFee)
 
 	_, onError := s.scheduledTxExecutionCallbacks(id, feeAmount)
@@ -278,7 +578,7 @@ func Test_ScheduledExecutionFailedCreateFeeFails(t *testing.T) {
 		},
 	}
 
-	mocks.MBurnEventRepository.On("UpdateStatusFailed", id).Return(nil)
+	mocks.MBurnEventRepository.On("UpdateStatusFailed", id, mock.Anything).Return(nil)
 	mocks.MFeeRepository.On("Create", mockEntityFee).Return(errors.New("create-failed"))
 
 	_, onError := s.scheduledTxExecutionCallbacks(id, feeAmount)
@@ -288,18 +588,22 @@ func Test_ScheduledExecutionFailedCreateFeeFails(t *testing.T) {
 func Test_ScheduledTxMinedExecutionSuccessCallback(t *testing.T) {
 	setup()
 
-	mocks.MBurnEventRepository.On("UpdateStatusCompleted", id).Return(nil)
-	mocks.MFeeRepository.On("UpdateStatusCompleted", txId).Return(nil)
+	mocks.MBurnEventRepository.On("UpdateStatusCompleted", id, mock.Anything).Return(nil)
+	mocks.MFeeRepository.On("UpdateStatusCompleted", txId, mock.Anything).Return(nil)
 
 	onSuccess, _ := s.scheduledTxMinedCallbacks(id)
 	onSuccess(txId)
+
+	expectedData := domainService.WebhookData{BurnEventID: id, TransactionID: txId}
+	mocks.MWebhooksService.AssertCalled(t, "Notify", domainService.WebhookBurnCompleted, expectedData)
+	mocks.MWebhooksService.AssertCalled(t, "Notify", domainService.WebhookFeeCompleted, expectedData)
 }
 
 func Test_ScheduledTxMinedExecutionSuccessUpdateStatusFails(t *testing.T) {
 	setup()
 
-	mocks.MBurnEventRepository.On("UpdateStatusCompleted", id).Return(errors.New("update-status-fail"))
-	mocks.MFeeRepository.AssertNotCalled(t, "UpdateStatusCompleted", txId)
+	mocks.MBurnEventRepository.On("UpdateStatusCompleted", id, mock.Anything).Return(errors.New("update-status-fail"))
+	mocks.MFeeRepository.AssertNotCalled(t, "UpdateStatusCompleted", txId, mock.Anything)
 
 	onSuccess, _ := s.scheduledTxMinedCallbacks(id)
 	onSuccess(txId)
@@ -308,18 +612,20 @@ func Test_ScheduledTxMinedExecutionSuccessUpdateStatusFails(t *testing.T) {
 func Test_ScheduledTxMinedExecutionFailCallback(t *testing.T) {
 	setup()
 
-	mocks.MBurnEventRepository.On("UpdateStatusFailed", id).Return(nil)
-	mocks.MFeeRepository.On("UpdateStatusFailed", txId).Return(nil)
+	mocks.MBurnEventRepository.On("UpdateStatusFailed", id, mock.Anything).Return(nil)
+	mocks.MFeeRepository.On("UpdateStatusFailed", txId, mock.Anything).Return(nil)
 
 	_, onFail := s.scheduledTxMinedCallbacks(id)
 	onFail(txId)
+
+	mocks.MWebhooksService.AssertCalled(t, "Notify", domainService.WebhookBurnFailed, domainService.WebhookData{BurnEventID: id, TransactionID: txId})
 }
 
 func Test_ScheduledTxMinedExecutionFailUpdateStatusFailedFails(t *testing.T) {
 	setup()
 
-	mocks.MBurnEventRepository.On("UpdateStatusFailed", id).Return(errors.New("update-status-fail"))
-	mocks.MFeeRepository.AssertNotCalled(t, "UpdateStatusFailed", txId)
+	mocks.MBurnEventRepository.On("UpdateStatusFailed", id, mock.Anything).Return(errors.New("update-status-fail"))
+	mocks.MFeeRepository.AssertNotCalled(t, "UpdateStatusFailed", txId, mock.Anything)
 
 	_, onFail := s.scheduledTxMinedCallbacks(id)
 	onFail(txId)
@@ -328,8 +634,8 @@ func Test_ScheduledTxMinedExecutionFailUpdateStatusFailedFails(t *testing.T) {
 func Test_ScheduledTxMinedExecutionFailFeeUpdateFails(t *testing.T) {
 	setup()
 
-	mocks.MBurnEventRepository.On("UpdateStatusFailed", id).Return(nil)
-	mocks.MFeeRepository.On("UpdateStatusFailed", txId).Return(errors.New("update-fail"))
+	mocks.MBurnEventRepository.On("UpdateStatusFailed", id, mock.Anything).Return(nil)
+	mocks.MFeeRepository.On("UpdateStatusFailed", txId, mock.Anything).Return(errors.New("update-fail"))
 
 	_, onFail := s.scheduledTxMinedCallbacks(id)
 	onFail(txId)
@@ -338,22 +644,35 @@ func Test_ScheduledTxMinedExecutionFailFeeUpdateFails(t *testing.T) {
 func Test_ScheduledTxMinedExecutionSuccessFeeUpdateFails(t *testing.T) {
 	setup()
 
-	mocks.MBurnEventRepository.On("UpdateStatusCompleted", id).Return(nil)
-	mocks.MFeeRepository.On("UpdateStatusCompleted", txId).Return(errors.New("update-fail"))
+	mocks.MBurnEventRepository.On("UpdateStatusCompleted", id, mock.Anything).Return(nil)
+	mocks.MFeeRepository.On("UpdateStatusCompleted", txId, mock.Anything).Return(errors.New("update-fail"))
 
 	onSuccess, _ := s.scheduledTxMinedCallbacks(id)
 	onSuccess(txId)
+
+	mocks.MWebhooksService.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
 }
 
 func setup() {
 	mocks.Setup()
+	mocks.MFeeSettlementService.On("Enabled").Return(false)
+	mocks.MPauseService.On("Queue", burnEvent.Id, "", mock.Anything).Return(false)
+	mocks.MDecimalsService.On("ToNative", burnEvent.NativeAsset, burnEvent.WrappedAsset, burnEvent.Amount).Return(burnEvent.Amount, big.NewInt(0), nil)
+	mocks.MWebhooksService.On("Notify", mock.Anything, mock.Anything).Return()
 	s = &Service{
 		bridgeAccount:      hederaAccount,
 		feeRepository:      mocks.MFeeRepository,
 		repository:         mocks.MBurnEventRepository,
+		unitOfWork:         mocks.MUnitOfWork,
+		statusTransitions:  mocks.MStatusTransitionRepository,
 		distributorService: mocks.MDistributorService,
 		feeService:         mocks.MFeeService,
 		scheduledService:   mocks.MScheduledService,
+		feeSettlement:      mocks.MFeeSettlementService,
+		decimals:           mocks.MDecimalsService,
+		pause:              mocks.MPauseService,
+		archive:            mocks.MArchiveService,
+		webhooks:           mocks.MWebhooksService,
 		logger:             config.GetLoggerFor("Burn Event Service"),
 	}
 }
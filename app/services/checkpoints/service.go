@@ -0,0 +1,101 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checkpoints
+
+import (
+	"errors"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/helper/timestamp"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// Transfers is the watcher of the incoming transfers to the bridge account
+	Transfers = "transfers"
+	// Messages is the watcher of the messages on the bridge topic
+	Messages = "messages"
+)
+
+type checkpoint struct {
+	entityID   string
+	repository repository.Status
+}
+
+type Service struct {
+	checkpoints map[string]checkpoint
+	logger      *log.Entry
+}
+
+func New(bridgeAccount, topicID string, transferStatus, messageStatus repository.Status) *Service {
+	account, err := hedera.AccountIDFromString(bridgeAccount)
+	if err != nil {
+		log.Fatalf("Invalid bridge account: [%s].", bridgeAccount)
+	}
+
+	topic, err := hedera.TopicIDFromString(topicID)
+	if err != nil {
+		log.Fatalf("Invalid monitoring Topic ID [%s] - Error: [%s]", topicID, err)
+	}
+
+	return &Service{
+		checkpoints: map[string]checkpoint{
+			Transfers: {entityID: account.String(), repository: transferStatus},
+			Messages:  {entityID: topic.String(), repository: messageStatus},
+		},
+		logger: config.GetLoggerFor("Checkpoints Service"),
+	}
+}
+
+// Get returns the checkpoints by watcher
+func (s *Service) Get() (map[string]int64, error) {
+	result := make(map[string]int64)
+	for watcher, c := range s.checkpoints {
+		ts, err := c.repository.GetLastFetchedTimestamp(c.entityID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			s.logger.Errorf("Failed to get checkpoint of watcher [%s]. Error: [%s]", watcher, err)
+			return nil, err
+		}
+		result[watcher] = ts
+	}
+	return result, nil
+}
+
+// Reset sets the checkpoint of the watcher, from which it continues with its next poll.
+// Returns ErrNotFound for unknown watchers
+func (s *Service) Reset(watcher string, ts int64) error {
+	c, ok := s.checkpoints[watcher]
+	if !ok {
+		return service.ErrNotFound
+	}
+
+	err := c.repository.UpdateLastFetchedTimestamp(c.entityID, ts)
+	if err != nil {
+		s.logger.Errorf("Failed to reset checkpoint of watcher [%s]. Error: [%s]", watcher, err)
+		return err
+	}
+
+	s.logger.Infof("Reset checkpoint of watcher [%s] to [%s]", watcher, timestamp.ToHumanReadable(ts))
+	return nil
+}
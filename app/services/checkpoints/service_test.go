@@ -0,0 +1,78 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checkpoints
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var (
+	bridgeAccount = "0.0.1111"
+	topicID       = "0.0.2222"
+)
+
+func setup() *Service {
+	mocks.Setup()
+	return New(bridgeAccount, topicID, mocks.MStatusRepository, mocks.MStatusRepository)
+}
+
+func Test_Get(t *testing.T) {
+	s := setup()
+	mocks.MStatusRepository.On("GetLastFetchedTimestamp", bridgeAccount).Return(int64(100), nil)
+	mocks.MStatusRepository.On("GetLastFetchedTimestamp", topicID).Return(int64(0), gorm.ErrRecordNotFound)
+
+	result, err := s.Get()
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{Transfers: 100}, result)
+}
+
+func Test_GetFails(t *testing.T) {
+	s := setup()
+	mocks.MStatusRepository.On("GetLastFetchedTimestamp", bridgeAccount).Return(int64(0), errors.New("connection-refused"))
+	mocks.MStatusRepository.On("GetLastFetchedTimestamp", topicID).Return(int64(0), errors.New("connection-refused"))
+
+	result, err := s.Get()
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+func Test_Reset(t *testing.T) {
+	s := setup()
+	mocks.MStatusRepository.On("UpdateLastFetchedTimestamp", topicID, int64(200)).Return(nil)
+
+	err := s.Reset(Messages, 200)
+
+	assert.Nil(t, err)
+	mocks.MStatusRepository.AssertCalled(t, "UpdateLastFetchedTimestamp", topicID, int64(200))
+}
+
+func Test_ResetUnknownWatcher(t *testing.T) {
+	s := setup()
+
+	err := s.Reset("unknown", 200)
+
+	assert.Equal(t, service.ErrNotFound, err)
+	mocks.MStatusRepository.AssertNotCalled(t, "UpdateLastFetchedTimestamp", "unknown", int64(200))
+}
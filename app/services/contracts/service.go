@@ -20,6 +20,7 @@ import (
 	"errors"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/client"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	"math/big"
 	"strings"
@@ -28,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	routerAbi "github.com/limechain/hedera-eth-bridge-validator/app/clients/ethereum/contracts/router"
+	"github.com/limechain/hedera-eth-bridge-validator/app/clients/ethereum/contracts/wtoken"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -55,7 +57,7 @@ func (bsc *Service) ToWrapped(nativeAsset string) (string, error) {
 
 	erc20address := wrappedAsset.String()
 	if erc20address == nilErc20Address {
-		return "", errors.New("token-not-supported")
+		return "", service.ErrUnsupportedAsset
 	}
 
 	return erc20address, nil
@@ -74,6 +76,26 @@ func (bsc *Service) ToNative(wrappedAsset common.Address) (string, error) {
 	return string(common.TrimRightZeroes(native)), nil
 }
 
+// WrappedDecimals returns the decimals of the wrapped ERC-20 token
+func (bsc *Service) WrappedDecimals(wrappedAsset string) (uint8, error) {
+	token, err := wtoken.NewWtoken(common.HexToAddress(wrappedAsset), bsc.Client.GetClient())
+	if err != nil {
+		return 0, err
+	}
+
+	return token.Decimals(nil)
+}
+
+// WrappedPaused returns whether the wrapped ERC-20 token is paused
+func (bsc *Service) WrappedPaused(wrappedAsset string) (bool, error) {
+	token, err := wtoken.NewWtoken(common.HexToAddress(wrappedAsset), bsc.Client.GetClient())
+	if err != nil {
+		return false, err
+	}
+
+	return token.Paused(nil)
+}
+
 // Address returns the address of the contract instance
 func (bsc *Service) Address() common.Address {
 	return bsc.address
@@ -99,6 +121,26 @@ func (bsc *Service) WatchBurnEventLogs(opts *bind.WatchOpts, sink chan<- *router
 	return bsc.contract.WatchBurn(opts, sink, nil, nil)
 }
 
+// WatchMintEventLogs creates a subscription for Mint Events emitted in the Bridge contract
+func (bsc *Service) WatchMintEventLogs(opts *bind.WatchOpts, sink chan<- *routerAbi.RouterMint) (event.Subscription, error) {
+	return bsc.contract.WatchMint(opts, sink, nil, nil, nil)
+}
+
+// FilterBurnEventLogs returns the Burn Events emitted in the Bridge contract in the range of blocks
+func (bsc *Service) FilterBurnEventLogs(opts *bind.FilterOpts) ([]*routerAbi.RouterBurn, error) {
+	iterator, err := bsc.contract.FilterBurn(opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	var events []*routerAbi.RouterBurn
+	for iterator.Next() {
+		events = append(events, iterator.Event)
+	}
+	return events, iterator.Error()
+}
+
 func (bsc *Service) updateMembers() {
 	membersCount, err := bsc.contract.MembersCount(nil)
 	if err != nil {
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package decimals
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/client"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/constants"
+	log "github.com/sirupsen/logrus"
+)
+
+type pair struct {
+	native  uint8
+	wrapped uint8
+}
+
+type Service struct {
+	mirrorNode client.MirrorNode
+	contracts  service.Contracts
+	mutex      sync.Mutex
+	pairs      map[string]pair
+	logger     *log.Entry
+}
+
+// New creates a decimals service, which retrieves the Hedera decimals from the mirror node
+// and the ERC-20 decimals from the wrapped token contract
+func New(mirrorNode client.MirrorNode, contracts service.Contracts) *Service {
+	return &Service{
+		mirrorNode: mirrorNode,
+		contracts:  contracts,
+		pairs:      make(map[string]pair),
+		logger:     config.GetLoggerFor("Decimals Service"),
+	}
+}
+
+// ToWrapped converts an amount in native asset units into wrapped token units.
+// Returns the dust (in native asset units), which cannot be represented with the wrapped token decimals
+func (s *Service) ToWrapped(nativeAsset, wrappedAsset string, amount *big.Int) (wrappedAmount, dust *big.Int, err error) {
+	p, err := s.pair(nativeAsset, wrappedAsset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wrappedAmount, dust = convert(amount, p.native, p.wrapped)
+	return wrappedAmount, dust, nil
+}
+
+// ToNative converts an amount in wrapped token units into native asset units.
+// Returns the dust (in wrapped token units), which cannot be represented with the native asset decimals
+func (s *Service) ToNative(nativeAsset, wrappedAsset string, amount *big.Int) (nativeAmount, dust *big.Int, err error) {
+	p, err := s.pair(nativeAsset, wrappedAsset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nativeAmount, dust = convert(amount, p.wrapped, p.native)
+	return nativeAmount, dust, nil
+}
+
+// convert converts the amount from the given decimals into the target decimals.
+// Returns the converted amount and the remainder (in source units) lost by the conversion
+func convert(amount *big.Int, from, to uint8) (converted, dust *big.Int) {
+	if from == to {
+		return new(big.Int).Set(amount), big.NewInt(0)
+	}
+
+	if from < to {
+		multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(to-from)), nil)
+		return new(big.Int).Mul(amount, multiplier), big.NewInt(0)
+	}
+
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(from-to)), nil)
+	converted, dust = new(big.Int).QuoRem(amount, divisor, new(big.Int))
+	return converted, dust
+}
+
+func (s *Service) pair(nativeAsset, wrappedAsset string) (pair, error) {
+	key := fmt.Sprintf("%s-%s", nativeAsset, wrappedAsset)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if p, ok := s.pairs[key]; ok {
+		return p, nil
+	}
+
+	native, err := s.nativeDecimals(nativeAsset)
+	if err != nil {
+		s.logger.Errorf("Failed to retrieve decimals of native asset [%s]. Error: [%s]", nativeAsset, err)
+		return pair{}, err
+	}
+
+	wrapped, err := s.contracts.WrappedDecimals(wrappedAsset)
+	if err != nil {
+		s.logger.Errorf("Failed to retrieve decimals of wrapped asset [%s]. Error: [%s]", wrappedAsset, err)
+		return pair{}, err
+	}
+
+	p := pair{native: native, wrapped: wrapped}
+	s.pairs[key] = p
+	s.logger.Debugf("Decimals of [%s] - [%d], decimals of [%s] - [%d].", nativeAsset, native, wrappedAsset, wrapped)
+	return p, nil
+}
+
+func (s *Service) nativeDecimals(nativeAsset string) (uint8, error) {
+	if nativeAsset == constants.Hbar {
+		return constants.HbarDecimals, nil
+	}
+
+	token, err := s.mirrorNode.GetToken(nativeAsset)
+	if err != nil {
+		return 0, err
+	}
+
+	decimals, err := strconv.ParseUint(token.Decimals, 10, 8)
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("Invalid decimals [%s] of token [%s].", token.Decimals, nativeAsset))
+	}
+	return uint8(decimals), nil
+}
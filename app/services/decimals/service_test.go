@@ -0,0 +1,129 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package decimals
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	mirror_node "github.com/limechain/hedera-eth-bridge-validator/app/clients/hedera/mirror-node"
+	"github.com/limechain/hedera-eth-bridge-validator/constants"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	token        = "0.0.1234"
+	wrappedAsset = "0x0000000000000000000000000000000000000002"
+)
+
+func newService() *Service {
+	mocks.Setup()
+	return New(mocks.MHederaMirrorClient, mocks.MBridgeContractService)
+}
+
+func Test_ToWrappedScalesUp(t *testing.T) {
+	s := newService()
+	mocks.MBridgeContractService.On("WrappedDecimals", wrappedAsset).Return(uint8(18), nil)
+
+	wrappedAmount, dust, err := s.ToWrapped(constants.Hbar, wrappedAsset, big.NewInt(123))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1230000000000", wrappedAmount.String())
+	assert.Equal(t, "0", dust.String())
+	mocks.MHederaMirrorClient.AssertNotCalled(t, "GetToken", constants.Hbar)
+}
+
+func Test_ToWrappedScalesDownWithDust(t *testing.T) {
+	s := newService()
+	mocks.MHederaMirrorClient.On("GetToken", token).Return(&mirror_node.Token{TokenID: token, Decimals: "8"}, nil)
+	mocks.MBridgeContractService.On("WrappedDecimals", wrappedAsset).Return(uint8(6), nil)
+
+	wrappedAmount, dust, err := s.ToWrapped(token, wrappedAsset, big.NewInt(123456789))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1234567", wrappedAmount.String())
+	assert.Equal(t, "89", dust.String())
+}
+
+func Test_ToNativeScalesDownWithDust(t *testing.T) {
+	s := newService()
+	mocks.MHederaMirrorClient.On("GetToken", token).Return(&mirror_node.Token{TokenID: token, Decimals: "8"}, nil)
+	mocks.MBridgeContractService.On("WrappedDecimals", wrappedAsset).Return(uint8(18), nil)
+	amount, _ := new(big.Int).SetString("1230000000000000005", 10)
+
+	nativeAmount, dust, err := s.ToNative(token, wrappedAsset, amount)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "123000000", nativeAmount.String())
+	assert.Equal(t, "5", dust.String())
+}
+
+func Test_SameDecimals(t *testing.T) {
+	s := newService()
+	mocks.MHederaMirrorClient.On("GetToken", token).Return(&mirror_node.Token{TokenID: token, Decimals: "6"}, nil)
+	mocks.MBridgeContractService.On("WrappedDecimals", wrappedAsset).Return(uint8(6), nil)
+
+	nativeAmount, dust, err := s.ToNative(token, wrappedAsset, big.NewInt(100))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "100", nativeAmount.String())
+	assert.Equal(t, "0", dust.String())
+}
+
+func Test_DecimalsAreCached(t *testing.T) {
+	s := newService()
+	mocks.MHederaMirrorClient.On("GetToken", token).Return(&mirror_node.Token{TokenID: token, Decimals: "8"}, nil)
+	mocks.MBridgeContractService.On("WrappedDecimals", wrappedAsset).Return(uint8(18), nil)
+
+	_, _, err := s.ToWrapped(token, wrappedAsset, big.NewInt(1))
+	assert.Nil(t, err)
+	_, _, err = s.ToNative(token, wrappedAsset, big.NewInt(1))
+	assert.Nil(t, err)
+
+	mocks.MHederaMirrorClient.AssertNumberOfCalls(t, "GetToken", 1)
+	mocks.MBridgeContractService.AssertNumberOfCalls(t, "WrappedDecimals", 1)
+}
+
+func Test_NativeDecimalsFail(t *testing.T) {
+	s := newService()
+	mocks.MHederaMirrorClient.On("GetToken", token).Return(nil, errors.New("not found"))
+
+	_, _, err := s.ToWrapped(token, wrappedAsset, big.NewInt(1))
+
+	assert.Error(t, err)
+	mocks.MBridgeContractService.AssertNotCalled(t, "WrappedDecimals", wrappedAsset)
+}
+
+func Test_InvalidNativeDecimals(t *testing.T) {
+	s := newService()
+	mocks.MHederaMirrorClient.On("GetToken", token).Return(&mirror_node.Token{TokenID: token, Decimals: "invalid"}, nil)
+
+	_, _, err := s.ToWrapped(token, wrappedAsset, big.NewInt(1))
+
+	assert.Error(t, err)
+}
+
+func Test_WrappedDecimalsFail(t *testing.T) {
+	s := newService()
+	mocks.MBridgeContractService.On("WrappedDecimals", wrappedAsset).Return(uint8(0), errors.New("execution reverted"))
+
+	_, _, err := s.ToNative(constants.Hbar, wrappedAsset, big.NewInt(1))
+
+	assert.Error(t, err)
+}
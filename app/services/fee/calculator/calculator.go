@@ -17,6 +17,11 @@
 package calculator
 
 import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/helper/file"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	log "github.com/sirupsen/logrus"
 )
@@ -26,28 +31,87 @@ const MinPercentage = 0
 
 type Service struct {
 	feePercentage int64
-	logger        *log.Entry
+	mutex         sync.RWMutex
+	// policies are sorted in descending order by the timestamp, from which they are effective
+	policies []*policy
+	logger   *log.Entry
 }
 
-func New(feePercentage int64) *Service {
-	if feePercentage < MinPercentage || feePercentage > MaxPercentage {
-		log.Fatalf("Invalid fee percentage: [%d]", feePercentage)
-	}
-
-	return &Service{
+// New creates a fee policy engine using the given default fee percentage and policy rules.
+// If the policy references a rules file, the file is watched and reloaded on change
+func New(feePercentage int64, feePolicy config.FeePolicy) *Service {
+	s := &Service{
 		feePercentage: feePercentage,
 		logger:        config.GetLoggerFor("Fee Service")}
-}
 
-// CalculateFee calculates the fee and remainder of a given amount
-func (s Service) CalculateFee(amount int64) (fee, remainder int64) {
-	fee = amount * s.feePercentage / MaxPercentage
-	remainder = amount - fee
+	rules := feePolicy.FeeRules
+	if feePolicy.File != "" {
+		var err error
+		rules, err = config.LoadFeeRules(feePolicy.File)
+		if err != nil {
+			log.Fatalf("Failed to load fee policy file [%s]. Error: [%s]", feePolicy.File, err)
+		}
+	}
 
-	totalAmount := remainder + fee
-	if totalAmount != amount {
-		remainder += amount - totalAmount
+	err := s.Reload(rules)
+	if err != nil {
+		log.Fatalf("Invalid fee policy. Error: [%s]", err)
 	}
 
+	if feePolicy.File != "" && feePolicy.ReloadInterval > 0 {
+		go file.Watch(feePolicy.File, "fee policy", feePolicy.ReloadInterval, s.logger, func() error {
+			rules, err := config.LoadFeeRules(feePolicy.File)
+			if err != nil {
+				return err
+			}
+			return s.Reload(rules)
+		})
+	}
+
+	return s
+}
+
+// CalculateFee calculates the fee and remainder of a given amount, based on the rules configured
+// for the asset and the receiver, which are effective at the timestamp (in seconds) of the operation
+func (s *Service) CalculateFee(nativeAsset, receiver string, amount *big.Int, timestamp int64) (fee, remainder *big.Int) {
+	s.mutex.RLock()
+	p := effectiveAt(s.policies, timestamp)
+	s.mutex.RUnlock()
+
+	fee = p.calculate(nativeAsset, receiver, amount)
+	remainder = new(big.Int).Sub(amount, fee)
+
 	return fee, remainder
 }
+
+// Reload validates the provided rules and replaces the currently applied policies
+func (s *Service) Reload(rules config.FeeRules) error {
+	policies, err := newPolicies(s.feePercentage, rules)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	previous := s.policies
+	s.policies = policies
+	s.mutex.Unlock()
+
+	if previous != nil {
+		s.warnRetroactive(previous, policies, time.Now().Unix())
+	}
+	return nil
+}
+
+// warnRetroactive warns about reloaded rules, which are already effective. Operations processed before the reload
+// were charged by the previous rules, so validators, which reloaded them at different times, may disagree on their fee
+func (s *Service) warnRetroactive(previous, policies []*policy, now int64) {
+	loaded := make(map[int64]bool)
+	for _, p := range previous {
+		loaded[p.effectiveFrom] = true
+	}
+	for _, p := range policies {
+		if !loaded[p.effectiveFrom] && p.effectiveFrom <= now {
+			s.logger.Warnf("Fee rules effective from [%d] are loaded after they took effect. Rules should be loaded by all validators before their `effective_from`.", p.effectiveFrom)
+		}
+	}
+}
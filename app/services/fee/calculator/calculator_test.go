@@ -0,0 +1,182 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package calculator
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	asset     = "0.0.1234"
+	receiver  = "0xsomeethaddress"
+	timestamp = int64(1600000000)
+)
+
+func percentage(p int64) *int64 {
+	return &p
+}
+
+func Test_CalculateFee_Default(t *testing.T) {
+	s := New(10000, config.FeePolicy{})
+
+	fee, remainder := s.CalculateFee(asset, receiver, big.NewInt(1000), timestamp)
+
+	assert.Equal(t, "100", fee.String())
+	assert.Equal(t, "900", remainder.String())
+}
+
+func Test_CalculateFee_AssetPercentageAndCaps(t *testing.T) {
+	s := New(10000, config.FeePolicy{
+		FeeRules: config.FeeRules{
+			Assets: map[string]config.AssetFee{
+				asset: {Percentage: percentage(1000), MinFee: 5, MaxFee: 50},
+			},
+		},
+	})
+
+	fee, _ := s.CalculateFee(asset, receiver, big.NewInt(100), timestamp)
+	assert.Equal(t, "5", fee.String())
+
+	fee, _ = s.CalculateFee(asset, receiver, big.NewInt(1000), timestamp)
+	assert.Equal(t, "10", fee.String())
+
+	fee, remainder := s.CalculateFee(asset, receiver, big.NewInt(100000), timestamp)
+	assert.Equal(t, "50", fee.String())
+	assert.Equal(t, "99950", remainder.String())
+
+	fee, remainder = s.CalculateFee(asset, receiver, big.NewInt(3), timestamp)
+	assert.Equal(t, "3", fee.String())
+	assert.Equal(t, "0", remainder.String())
+}
+
+func Test_CalculateFee_Tiers(t *testing.T) {
+	s := New(10000, config.FeePolicy{
+		FeeRules: config.FeeRules{
+			Assets: map[string]config.AssetFee{
+				asset: {Tiers: []config.FeeTier{
+					{From: 10000, Percentage: 1000},
+					{From: 1000, Percentage: 5000},
+				}},
+			},
+		},
+	})
+
+	fee, _ := s.CalculateFee(asset, receiver, big.NewInt(100), timestamp)
+	assert.Equal(t, "10", fee.String())
+
+	fee, _ = s.CalculateFee(asset, receiver, big.NewInt(1000), timestamp)
+	assert.Equal(t, "50", fee.String())
+
+	fee, _ = s.CalculateFee(asset, receiver, big.NewInt(10000), timestamp)
+	assert.Equal(t, "100", fee.String())
+}
+
+func Test_CalculateFee_Whitelist(t *testing.T) {
+	s := New(10000, config.FeePolicy{
+		FeeRules: config.FeeRules{
+			Whitelist: []string{"0xSomeEthAddress"},
+		},
+	})
+
+	fee, remainder := s.CalculateFee(asset, receiver, big.NewInt(1000), timestamp)
+
+	assert.Equal(t, "0", fee.String())
+	assert.Equal(t, "1000", remainder.String())
+}
+
+func Test_Reload(t *testing.T) {
+	s := New(10000, config.FeePolicy{})
+
+	err := s.Reload(config.FeeRules{Percentage: percentage(MaxPercentage + 1)})
+	assert.Error(t, err)
+
+	fee, _ := s.CalculateFee(asset, receiver, big.NewInt(1000), timestamp)
+	assert.Equal(t, "100", fee.String())
+
+	err = s.Reload(config.FeeRules{Percentage: percentage(20000)})
+	assert.Nil(t, err)
+
+	fee, _ = s.CalculateFee(asset, receiver, big.NewInt(1000), timestamp)
+	assert.Equal(t, "200", fee.String())
+}
+
+func Test_CalculateFee_BigAmount(t *testing.T) {
+	s := New(10000, config.FeePolicy{})
+
+	amount, _ := new(big.Int).SetString("100000000000000000000", 10)
+	fee, remainder := s.CalculateFee(asset, receiver, amount, timestamp)
+
+	assert.Equal(t, "10000000000000000000", fee.String())
+	assert.Equal(t, "90000000000000000000", remainder.String())
+}
+
+func Test_CalculateFee_EffectiveFrom(t *testing.T) {
+	s := New(10000, config.FeePolicy{
+		FeeRules: config.FeeRules{
+			EffectiveFrom: timestamp,
+			Percentage:    percentage(20000),
+			History: []config.FeeRules{
+				{Percentage: percentage(5000)},
+				{EffectiveFrom: timestamp - 100, Percentage: percentage(1000)},
+			},
+		},
+	})
+
+	fee, _ := s.CalculateFee(asset, receiver, big.NewInt(1000), timestamp-101)
+	assert.Equal(t, "50", fee.String())
+
+	fee, _ = s.CalculateFee(asset, receiver, big.NewInt(1000), timestamp-100)
+	assert.Equal(t, "10", fee.String())
+
+	fee, _ = s.CalculateFee(asset, receiver, big.NewInt(1000), timestamp-1)
+	assert.Equal(t, "10", fee.String())
+
+	fee, _ = s.CalculateFee(asset, receiver, big.NewInt(1000), timestamp)
+	assert.Equal(t, "200", fee.String())
+}
+
+func Test_CalculateFee_BeforeEarliestRules(t *testing.T) {
+	s := New(10000, config.FeePolicy{
+		FeeRules: config.FeeRules{EffectiveFrom: timestamp, Percentage: percentage(20000)},
+	})
+
+	fee, _ := s.CalculateFee(asset, receiver, big.NewInt(1000), timestamp-1)
+
+	assert.Equal(t, "200", fee.String())
+}
+
+func Test_Reload_InvalidHistory(t *testing.T) {
+	s := New(10000, config.FeePolicy{})
+
+	err := s.Reload(config.FeeRules{History: []config.FeeRules{{}}})
+	assert.Error(t, err)
+
+	err = s.Reload(config.FeeRules{EffectiveFrom: timestamp, History: []config.FeeRules{
+		{History: []config.FeeRules{{EffectiveFrom: 1}}},
+	}})
+	assert.Error(t, err)
+
+	err = s.Reload(config.FeeRules{EffectiveFrom: -1})
+	assert.Error(t, err)
+
+	fee, _ := s.CalculateFee(asset, receiver, big.NewInt(1000), timestamp)
+	assert.Equal(t, "100", fee.String())
+}
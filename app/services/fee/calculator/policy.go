@@ -0,0 +1,196 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+)
+
+// policy is the validated, immutable representation of the configured fee rules
+type policy struct {
+	// effectiveFrom is the timestamp (in seconds) of the operations, from which the policy is applied
+	effectiveFrom int64
+	defaultRule   rule
+	assets        map[string]rule
+	whitelist     map[string]bool
+}
+
+type rule struct {
+	percentage int64
+	minFee     *big.Int
+	maxFee     *big.Int
+	// tiers are sorted in descending order by their `from` amount
+	tiers []tier
+}
+
+type tier struct {
+	from       *big.Int
+	percentage int64
+}
+
+// newPolicies validates the rules together with their history and returns their policies, sorted in
+// descending order by the timestamp, from which they are effective
+func newPolicies(feePercentage int64, rules config.FeeRules) ([]*policy, error) {
+	var policies []*policy
+	effective := make(map[int64]bool)
+	for i, r := range append([]config.FeeRules{rules}, rules.History...) {
+		if i > 0 && len(r.History) > 0 {
+			return nil, errors.New(fmt.Sprintf("rules effective from [%d] in the history cannot have a history", r.EffectiveFrom))
+		}
+		if r.EffectiveFrom < 0 {
+			return nil, errors.New(fmt.Sprintf("invalid effective from [%d]", r.EffectiveFrom))
+		}
+		if effective[r.EffectiveFrom] {
+			return nil, errors.New(fmt.Sprintf("more than one set of rules is effective from [%d]", r.EffectiveFrom))
+		}
+		effective[r.EffectiveFrom] = true
+
+		p, err := newPolicy(feePercentage, r)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].effectiveFrom > policies[j].effectiveFrom
+	})
+	return policies, nil
+}
+
+// effectiveAt returns the policy effective at the given timestamp (in seconds). Operations before
+// the earliest policy are charged by it
+func effectiveAt(policies []*policy, timestamp int64) *policy {
+	for _, p := range policies {
+		if p.effectiveFrom <= timestamp {
+			return p
+		}
+	}
+	return policies[len(policies)-1]
+}
+
+func newPolicy(feePercentage int64, rules config.FeeRules) (*policy, error) {
+	if rules.Percentage != nil {
+		feePercentage = *rules.Percentage
+	}
+	if !isValidPercentage(feePercentage) {
+		return nil, errors.New(fmt.Sprintf("invalid fee percentage [%d]", feePercentage))
+	}
+
+	p := &policy{
+		effectiveFrom: rules.EffectiveFrom,
+		defaultRule:   rule{percentage: feePercentage, minFee: big.NewInt(0), maxFee: big.NewInt(0)},
+		assets:        make(map[string]rule),
+		whitelist:     make(map[string]bool),
+	}
+
+	for asset, assetFee := range rules.Assets {
+		r, err := newRule(feePercentage, assetFee)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("invalid fee rule for asset [%s]: %s", asset, err))
+		}
+		p.assets[asset] = r
+	}
+
+	for _, receiver := range rules.Whitelist {
+		p.whitelist[strings.ToLower(receiver)] = true
+	}
+
+	return p, nil
+}
+
+func newRule(defaultPercentage int64, assetFee config.AssetFee) (rule, error) {
+	r := rule{
+		percentage: defaultPercentage,
+		minFee:     big.NewInt(assetFee.MinFee),
+		maxFee:     big.NewInt(assetFee.MaxFee),
+	}
+	if assetFee.Percentage != nil {
+		r.percentage = *assetFee.Percentage
+	}
+
+	if !isValidPercentage(r.percentage) {
+		return rule{}, errors.New(fmt.Sprintf("percentage [%d] out of range", r.percentage))
+	}
+	if r.minFee.Sign() < 0 || r.maxFee.Sign() < 0 {
+		return rule{}, errors.New("fee caps cannot be negative")
+	}
+	if r.maxFee.Sign() > 0 && r.minFee.Cmp(r.maxFee) > 0 {
+		return rule{}, errors.New(fmt.Sprintf("min fee [%d] is greater than max fee [%d]", r.minFee, r.maxFee))
+	}
+
+	for _, t := range assetFee.Tiers {
+		if !isValidPercentage(t.Percentage) {
+			return rule{}, errors.New(fmt.Sprintf("tier percentage [%d] out of range", t.Percentage))
+		}
+		if t.From < 0 {
+			return rule{}, errors.New(fmt.Sprintf("tier amount [%d] cannot be negative", t.From))
+		}
+		r.tiers = append(r.tiers, tier{from: big.NewInt(t.From), percentage: t.Percentage})
+	}
+	sort.SliceStable(r.tiers, func(i, j int) bool {
+		return r.tiers[i].from.Cmp(r.tiers[j].from) > 0
+	})
+
+	return r, nil
+}
+
+// calculate returns the fee for the given amount. Whitelisted receivers are not charged
+func (p *policy) calculate(nativeAsset, receiver string, amount *big.Int) *big.Int {
+	if amount.Sign() <= 0 || p.whitelist[strings.ToLower(receiver)] {
+		return big.NewInt(0)
+	}
+
+	r, ok := p.assets[nativeAsset]
+	if !ok {
+		r = p.defaultRule
+	}
+
+	fee := new(big.Int).Mul(amount, big.NewInt(r.percentageFor(amount)))
+	fee.Div(fee, big.NewInt(MaxPercentage))
+	if fee.Cmp(r.minFee) < 0 {
+		fee.Set(r.minFee)
+	}
+	if r.maxFee.Sign() > 0 && fee.Cmp(r.maxFee) > 0 {
+		fee.Set(r.maxFee)
+	}
+	if fee.Cmp(amount) > 0 {
+		fee.Set(amount)
+	}
+
+	return fee
+}
+
+// percentageFor returns the percentage of the highest tier reached by the amount
+func (r rule) percentageFor(amount *big.Int) int64 {
+	for _, t := range r.tiers {
+		if amount.Cmp(t.from) >= 0 {
+			return t.percentage
+		}
+	}
+	return r.percentage
+}
+
+func isValidPercentage(percentage int64) bool {
+	return percentage >= MinPercentage && percentage <= MaxPercentage
+}
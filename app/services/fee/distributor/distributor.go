@@ -18,65 +18,105 @@ package distributor
 
 import (
 	"errors"
-	"github.com/hashgraph/hedera-sdk-go/v2"
+	"fmt"
+	"hash/fnv"
+	"math/big"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	big_numbers "github.com/limechain/hedera-eth-bridge-validator/app/helper/big-numbers"
 	"github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	log "github.com/sirupsen/logrus"
 )
 
+const defaultWeight = 1
+
 type Service struct {
-	accountIDs []hedera.AccountID
-	logger     *log.Entry
+	registry service.MemberRegistry
+	weights  map[string]int64
+	logger   *log.Entry
 }
 
-func New(members []string) *Service {
-	if len(members) == 0 {
-		log.Fatal("No members accounts provided")
-	}
-
-	var accountIDs []hedera.AccountID
-	for _, v := range members {
-		accountID, err := hedera.AccountIDFromString(v)
-		if err != nil {
-			log.Fatalf("Invalid members account: [%s].", v)
+// New creates a distributor for the members accounts provided by the registry. Members
+// without a configured weight have a default weight of 1
+func New(registry service.MemberRegistry, weights map[string]int64) *Service {
+	for account, weight := range weights {
+		if weight <= 0 {
+			log.Fatalf("Invalid weight [%d] for members account: [%s].", weight, account)
 		}
-		accountIDs = append(accountIDs, accountID)
 	}
 
 	return &Service{
-		accountIDs: accountIDs,
-		logger:     config.GetLoggerFor("Fee Service")}
+		registry: registry,
+		weights:  weights,
+		logger:   config.GetLoggerFor("Distributor Service")}
 }
 
-// CalculateMemberDistribution Returns an equally divided to each member
-func (s Service) CalculateMemberDistribution(amount int64) ([]transfer.Hedera, error) {
-	feePerAccount := amount / int64(len(s.accountIDs))
+// CalculateMemberDistribution returns the transfers, distributing the whole amount to
+// the members proportionally to their weights. The remainder left after the proportional
+// split is given out one unit per member, starting from a member determined by the `id`,
+// so that every validator computes the same distribution for a given operation
+func (s Service) CalculateMemberDistribution(id string, amount *big.Int) ([]transfer.Hedera, error) {
+	if amount.Sign() < 0 {
+		s.logger.Errorf("[%s] - Provided fee [%s] is negative.", id, amount)
+		return nil, errors.New(fmt.Sprintf("invalid amount [%s]", amount))
+	}
 
-	totalAmount := feePerAccount * int64(len(s.accountIDs))
-	if totalAmount != amount {
-		s.logger.Errorf("Provided fee [%d] is not divisible.", amount)
-		return nil, errors.New("amount not divisible")
+	members := s.registry.Accounts()
+	if len(members) == 0 {
+		s.logger.Errorf("[%s] - No members accounts to distribute to.", id)
+		return nil, errors.New("no members accounts")
+	}
+
+	weights := make([]int64, len(members))
+	var totalWeight int64
+	for i, m := range members {
+		weight, ok := s.weights[m.String()]
+		if !ok {
+			weight = defaultWeight
+		}
+		weights[i] = weight
+		totalWeight += weight
+	}
+
+	amounts := make([]*big.Int, len(members))
+	distributed := big.NewInt(0)
+	for i := range members {
+		amounts[i] = new(big.Int).Mul(amount, big.NewInt(weights[i]))
+		amounts[i].Quo(amounts[i], big.NewInt(totalWeight))
+		distributed.Add(distributed, amounts[i])
+	}
+
+	// The remainder is less than the number of members, as every share is rounded down by less than a unit
+	remainder := new(big.Int).Sub(amount, distributed).Int64()
+	start := offset(id, len(members))
+	for i := int64(0); i < remainder; i++ {
+		share := amounts[(start+int(i))%len(members)]
+		share.Add(share, big.NewInt(1))
 	}
 
 	var transfers []transfer.Hedera
-	for _, a := range s.accountIDs {
+	for i, m := range members {
+		if amounts[i].Sign() == 0 {
+			continue
+		}
+		hederaAmount, err := big_numbers.ToInt64(amounts[i])
+		if err != nil {
+			s.logger.Errorf("[%s] - Share of [%s] cannot be transferred. Error: [%s].", id, m, err)
+			return nil, err
+		}
 		transfers = append(transfers, transfer.Hedera{
-			AccountID: a,
-			Amount:    feePerAccount,
+			AccountID: m,
+			Amount:    hederaAmount,
 		})
 	}
 
 	return transfers, nil
 }
 
-// ValidAmount Returns the closest amount, which can be equally divided to members
-func (s Service) ValidAmount(amount int64) int64 {
-	feePerAccount := amount / int64(len(s.accountIDs))
-
-	totalAmount := feePerAccount * int64(len(s.accountIDs))
-	if totalAmount != amount {
-		return totalAmount
-	}
-
-	return amount
+// offset returns the deterministic index of the member receiving the first remainder unit
+func offset(id string, membersCount int) int {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return int(h.Sum32() % uint32(membersCount))
 }
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distributor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	members = []string{"0.0.1", "0.0.2", "0.0.3"}
+	id      = "0.0.1234-1610000000-000000000"
+)
+
+func setup() {
+	mocks.Setup()
+
+	var accounts []hedera.AccountID
+	for _, m := range members {
+		account, _ := hedera.AccountIDFromString(m)
+		accounts = append(accounts, account)
+	}
+	mocks.MMemberRegistry.On("Accounts").Return(accounts)
+}
+
+func total(t *testing.T, s *Service, amount int64) map[string]int64 {
+	transfers, err := s.CalculateMemberDistribution(id, big.NewInt(amount))
+	assert.Nil(t, err)
+
+	result := make(map[string]int64)
+	var sum int64
+	for _, tr := range transfers {
+		result[tr.AccountID.String()] = tr.Amount
+		sum += tr.Amount
+	}
+	assert.Equal(t, amount, sum)
+
+	return result
+}
+
+func Test_CalculateMemberDistribution_Equal(t *testing.T) {
+	setup()
+	s := New(mocks.MMemberRegistry, nil)
+
+	result := total(t, s, 30)
+
+	for _, m := range members {
+		assert.Equal(t, int64(10), result[m])
+	}
+}
+
+func Test_CalculateMemberDistribution_Weights(t *testing.T) {
+	setup()
+	s := New(mocks.MMemberRegistry, map[string]int64{"0.0.1": 2, "0.0.3": 5})
+
+	result := total(t, s, 800)
+
+	assert.Equal(t, int64(200), result["0.0.1"])
+	assert.Equal(t, int64(100), result["0.0.2"])
+	assert.Equal(t, int64(500), result["0.0.3"])
+}
+
+func Test_CalculateMemberDistribution_Remainder(t *testing.T) {
+	setup()
+	s := New(mocks.MMemberRegistry, nil)
+
+	result := total(t, s, 32)
+
+	var extra int
+	for _, m := range members {
+		assert.True(t, result[m] == 10 || result[m] == 11)
+		if result[m] == 11 {
+			extra++
+		}
+	}
+	assert.Equal(t, 2, extra)
+	assert.Equal(t, result, total(t, s, 32))
+}
+
+func Test_CalculateMemberDistribution_SmallAmount(t *testing.T) {
+	setup()
+	s := New(mocks.MMemberRegistry, nil)
+
+	transfers, err := s.CalculateMemberDistribution(id, big.NewInt(1))
+
+	assert.Nil(t, err)
+	assert.Len(t, transfers, 1)
+	assert.Equal(t, int64(1), transfers[0].Amount)
+}
+
+func Test_CalculateMemberDistribution_Negative(t *testing.T) {
+	setup()
+	s := New(mocks.MMemberRegistry, nil)
+
+	transfers, err := s.CalculateMemberDistribution(id, big.NewInt(-1))
+
+	assert.Error(t, err)
+	assert.Nil(t, transfers)
+}
+
+func Test_CalculateMemberDistribution_NoMembers(t *testing.T) {
+	mocks.Setup()
+	mocks.MMemberRegistry.On("Accounts").Return([]hedera.AccountID{})
+	s := New(mocks.MMemberRegistry, nil)
+
+	transfers, err := s.CalculateMemberDistribution(id, big.NewInt(10))
+
+	assert.Error(t, err)
+	assert.Nil(t, transfers)
+}
+
+func Test_CalculateMemberDistribution_Overflow(t *testing.T) {
+	setup()
+	s := New(mocks.MMemberRegistry, nil)
+
+	amount, _ := new(big.Int).SetString("100000000000000000000", 10)
+	transfers, err := s.CalculateMemberDistribution(id, amount)
+
+	assert.Error(t, err)
+	assert.Nil(t, transfers)
+}
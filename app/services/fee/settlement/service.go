@@ -0,0 +1,299 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package settlement
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	big_numbers "github.com/limechain/hedera-eth-bridge-validator/app/helper/big-numbers"
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// actor is the component recorded in the status transitions of the service
+const actor = "Fee Settlement Service"
+
+type Service struct {
+	enabled          bool
+	interval         int64
+	delay            int64
+	bridgeAccount    hedera.AccountID
+	feeRepository    repository.Fee
+	statusRepository repository.Status
+	distributor      service.Distributor
+	scheduledService service.Scheduled
+	webhooks         service.Webhooks
+	logger           *log.Entry
+}
+
+// batch groups the accrued fees of a single asset, which are settled together
+type batch struct {
+	nativeAsset string
+	start       int64
+	amount      *big.Int
+	fees        []string
+	// owners are the transfers and burn events, which the fees are charged for
+	owners []service.WebhookData
+}
+
+func New(
+	settlement config.FeeSettlement,
+	bridgeAccount string,
+	feeRepository repository.Fee,
+	statusRepository repository.Status,
+	distributor service.Distributor,
+	scheduledService service.Scheduled,
+	webhooks service.Webhooks) *Service {
+	bridgeAccountID, err := hedera.AccountIDFromString(bridgeAccount)
+	if err != nil {
+		log.Fatalf("Invalid bridge account: [%s].", bridgeAccount)
+	}
+
+	if settlement.Enabled && settlement.Interval <= 0 {
+		log.Fatalf("Invalid fee settlement interval: [%d].", settlement.Interval)
+	}
+
+	if settlement.Delay < 0 {
+		log.Fatalf("Invalid fee settlement delay: [%d].", settlement.Delay)
+	}
+
+	return &Service{
+		enabled:          settlement.Enabled,
+		interval:         int64(settlement.Interval),
+		delay:            int64(settlement.Delay),
+		bridgeAccount:    bridgeAccountID,
+		feeRepository:    feeRepository,
+		statusRepository: statusRepository,
+		distributor:      distributor,
+		scheduledService: scheduledService,
+		webhooks:         webhooks,
+		logger:           config.GetLoggerFor("Fee Settlement Service"),
+	}
+}
+
+// Enabled returns whether fees are accrued, instead of being paid out for every operation
+func (s *Service) Enabled() bool {
+	return s.enabled
+}
+
+// Batch returns the batch, in which the fee of an operation with the given timestamp (in seconds) is accrued.
+// Batches are identified by their start timestamp
+func (s *Service) Batch(timestamp int64) int64 {
+	return timestamp - timestamp%s.interval
+}
+
+// Start periodically settles the accrued fees of batches, which are due
+func (s *Service) Start() {
+	if !s.enabled {
+		return
+	}
+
+	go func() {
+		for {
+			s.settleClosed()
+			time.Sleep(time.Duration(s.interval) * time.Second)
+		}
+	}()
+	s.logger.Infof("Settling accrued fees every [%d] seconds.", s.interval)
+}
+
+// settleClosed settles the batches closed by the consensus timestamp, up to which the transfers to
+// the bridge account are processed. Unlike the local clock, it is the same for all validators
+func (s *Service) settleClosed() {
+	consensusTimestamp, err := s.statusRepository.GetLastFetchedTimestamp(s.bridgeAccount.String())
+	if err != nil {
+		s.logger.Errorf("Failed to get the last processed consensus timestamp. Error [%s].", err)
+		return
+	}
+	s.Settle(consensusTimestamp / int64(time.Second))
+}
+
+// Settle submits a scheduled transaction for every asset and batch, which has ended
+// at least `delay` seconds before the provided consensus timestamp (in seconds)
+func (s *Service) Settle(consensusTimestamp int64) {
+	batches, err := s.feeRepository.GetAccruedBatches(s.Batch(consensusTimestamp - s.delay))
+	if err != nil {
+		s.logger.Errorf("Failed to get batches with accrued fees. Error [%s].", err)
+		return
+	}
+
+	for _, fb := range batches {
+		b, err := s.load(fb)
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to get accrued fees. Error [%s].", settlementID(fb.NativeAsset, fb.Batch), err)
+			continue
+		}
+		if len(b.fees) == 0 {
+			continue
+		}
+		s.settle(b)
+	}
+}
+
+// load builds the batch from its accrued fees, ordered by transaction id, so that
+// all validators settle the same fees in the same order
+func (s *Service) load(fb repository.FeeBatch) (*batch, error) {
+	fees, err := s.feeRepository.GetAccruedInBatch(fb.NativeAsset, fb.Batch)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &batch{nativeAsset: fb.NativeAsset, start: fb.Batch, amount: big.NewInt(0)}
+	for _, f := range fees {
+		amount, err := big_numbers.ToBigInt(f.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		b.amount.Add(b.amount, amount)
+		b.fees = append(b.fees, f.TransactionID)
+		b.owners = append(b.owners, service.WebhookData{
+			TransferID:  f.TransferID.String,
+			BurnEventID: f.BurnEventID.String,
+		})
+	}
+	return b, nil
+}
+
+// settle submits the scheduled transaction paying out the batch. Its id is used as
+// memo of the scheduled transaction, so that all validators create the same schedule.
+// Batches, which are already settled, are moved to the next batch instead
+func (s *Service) settle(b *batch) {
+	id := settlementID(b.nativeAsset, b.start)
+
+	settlement, err := s.feeRepository.GetSettlement(id)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to get settlement. Error: [%s].", id, err)
+		return
+	}
+	if settlement != nil {
+		s.postpone(b)
+		return
+	}
+
+	transfers, err := s.distributor.CalculateMemberDistribution(id, b.amount)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to Distribute to Members. Error: [%s].", id, err)
+		return
+	}
+
+	amount, err := big_numbers.ToInt64(b.amount)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to settle accrued fees. Error: [%s].", id, err)
+		return
+	}
+
+	transfers = append(transfers,
+		transfer.Hedera{
+			AccountID: s.bridgeAccount,
+			Amount:    -amount,
+		})
+
+	s.logger.Infof("[%s] - Settling [%d] accrued fees with total amount [%s].", id, len(b.fees), b.amount)
+
+	onExecutionSuccess, onExecutionFail := s.scheduledTxExecutionCallbacks(id, b)
+	onSuccess, onFail := s.scheduledTxMinedCallbacks(id, b.fees, b.owners)
+
+	s.scheduledService.Execute(id, b.nativeAsset, transfers, onExecutionSuccess, onExecutionFail, onSuccess, onFail)
+}
+
+// postpone moves the fees of an already settled batch (accrued after its settlement or accrued again after
+// its settlement failed) to the first following batch, which is not settled. The settled batches are the same
+// for all validators, so that the fees are settled with the same batch by all of them
+func (s *Service) postpone(b *batch) {
+	next := b.start + s.interval
+	for {
+		settlement, err := s.feeRepository.GetSettlement(settlementID(b.nativeAsset, next))
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to get settlement. Error: [%s].", settlementID(b.nativeAsset, next), err)
+			return
+		}
+		if settlement == nil {
+			break
+		}
+		next += s.interval
+	}
+
+	err := s.feeRepository.UpdateBatch(b.fees, next)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to move [%d] fees to batch [%d]. Error: [%s].", settlementID(b.nativeAsset, b.start), len(b.fees), next, err)
+		return
+	}
+	s.logger.Infof("[%s] - Batch is already settled. Moved [%d] fees to batch [%d].", settlementID(b.nativeAsset, b.start), len(b.fees), next)
+}
+
+// settlementID returns the id of the settlement of the asset's batch
+func settlementID(nativeAsset string, batch int64) string {
+	return fmt.Sprintf("fees-%s-%d", nativeAsset, batch)
+}
+
+func (s *Service) scheduledTxExecutionCallbacks(id string, b *batch) (onExecutionSuccess func(transactionID, scheduleID string), onExecutionFail func(transactionID string)) {
+	onExecutionSuccess = func(transactionID, scheduleID string) {
+		settlement := &entity.FeeSettlement{
+			ID:            id,
+			NativeAsset:   b.nativeAsset,
+			Batch:         b.start,
+			TransactionID: transactionID,
+		}
+		err := s.feeRepository.UpdateSettlementSubmitted(b.fees, settlement, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxSubmitted})
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to update status submitted with TransactionID [%s]. Error [%s].", id, transactionID, err)
+			return
+		}
+	}
+
+	// The fees stay accrued, so that the same settlement is submitted again with the next run
+	onExecutionFail = func(transactionID string) {
+		s.logger.Errorf("[%s] - Failed to submit settlement [%s]. Retrying with the next run.", id, transactionID)
+	}
+
+	return onExecutionSuccess, onExecutionFail
+}
+
+func (s *Service) scheduledTxMinedCallbacks(id string, fees []string, owners []service.WebhookData) (onSuccess, onFail func(transactionID string)) {
+	onSuccess = func(transactionID string) {
+		s.logger.Debugf("[%s] - Scheduled TX [%s] execution successful.", id, transactionID)
+		err := s.feeRepository.UpdateSettlementCompleted(fees, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxExecuted})
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to update status completed. Error [%s].", id, err)
+			return
+		}
+		for _, owner := range owners {
+			owner.TransactionID = transactionID
+			s.webhooks.Notify(service.WebhookFeeCompleted, owner)
+		}
+	}
+
+	onFail = func(transactionID string) {
+		s.logger.Debugf("[%s] - Scheduled TX [%s] execution has failed.", id, transactionID)
+		err := s.feeRepository.UpdateSettlementAccrued(fees, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxFailed})
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to update status accrued. Error [%s].", id, err)
+			return
+		}
+	}
+
+	return onSuccess, onFail
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package settlement
+
+import (
+	"database/sql"
+	"math/big"
+	"testing"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/constants"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	s             = &Service{}
+	bridgeAccount = hedera.AccountID{Account: 111111}
+	memberAccount = hedera.AccountID{Account: 222222}
+	token         = "0.0.333333"
+)
+
+func setup() {
+	mocks.Setup()
+	s = &Service{
+		enabled:          true,
+		interval:         60,
+		delay:            30,
+		bridgeAccount:    bridgeAccount,
+		feeRepository:    mocks.MFeeRepository,
+		statusRepository: mocks.MStatusRepository,
+		distributor:      mocks.MDistributorService,
+		scheduledService: mocks.MScheduledService,
+		webhooks:         mocks.MWebhooksService,
+		logger:           config.GetLoggerFor("Fee Settlement Service"),
+	}
+}
+
+func Test_Batch(t *testing.T) {
+	setup()
+
+	assert.Equal(t, int64(120), s.Batch(120))
+	assert.Equal(t, int64(120), s.Batch(179))
+	assert.Equal(t, int64(180), s.Batch(180))
+}
+
+func Test_Settle(t *testing.T) {
+	setup()
+
+	mocks.MFeeRepository.On("GetAccruedBatches", int64(180)).Return([]repository.FeeBatch{
+		{NativeAsset: constants.Hbar, Batch: 60},
+		{NativeAsset: constants.Hbar, Batch: 120},
+		{NativeAsset: token, Batch: 120},
+	}, nil)
+	mocks.MFeeRepository.On("GetAccruedInBatch", constants.Hbar, int64(60)).Return([]*entity.Fee{
+		{TransactionID: "1", Amount: "10", NativeAsset: constants.Hbar, Batch: 60},
+		{TransactionID: "2", Amount: "15", NativeAsset: constants.Hbar, Batch: 60},
+	}, nil)
+	mocks.MFeeRepository.On("GetAccruedInBatch", constants.Hbar, int64(120)).Return([]*entity.Fee{
+		{TransactionID: "3", Amount: "20", NativeAsset: constants.Hbar, Batch: 120},
+	}, nil)
+	mocks.MFeeRepository.On("GetAccruedInBatch", token, int64(120)).Return([]*entity.Fee{
+		{TransactionID: "4", Amount: "5", NativeAsset: token, Batch: 120},
+	}, nil)
+	mocks.MFeeRepository.On("GetSettlement", mock.Anything).Return((*entity.FeeSettlement)(nil), nil)
+	expectBatch("fees-HBAR-60", constants.Hbar, 25)
+	expectBatch("fees-HBAR-120", constants.Hbar, 20)
+	expectBatch("fees-0.0.333333-120", token, 5)
+
+	s.Settle(240)
+
+	mocks.MScheduledService.AssertNumberOfCalls(t, "Execute", 3)
+}
+
+func Test_SettleNothingDue(t *testing.T) {
+	setup()
+
+	mocks.MFeeRepository.On("GetAccruedBatches", int64(60)).Return([]repository.FeeBatch{}, nil)
+
+	s.Settle(100)
+
+	mocks.MDistributorService.AssertNotCalled(t, "CalculateMemberDistribution")
+	mocks.MScheduledService.AssertNotCalled(t, "Execute")
+}
+
+func Test_SettlePostponesSettledBatch(t *testing.T) {
+	setup()
+
+	mocks.MFeeRepository.On("GetAccruedBatches", int64(180)).Return([]repository.FeeBatch{{NativeAsset: constants.Hbar, Batch: 60}}, nil)
+	mocks.MFeeRepository.On("GetAccruedInBatch", constants.Hbar, int64(60)).Return([]*entity.Fee{
+		{TransactionID: "1", Amount: "10", NativeAsset: constants.Hbar, Batch: 60},
+	}, nil)
+	mocks.MFeeRepository.On("GetSettlement", "fees-HBAR-60").Return(&entity.FeeSettlement{ID: "fees-HBAR-60"}, nil)
+	mocks.MFeeRepository.On("GetSettlement", "fees-HBAR-120").Return(&entity.FeeSettlement{ID: "fees-HBAR-120"}, nil)
+	mocks.MFeeRepository.On("GetSettlement", "fees-HBAR-180").Return((*entity.FeeSettlement)(nil), nil)
+	mocks.MFeeRepository.On("UpdateBatch", []string{"1"}, int64(180)).Return(nil)
+
+	s.Settle(240)
+
+	mocks.MFeeRepository.AssertCalled(t, "UpdateBatch", []string{"1"}, int64(180))
+	mocks.MDistributorService.AssertNotCalled(t, "CalculateMemberDistribution", mock.Anything, mock.Anything)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_SettleClosedByConsensusTimestamp(t *testing.T) {
+	setup()
+
+	mocks.MStatusRepository.On("GetLastFetchedTimestamp", bridgeAccount.String()).Return(int64(240000000001), nil)
+	mocks.MFeeRepository.On("GetAccruedBatches", int64(180)).Return([]repository.FeeBatch{}, nil)
+
+	s.settleClosed()
+
+	mocks.MFeeRepository.AssertCalled(t, "GetAccruedBatches", int64(180))
+}
+
+func Test_Load(t *testing.T) {
+	setup()
+
+	mocks.MFeeRepository.On("GetAccruedInBatch", constants.Hbar, int64(60)).Return([]*entity.Fee{
+		{TransactionID: "1", Amount: "10", NativeAsset: constants.Hbar, Batch: 60, TransferID: sql.NullString{String: "1", Valid: true}},
+		{TransactionID: "2", Amount: "15", NativeAsset: constants.Hbar, Batch: 60, BurnEventID: sql.NullString{String: "2", Valid: true}},
+	}, nil)
+
+	b, err := s.load(repository.FeeBatch{NativeAsset: constants.Hbar, Batch: 60})
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(60), b.start)
+	assert.Equal(t, []string{"1", "2"}, b.fees)
+	assert.Equal(t, []service.WebhookData{{TransferID: "1"}, {BurnEventID: "2"}}, b.owners)
+	assert.Equal(t, big.NewInt(25), b.amount)
+}
+
+func Test_ScheduledTxCallbacks(t *testing.T) {
+	setup()
+
+	ids := []string{"1", "2"}
+	owners := []service.WebhookData{{TransferID: "1"}, {BurnEventID: "2"}}
+	settlement := &entity.FeeSettlement{ID: "fees-HBAR-60", NativeAsset: constants.Hbar, Batch: 60, TransactionID: "0.0.1-1-1"}
+	mocks.MFeeRepository.On("UpdateSettlementSubmitted", ids, settlement, mock.Anything).Return(nil)
+	mocks.MFeeRepository.On("UpdateSettlementCompleted", ids, mock.Anything).Return(nil)
+	mocks.MWebhooksService.On("Notify", service.WebhookFeeCompleted, mock.Anything).Return()
+
+	onExecutionSuccess, _ := s.scheduledTxExecutionCallbacks("fees-HBAR-60", &batch{nativeAsset: constants.Hbar, start: 60, fees: ids})
+	onSuccess, _ := s.scheduledTxMinedCallbacks("fees-HBAR-60", ids, owners)
+	onExecutionSuccess("0.0.1-1-1", "0.0.2")
+	onSuccess("0.0.1-1-1")
+
+	mocks.MFeeRepository.AssertExpectations(t)
+	mocks.MWebhooksService.AssertCalled(t, "Notify", service.WebhookFeeCompleted, service.WebhookData{TransferID: "1", TransactionID: "0.0.1-1-1"})
+	mocks.MWebhooksService.AssertCalled(t, "Notify", service.WebhookFeeCompleted, service.WebhookData{BurnEventID: "2", TransactionID: "0.0.1-1-1"})
+}
+
+func Test_ScheduledTxFailedCallbacks(t *testing.T) {
+	setup()
+
+	ids := []string{"1", "2"}
+	mocks.MFeeRepository.On("UpdateSettlementAccrued", ids, mock.Anything).Return(nil)
+
+	_, onExecutionFail := s.scheduledTxExecutionCallbacks("fees-HBAR-60", &batch{nativeAsset: constants.Hbar, start: 60, fees: ids})
+	_, onFail := s.scheduledTxMinedCallbacks("fees-HBAR-60", ids, nil)
+	onExecutionFail("0.0.1-1-1")
+	onFail("0.0.1-1-1")
+
+	mocks.MFeeRepository.AssertNumberOfCalls(t, "UpdateSettlementAccrued", 1)
+	mocks.MFeeRepository.AssertNotCalled(t, "UpdateSettlementSubmitted", mock.Anything, mock.Anything, mock.Anything)
+	mocks.MWebhooksService.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
+
+func expectBatch(id, asset string, amount int64) {
+	distribution := []transfer.Hedera{{AccountID: memberAccount, Amount: amount}}
+	mocks.MDistributorService.On("CalculateMemberDistribution", id, big.NewInt(amount)).Return(distribution, nil)
+	mocks.MScheduledService.On("Execute", id, asset, append(distribution, transfer.Hedera{AccountID: bridgeAccount, Amount: -amount})).Return()
+}
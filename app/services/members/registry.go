@@ -0,0 +1,152 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package members
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	ethhelper "github.com/limechain/hedera-eth-bridge-validator/app/helper/ethereum"
+	"github.com/limechain/hedera-eth-bridge-validator/app/helper/file"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// Registry maps the bridge members, tracked live by the Contracts Service, to their Hedera fee accounts
+type Registry struct {
+	contracts service.Contracts
+	static    []hedera.AccountID
+	mutex     sync.RWMutex
+	accounts  map[string]hedera.AccountID
+	logger    *log.Entry
+}
+
+// New creates a member registry. If a registry file is provided, the Hedera fee accounts are
+// taken from the signed entries in it. Otherwise, the static list of members accounts is used
+func New(contracts service.Contracts, registry config.MemberRegistry, members []string) *Registry {
+	r := &Registry{
+		contracts: contracts,
+		logger:    config.GetLoggerFor("Member Registry"),
+	}
+
+	if registry.File == "" {
+		if len(members) == 0 {
+			log.Fatal("No members accounts provided")
+		}
+
+		for _, v := range members {
+			accountID, err := hedera.AccountIDFromString(v)
+			if err != nil {
+				log.Fatalf("Invalid members account: [%s].", v)
+			}
+			r.static = append(r.static, accountID)
+		}
+		return r
+	}
+
+	registered, err := config.LoadMemberRegistry(registry.File)
+	if err != nil {
+		log.Fatalf("Failed to load member registry file [%s]. Error: [%s]", registry.File, err)
+	}
+
+	err = r.Reload(registered)
+	if err != nil {
+		log.Fatalf("Invalid member registry. Error: [%s]", err)
+	}
+
+	if registry.ReloadInterval > 0 {
+		go file.Watch(registry.File, "member registry", registry.ReloadInterval, r.logger, func() error {
+			registered, err := config.LoadMemberRegistry(registry.File)
+			if err != nil {
+				return err
+			}
+			return r.Reload(registered)
+		})
+	}
+
+	return r
+}
+
+// Signers returns the Ethereum addresses of the bridge members currently set in the Bridge contract
+func (r *Registry) Signers() []string {
+	return r.contracts.GetMembers()
+}
+
+// Accounts returns the Hedera fee accounts of the current bridge members, in the order
+// of the members in the Bridge contract. Members without a registered account are skipped
+func (r *Registry) Accounts() []hedera.AccountID {
+	if r.static != nil {
+		return r.static
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []hedera.AccountID
+	for _, member := range r.contracts.GetMembers() {
+		account, ok := r.accounts[strings.ToLower(member)]
+		if !ok {
+			r.logger.Warnf("Member [%s] has no registered Hedera account.", member)
+			continue
+		}
+		result = append(result, account)
+	}
+	return result
+}
+
+// Reload verifies the signatures of the provided members and replaces the currently registered accounts
+func (r *Registry) Reload(registered []config.RegisteredMember) error {
+	accounts := make(map[string]hedera.AccountID)
+	for _, m := range registered {
+		if !common.IsHexAddress(m.Address) {
+			return errors.New(fmt.Sprintf("invalid member address [%s]", m.Address))
+		}
+
+		account, err := hedera.AccountIDFromString(m.Account)
+		if err != nil {
+			return errors.New(fmt.Sprintf("invalid account [%s] for member [%s]", m.Account, m.Address))
+		}
+
+		signature, _, err := ethhelper.DecodeSignature(strings.TrimPrefix(m.Signature, "0x"))
+		if err != nil {
+			return errors.New(fmt.Sprintf("invalid signature for member [%s]. Error: [%s]", m.Address, err))
+		}
+
+		signer, err := ethhelper.RecoverSignerFromBytes(Hash(m.Address, account.String()), signature)
+		if err != nil || !strings.EqualFold(signer, m.Address) {
+			return errors.New(fmt.Sprintf("account [%s] is not signed by member [%s]", m.Account, m.Address))
+		}
+
+		accounts[strings.ToLower(m.Address)] = account
+	}
+
+	r.mutex.Lock()
+	r.accounts = accounts
+	r.mutex.Unlock()
+	return nil
+}
+
+// Hash returns the hash, which a member signs with its Ethereum key to register its Hedera fee account
+func Hash(address, account string) []byte {
+	return accounts.TextHash([]byte(fmt.Sprintf("%s:%s", strings.ToLower(address), account)))
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package members
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func register(t *testing.T, account string) (string, config.RegisteredMember) {
+	key, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey).String()
+
+	signature, err := crypto.Sign(Hash(address, account), key)
+	assert.Nil(t, err)
+
+	return address, config.RegisteredMember{
+		Address:   address,
+		Account:   account,
+		Signature: hex.EncodeToString(signature),
+	}
+}
+
+func newRegistry() *Registry {
+	mocks.Setup()
+	return &Registry{
+		contracts: mocks.MBridgeContractService,
+		logger:    config.GetLoggerFor("Member Registry"),
+	}
+}
+
+func Test_Accounts(t *testing.T) {
+	r := newRegistry()
+	first, firstMember := register(t, "0.0.1")
+	second, secondMember := register(t, "0.0.2")
+	unregistered, _ := register(t, "0.0.3")
+
+	err := r.Reload([]config.RegisteredMember{firstMember, secondMember})
+	assert.Nil(t, err)
+
+	mocks.MBridgeContractService.On("GetMembers").Return([]string{second, unregistered, first})
+
+	assert.Equal(t, []hedera.AccountID{{Account: 2}, {Account: 1}}, r.Accounts())
+}
+
+func Test_ReloadInvalidSignature(t *testing.T) {
+	r := newRegistry()
+	_, member := register(t, "0.0.1")
+	member.Account = "0.0.2"
+
+	err := r.Reload([]config.RegisteredMember{member})
+
+	assert.Error(t, err)
+}
+
+func Test_ReloadKeepsRegistryOnError(t *testing.T) {
+	r := newRegistry()
+	address, member := register(t, "0.0.1")
+	err := r.Reload([]config.RegisteredMember{member})
+	assert.Nil(t, err)
+
+	err = r.Reload([]config.RegisteredMember{{Address: "invalid", Account: "0.0.2"}})
+	assert.Error(t, err)
+
+	mocks.MBridgeContractService.On("GetMembers").Return([]string{address})
+	assert.Equal(t, []hedera.AccountID{{Account: 1}}, r.Accounts())
+}
+
+func Test_StaticMembers(t *testing.T) {
+	r := New(nil, config.MemberRegistry{}, []string{"0.0.1", "0.0.2"})
+
+	assert.Equal(t, []hedera.AccountID{{Account: 1}, {Account: 2}}, r.Accounts())
+}
@@ -20,8 +20,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"strconv"
-	"time"
+	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -29,24 +29,48 @@ import (
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/client"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	big_numbers "github.com/limechain/hedera-eth-bridge-validator/app/helper/big-numbers"
 	ethhelper "github.com/limechain/hedera-eth-bridge-validator/app/helper/ethereum"
 	auth_message "github.com/limechain/hedera-eth-bridge-validator/app/model/auth-message"
 	"github.com/limechain/hedera-eth-bridge-validator/app/model/message"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	rejected_message "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/rejected-message"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	log "github.com/sirupsen/logrus"
 )
 
+var errNonMember = errors.New("signer is not signatures member")
+
+var rejectedMessages = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "validator_rejected_signature_messages_total",
+		Help: "The number of rejected signature messages by reason and recovered signer.",
+	},
+	[]string{"reason", "signer"})
+
+var equivocations = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "validator_equivocations_total",
+		Help: "The number of conflicting signatures by Bridge members for the same transfer.",
+	},
+	[]string{"signer"})
+
 type Service struct {
-	ethSigner          service.Signer
-	contractsService   service.Contracts
-	transferRepository repository.Transfer
-	messageRepository  repository.Message
-	topicID            hedera.TopicID
-	hederaClient       client.HederaNode
-	mirrorClient       client.MirrorNode
-	ethClient          client.Ethereum
-	logger             *log.Entry
+	ethSigner                 service.Signer
+	contractsService          service.Contracts
+	transferRepository        repository.Transfer
+	messageRepository         repository.Message
+	rejectedMessageRepository repository.RejectedMessage
+	equivocationRepository    repository.Equivocation
+	unitOfWork                repository.UnitOfWork
+	decimals                  service.Decimals
+	topicID                   hedera.TopicID
+	hederaClient              client.HederaNode
+	mirrorClient              client.MirrorNode
+	ethClient                 client.Ethereum
+	logger                    *log.Entry
 }
 
 func NewService(
@@ -54,6 +78,10 @@ func NewService(
 	contractsService service.Contracts,
 	transferRepository repository.Transfer,
 	messageRepository repository.Message,
+	rejectedMessageRepository repository.RejectedMessage,
+	equivocationRepository repository.Equivocation,
+	unitOfWork repository.UnitOfWork,
+	decimals service.Decimals,
 	hederaClient client.HederaNode,
 	mirrorClient client.MirrorNode,
 	ethClient client.Ethereum,
@@ -65,40 +93,48 @@ func NewService(
 	}
 
 	return &Service{
-		ethSigner:          ethSigner,
-		contractsService:   contractsService,
-		messageRepository:  messageRepository,
-		transferRepository: transferRepository,
-		logger:             config.GetLoggerFor(fmt.Sprintf("Messages Service")),
-		topicID:            tID,
-		hederaClient:       hederaClient,
-		mirrorClient:       mirrorClient,
-		ethClient:          ethClient,
+		ethSigner:                 ethSigner,
+		contractsService:          contractsService,
+		messageRepository:         messageRepository,
+		rejectedMessageRepository: rejectedMessageRepository,
+		equivocationRepository:    equivocationRepository,
+		unitOfWork:                unitOfWork,
+		decimals:                  decimals,
+		transferRepository:        transferRepository,
+		logger:                    config.GetLoggerFor(fmt.Sprintf("Messages Service")),
+		topicID:                   tID,
+		hederaClient:              hederaClient,
+		mirrorClient:              mirrorClient,
+		ethClient:                 ethClient,
 	}
 }
 
 // SanityCheckSignature performs validation on the topic message metadata.
 // Validates it against the Transaction Record metadata from DB
 func (ss *Service) SanityCheckSignature(topicMessage message.Message) (bool, error) {
-	// In case a topic message for given transfer is being processed before the actual transfer
-	t, err := ss.awaitTransfer(topicMessage.TransferID)
+	t, err := ss.transferRepository.GetWithFee(topicMessage.TransferID)
 	if err != nil {
-		ss.logger.Errorf("[%s] - Failed to await incoming transfer. Error: [%s]", topicMessage.TransferID, err)
+		ss.logger.Errorf("[%s] - Failed to retrieve Transaction Record. Error: [%s]", topicMessage.TransferID, err)
 		return false, err
 	}
 
-	amount, err := strconv.ParseInt(t.Amount, 10, 64)
+	// In case a topic message for given transfer is being processed before the actual transfer
+	if t == nil || t.Fee.TransactionID == "" {
+		ss.logger.Debugf("[%s] - Transfer not yet processed.", topicMessage.TransferID)
+		return false, service.ErrNotFound
+	}
+
+	amount, err := big_numbers.ToBigInt(t.Amount)
 	if err != nil {
 		ss.logger.Errorf("[%s] - Failed to parse transfer amount. Error [%s]", topicMessage.TransferID, err)
 		return false, err
 	}
 
-	feeAmount, err := strconv.ParseInt(t.Fee.Amount, 10, 64)
+	feeAmount, err := big_numbers.ToBigInt(t.Fee.Amount)
 	if err != nil {
 		ss.logger.Errorf("[%s] - Failed to parse fee amount. Error [%s]", topicMessage.TransferID, err)
 		return false, err
 	}
-	signedAmount := strconv.FormatInt(amount-feeAmount, 10)
 
 	wrappedAsset, err := ss.contractsService.ToWrapped(t.NativeAsset)
 	if err != nil {
@@ -106,10 +142,29 @@ func (ss *Service) SanityCheckSignature(topicMessage message.Message) (bool, err
 		return false, err
 	}
 
+	signedAmount, _, err := ss.decimals.ToWrapped(t.NativeAsset, wrappedAsset, new(big.Int).Sub(amount, feeAmount))
+	if err != nil {
+		ss.logger.Errorf("[%s] - Failed to convert amount to wrapped decimals. Error [%s]", topicMessage.TransferID, err)
+		return false, err
+	}
+
 	match := topicMessage.Receiver == t.Receiver &&
 		topicMessage.RouterAddress == t.RouterAddress &&
-		topicMessage.Amount == signedAmount &&
+		topicMessage.Amount == signedAmount.String() &&
 		topicMessage.WrappedAsset == wrappedAsset
+	if !match {
+		signer, hash := ss.recoverSigner(topicMessage)
+		if signer != "" && ss.contractsService.IsMember(signer) {
+			err = ss.unitOfWork.Do(func(tx repository.Transaction) error {
+				_, err := ss.detectEquivocation(tx, topicMessage, signer, hash)
+				return err
+			})
+			if err != nil {
+				return false, err
+			}
+		}
+		ss.reject(topicMessage, rejected_message.ReasonSanityMismatch, signer)
+	}
 	return match, nil
 }
 
@@ -119,6 +174,7 @@ func (ss *Service) ProcessSignature(tsm message.Message) error {
 	authMsgBytes, err := auth_message.EncodeBytesFrom(tsm.TransferID, tsm.RouterAddress, tsm.WrappedAsset, tsm.Receiver, tsm.Amount)
 	if err != nil {
 		ss.logger.Errorf("[%s] - Failed to encode the authorisation signature. Error: [%s]", tsm.TransferID, err)
+		ss.reject(tsm, rejected_message.ReasonDecodeFailure, "")
 		return err
 	}
 
@@ -126,47 +182,80 @@ func (ss *Service) ProcessSignature(tsm message.Message) error {
 	signatureBytes, signatureHex, err := ethhelper.DecodeSignature(tsm.GetSignature())
 	if err != nil {
 		ss.logger.Errorf("[%s] - Decoding Signature [%s] for TX failed. Error: [%s]", tsm.TransferID, tsm.GetSignature(), err)
+		ss.reject(tsm, rejected_message.ReasonDecodeFailure, "")
 		return err
 	}
 	authMessageStr := hex.EncodeToString(authMsgBytes)
 
-	// Check for duplicated signature
-	exists, err := ss.messageRepository.Exist(tsm.TransferID, signatureHex, authMessageStr)
-	if err != nil {
-		ss.logger.Errorf("[%s] - An error occurred while checking existence from DB. Error: [%s]", tsm.TransferID, err)
-		return err
-	}
-	if exists {
-		ss.logger.Errorf("[%s] - Signature already received", tsm.TransferID)
-		return err
-	}
-
 	// Verify Signature
-	address, err := ss.verifySignature(err, authMsgBytes, signatureBytes, tsm.TransferID, authMessageStr)
+	address, err := ss.verifySignature(authMsgBytes, signatureBytes, tsm.TransferID, authMessageStr)
 	if err != nil {
+		if errors.Is(err, errNonMember) {
+			ss.reject(tsm, rejected_message.ReasonNonMember, address.String())
+		} else {
+			ss.reject(tsm, rejected_message.ReasonDecodeFailure, "")
+		}
 		return err
 	}
 
 	ss.logger.Debugf("[%s] - Successfully verified new Signature from [%s]", tsm.TransferID, address.String())
 
-	// Persist in DB
-	err = ss.messageRepository.Create(&entity.Message{
-		TransferID:           tsm.TransferID,
-		Signature:            signatureHex,
-		Hash:                 authMessageStr,
-		Signer:               address.String(),
-		TransactionTimestamp: tsm.TransactionTimestamp,
+	// The duplicate and equivocation checks are performed in the same transaction as the persistence of the message
+	duplicate, equivocated := false, false
+	err = ss.unitOfWork.Do(func(tx repository.Transaction) error {
+		exists, err := tx.Messages().Exist(tsm.TransferID, signatureHex, authMessageStr)
+		if err != nil {
+			ss.logger.Errorf("[%s] - An error occurred while checking existence from DB. Error: [%s]", tsm.TransferID, err)
+			return err
+		}
+		if exists {
+			duplicate = true
+			return nil
+		}
+
+		// Only a single signature of a member is accepted for a transfer
+		equivocated, err = ss.detectEquivocation(tx, tsm, address.String(), authMessageStr)
+		if err != nil || equivocated {
+			return err
+		}
+
+		err = tx.Messages().Create(&entity.Message{
+			TransferID:           tsm.TransferID,
+			Signature:            signatureHex,
+			Hash:                 authMessageStr,
+			Signer:               address.String(),
+			TransactionTimestamp: tsm.TransactionTimestamp,
+		})
+		// The message was persisted by a concurrent handler
+		if errors.Is(err, repository.ErrDuplicate) {
+			duplicate = true
+			return nil
+		}
+		if err != nil {
+			ss.logger.Errorf("[%s] - Failed to save Transaction Message in DB with Signature [%s]. Error: [%s]", tsm.TransferID, signatureHex, err)
+		}
+		return err
 	})
 	if err != nil {
-		ss.logger.Errorf("[%s] - Failed to save Transaction Message in DB with Signature [%s]. Error: [%s]", tsm.TransferID, signatureHex, err)
 		return err
 	}
+	if duplicate {
+		ss.logger.Errorf("[%s] - Signature already received", tsm.TransferID)
+		ss.reject(tsm, rejected_message.ReasonDuplicate, address.String())
+		return nil
+	}
+	if equivocated {
+		ss.reject(tsm, rejected_message.ReasonEquivocation, address.String())
+		return errors.New(fmt.Sprintf("signer [%s] already signed different data", address.String()))
+	}
 
 	ss.logger.Infof("[%s] - Successfully processed Signature Message from [%s]", tsm.TransferID, address.String())
 	return nil
 }
 
-func (ss *Service) verifySignature(err error, authMsgBytes []byte, signatureBytes []byte, transferID, authMessageStr string) (common.Address, error) {
+// verifySignature recovers the signer of the signature. In case the signer is not a Bridge member,
+// it is returned together with errNonMember
+func (ss *Service) verifySignature(authMsgBytes []byte, signatureBytes []byte, transferID, authMessageStr string) (common.Address, error) {
 	publicKey, err := crypto.Ecrecover(authMsgBytes, signatureBytes)
 	if err != nil {
 		ss.logger.Errorf("[%s] - Failed to recover public key. Hash [%s]. Error: [%s]", transferID, authMessageStr, err)
@@ -180,24 +269,196 @@ func (ss *Service) verifySignature(err error, authMsgBytes []byte, signatureByte
 	address := crypto.PubkeyToAddress(*unmarshalledPublicKey)
 	if !ss.contractsService.IsMember(address.String()) {
 		ss.logger.Errorf("[%s] - Received Signature [%s] is not signed by Bridge member", transferID, authMessageStr)
-		return common.Address{}, errors.New(fmt.Sprintf("signer is not signatures member"))
+		return address, errNonMember
 	}
 	return address, nil
 }
 
-// awaitTransfer checks until given transfer is found
-func (ss *Service) awaitTransfer(transferID string) (*entity.Transfer, error) {
-	for {
-		t, err := ss.transferRepository.GetWithFee(transferID)
+// RejectedMessages returns up to `limit` rejected signature messages with consensus timestamp after `after`,
+// filtered by transfer and signer, unless they are empty
+func (ss *Service) RejectedMessages(transferID, signer string, after int64, limit int) ([]service.RejectedMessage, error) {
+	messages, err := ss.rejectedMessageRepository.GetPage(transferID, signer, after, limit)
+	if err != nil {
+		ss.logger.Errorf("Failed to query rejected Signature Messages. Error: [%s]", err)
+		return nil, err
+	}
+
+	result := make([]service.RejectedMessage, len(messages))
+	for i, m := range messages {
+		result[i] = service.RejectedMessage{
+			TransferID:           m.TransferID,
+			Signature:            m.Signature,
+			Signer:               m.Signer,
+			Reason:               m.Reason,
+			RouterAddress:        m.RouterAddress,
+			Receiver:             m.Receiver,
+			Amount:               m.Amount,
+			WrappedAsset:         m.WrappedAsset,
+			TransactionTimestamp: m.TransactionTimestamp,
+		}
+	}
+	return result, nil
+}
+
+// Equivocations returns the conflicting signatures of members, filtered by transfer and signer, unless they are empty
+func (ss *Service) Equivocations(transferID, signer string) ([]service.Equivocation, error) {
+	records, err := ss.equivocationRepository.Get(transferID, signer)
+	if err != nil {
+		ss.logger.Errorf("Failed to query Equivocations. Error: [%s]", err)
+		return nil, err
+	}
+
+	result := make([]service.Equivocation, len(records))
+	for i, e := range records {
+		result[i] = service.Equivocation{
+			TransferID: e.TransferID,
+			Signer:     e.Signer,
+			First: service.SignedMessage{
+				Signature:            e.FirstSignature,
+				Hash:                 e.FirstHash,
+				TransactionTimestamp: e.FirstTimestamp,
+			},
+			Second: service.SignedMessage{
+				Signature:            e.SecondSignature,
+				Hash:                 e.SecondHash,
+				TransactionTimestamp: e.SecondTimestamp,
+			},
+		}
+	}
+	return result, nil
+}
+
+// recoverSigner returns the signer of the message and the hash of the signed data.
+// Returns empty signer, if it cannot be recovered
+func (ss *Service) recoverSigner(tsm message.Message) (signer, hash string) {
+	authMsgBytes, err := auth_message.EncodeBytesFrom(tsm.TransferID, tsm.RouterAddress, tsm.WrappedAsset, tsm.Receiver, tsm.Amount)
+	if err != nil {
+		return "", ""
+	}
+
+	signer, _, err = ethhelper.RecoverSignerFromStr(tsm.GetSignature(), authMsgBytes)
+	if err != nil {
+		return "", ""
+	}
+	return signer, hex.EncodeToString(authMsgBytes)
+}
+
+// detectEquivocation records an equivocation for every previous message of the signer for the same transfer,
+// which has a different hash. Returns whether the signer has an accepted signature with a different hash
+func (ss *Service) detectEquivocation(tx repository.Transaction, tsm message.Message, signer, hash string) (bool, error) {
+	accepted, err := tx.Messages().Get(tsm.TransferID)
+	if err != nil {
+		ss.logger.Errorf("[%s] - Failed to query all Signature Messages. Error: [%s]", tsm.TransferID, err)
+		return false, err
+	}
+
+	rejected, err := tx.RejectedMessages().Get(tsm.TransferID, signer)
+	if err != nil {
+		ss.logger.Errorf("[%s] - Failed to query rejected Signature Messages. Error: [%s]", tsm.TransferID, err)
+		return false, err
+	}
+
+	conflicting := false
+	for _, m := range accepted {
+		if strings.EqualFold(m.Signer, signer) && m.Hash != hash {
+			conflicting = true
+			ss.recordEquivocation(tx.Equivocations(), tsm, signer, hash, m.Signature, m.Hash, m.TransactionTimestamp)
+		}
+	}
+
+	for _, m := range rejected {
+		// Only signed data, which does not match the transfer, conflicts with other signatures
+		if m.Reason != rejected_message.ReasonSanityMismatch {
+			continue
+		}
+		authMsgBytes, err := auth_message.EncodeBytesFrom(m.TransferID, m.RouterAddress, m.WrappedAsset, m.Receiver, m.Amount)
 		if err != nil {
-			ss.logger.Errorf("[%s] - Failed to retrieve Transaction Record. Error: [%s]", transferID, err)
-			return nil, err
+			continue
 		}
+		if rejectedHash := hex.EncodeToString(authMsgBytes); rejectedHash != hash {
+			ss.recordEquivocation(tx.Equivocations(), tsm, signer, hash, m.Signature, rejectedHash, m.TransactionTimestamp)
+		}
+	}
+
+	return conflicting, nil
+}
+
+func (ss *Service) recordEquivocation(equivocationRepository repository.Equivocation, tsm message.Message, signer, hash, firstSignature, firstHash string, firstTimestamp int64) {
+	equivocations.WithLabelValues(signer).Inc()
+	ss.logger.Errorf("[%s] - Equivocation detected! Member [%s] signed conflicting data [%s] at [%d] and [%s] at [%d].",
+		tsm.TransferID, signer, firstHash, firstTimestamp, hash, tsm.TransactionTimestamp)
+
+	err := equivocationRepository.Create(&entity.Equivocation{
+		TransferID:      tsm.TransferID,
+		Signer:          signer,
+		FirstSignature:  firstSignature,
+		FirstHash:       firstHash,
+		FirstTimestamp:  firstTimestamp,
+		SecondSignature: tsm.GetSignature(),
+		SecondHash:      hash,
+		SecondTimestamp: tsm.TransactionTimestamp,
+	})
+	if err != nil {
+		ss.logger.Errorf("[%s] - Failed to save Equivocation of [%s]. Error: [%s]", tsm.TransferID, signer, err)
+	}
+}
+
+// Processed returns whether the signature message was already accepted or rejected
+func (ss *Service) Processed(tsm message.Message) (bool, error) {
+	// Messages, which cannot be decoded, can only be rejected
+	authMsgBytes, err := auth_message.EncodeBytesFrom(tsm.TransferID, tsm.RouterAddress, tsm.WrappedAsset, tsm.Receiver, tsm.Amount)
+	if err != nil {
+		return ss.rejected(tsm)
+	}
+	_, signatureHex, err := ethhelper.DecodeSignature(tsm.GetSignature())
+	if err != nil {
+		return ss.rejected(tsm)
+	}
+
+	exists, err := ss.messageRepository.Exist(tsm.TransferID, signatureHex, hex.EncodeToString(authMsgBytes))
+	if err != nil || exists {
+		return exists, err
+	}
+	return ss.rejected(tsm)
+}
 
-		if t != nil && t.Fee.TransactionID != "" {
-			return t, nil
+// rejected returns whether the signature message, with its consensus timestamp, was rejected
+func (ss *Service) rejected(tsm message.Message) (bool, error) {
+	rejected, err := ss.rejectedMessageRepository.Get(tsm.TransferID, "")
+	if err != nil {
+		return false, err
+	}
+	for _, r := range rejected {
+		if r.Signature == tsm.GetSignature() && r.TransactionTimestamp == tsm.TransactionTimestamp {
+			return true, nil
 		}
-		ss.logger.Debugf("[%s] - Transfer not yet added. Querying after 5 seconds", transferID)
-		time.Sleep(5 * time.Second)
 	}
+	return false, nil
+}
+
+// reject persists the rejected signature message, so that misbehaving validators can be detected
+func (ss *Service) reject(tsm message.Message, reason, signer string) {
+	label := "unknown"
+	if reason != rejected_message.ReasonNonMember && signer != "" && ss.contractsService.IsMember(signer) {
+		// Only members are labeled individually, as anyone could submit to the topic
+		label = signer
+	}
+	rejectedMessages.WithLabelValues(reason, label).Inc()
+
+	err := ss.rejectedMessageRepository.Create(&entity.RejectedMessage{
+		TransferID:           tsm.TransferID,
+		Signature:            tsm.GetSignature(),
+		Signer:               signer,
+		Reason:               reason,
+		RouterAddress:        tsm.RouterAddress,
+		Receiver:             tsm.Receiver,
+		Amount:               tsm.Amount,
+		WrappedAsset:         tsm.WrappedAsset,
+		TransactionTimestamp: tsm.TransactionTimestamp,
+	})
+	if err != nil {
+		ss.logger.Errorf("[%s] - Failed to save rejected Signature Message [%s]. Error: [%s]", tsm.TransferID, tsm.GetSignature(), err)
+		return
+	}
+	ss.logger.Warnf("[%s] - Rejected Signature Message from [%s]. Reason: [%s]", tsm.TransferID, signer, reason)
 }
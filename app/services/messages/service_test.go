@@ -0,0 +1,334 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package messages
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	auth_message "github.com/limechain/hedera-eth-bridge-validator/app/model/auth-message"
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/message"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	rejected_message "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/rejected-message"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/constants"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	s             = &Service{}
+	transferID    = "0.0.123456-1620000000-000000000"
+	routerAddress = "0x0000000000000000000000000000000000000001"
+	wrappedAsset  = "0x0000000000000000000000000000000000000002"
+	receiver      = "0x0000000000000000000000000000000000000003"
+)
+
+func setup() {
+	mocks.Setup()
+	s = &Service{
+		contractsService:          mocks.MBridgeContractService,
+		transferRepository:        mocks.MTransferRepository,
+		messageRepository:         mocks.MMessageRepository,
+		rejectedMessageRepository: mocks.MRejectedMessageRepository,
+		equivocationRepository:    mocks.MEquivocationRepository,
+		unitOfWork:                mocks.MUnitOfWork,
+		decimals:                  mocks.MDecimalsService,
+		logger:                    config.GetLoggerFor("Messages Service"),
+	}
+}
+
+// signedMessage returns a signature message for the given amount, signed with a new key, and the signer address
+func signedMessage(t *testing.T, amount string) (*message.Message, string) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signedMessageWith(t, key, amount), crypto.PubkeyToAddress(key.PublicKey).String()
+}
+
+// signedMessageWith returns a signature message for the given amount, signed with the provided key
+func signedMessageWith(t *testing.T, key *ecdsa.PrivateKey, amount string) *message.Message {
+	authMsgBytes, err := auth_message.EncodeBytesFrom(transferID, routerAddress, wrappedAsset, receiver, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature, err := crypto.Sign(authMsgBytes, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tm := message.NewSignature(transferID, routerAddress, receiver, amount, hex.EncodeToString(signature), wrappedAsset)
+	tm.TransactionTimestamp = 10
+	return tm
+}
+
+// hashOf returns the hash of the authorisation data for the given amount
+func hashOf(t *testing.T, amount string) string {
+	authMsgBytes, err := auth_message.EncodeBytesFrom(transferID, routerAddress, wrappedAsset, receiver, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(authMsgBytes)
+}
+
+func expectTransfer() {
+	mocks.MTransferRepository.On("GetWithFee", transferID).Return(&entity.Transfer{
+		TransactionID: transferID,
+		Receiver:      receiver,
+		RouterAddress: routerAddress,
+		Amount:        "100",
+		NativeAsset:   constants.Hbar,
+		Fee:           entity.Fee{TransactionID: transferID, Amount: "10"},
+	}, nil)
+	mocks.MBridgeContractService.On("ToWrapped", constants.Hbar).Return(wrappedAsset, nil)
+	mocks.MDecimalsService.On("ToWrapped", constants.Hbar, wrappedAsset, big.NewInt(90)).Return(big.NewInt(90), big.NewInt(0), nil)
+}
+
+func Test_SanityCheckSignatureRejectsMismatch(t *testing.T) {
+	setup()
+
+	tm, signer := signedMessage(t, "1000")
+	expectTransfer()
+	mocks.MBridgeContractService.On("IsMember", signer).Return(true)
+	mocks.MMessageRepository.On("Get", transferID).Return([]entity.Message{}, nil)
+	mocks.MRejectedMessageRepository.On("Get", transferID, signer).Return([]entity.RejectedMessage{}, nil)
+	mocks.MRejectedMessageRepository.On("Create", mock.Anything).Return(nil)
+
+	valid, err := s.SanityCheckSignature(*tm)
+
+	assert.Nil(t, err)
+	assert.False(t, valid)
+	rejected := mocks.MRejectedMessageRepository.Calls[1].Arguments.Get(0).(*entity.RejectedMessage)
+	assert.Equal(t, rejected_message.ReasonSanityMismatch, rejected.Reason)
+	assert.Equal(t, signer, rejected.Signer)
+	assert.Equal(t, "1000", rejected.Amount)
+	assert.Equal(t, int64(10), rejected.TransactionTimestamp)
+}
+
+func Test_SanityCheckSignatureMismatchOfNonMemberIsNotLabeled(t *testing.T) {
+	setup()
+
+	tm, signer := signedMessage(t, "1000")
+	expectTransfer()
+	mocks.MBridgeContractService.On("IsMember", signer).Return(false)
+	mocks.MRejectedMessageRepository.On("Create", mock.Anything).Return(nil)
+	before := testutil.ToFloat64(rejectedMessages.WithLabelValues(rejected_message.ReasonSanityMismatch, "unknown"))
+
+	valid, err := s.SanityCheckSignature(*tm)
+
+	assert.Nil(t, err)
+	assert.False(t, valid)
+	assert.Equal(t, before+1, testutil.ToFloat64(rejectedMessages.WithLabelValues(rejected_message.ReasonSanityMismatch, "unknown")))
+	assert.Zero(t, testutil.ToFloat64(rejectedMessages.WithLabelValues(rejected_message.ReasonSanityMismatch, signer)))
+}
+
+func Test_SanityCheckSignatureMatches(t *testing.T) {
+	setup()
+
+	tm, _ := signedMessage(t, "90")
+	expectTransfer()
+
+	valid, err := s.SanityCheckSignature(*tm)
+
+	assert.Nil(t, err)
+	assert.True(t, valid)
+	mocks.MRejectedMessageRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_SanityCheckSignatureMatchesWrappedDecimals(t *testing.T) {
+	setup()
+
+	wrappedAmount, _ := new(big.Int).SetString("900000000000", 10)
+	tm, _ := signedMessage(t, wrappedAmount.String())
+	mocks.MTransferRepository.On("GetWithFee", transferID).Return(&entity.Transfer{
+		TransactionID: transferID,
+		Receiver:      receiver,
+		RouterAddress: routerAddress,
+		Amount:        "100",
+		NativeAsset:   constants.Hbar,
+		Fee:           entity.Fee{TransactionID: transferID, Amount: "10"},
+	}, nil)
+	mocks.MBridgeContractService.On("ToWrapped", constants.Hbar).Return(wrappedAsset, nil)
+	mocks.MDecimalsService.On("ToWrapped", constants.Hbar, wrappedAsset, big.NewInt(90)).Return(wrappedAmount, big.NewInt(0), nil)
+
+	valid, err := s.SanityCheckSignature(*tm)
+
+	assert.Nil(t, err)
+	assert.True(t, valid)
+}
+
+func Test_ProcessSignatureRejectsInvalidSignature(t *testing.T) {
+	setup()
+
+	tm := message.NewSignature(transferID, routerAddress, receiver, "90", "invalid", wrappedAsset)
+	mocks.MRejectedMessageRepository.On("Create", mock.Anything).Return(nil)
+
+	err := s.ProcessSignature(*tm)
+
+	assert.Error(t, err)
+	rejected := mocks.MRejectedMessageRepository.Calls[0].Arguments.Get(0).(*entity.RejectedMessage)
+	assert.Equal(t, rejected_message.ReasonDecodeFailure, rejected.Reason)
+	assert.Empty(t, rejected.Signer)
+}
+
+func Test_RejectedMessages(t *testing.T) {
+	setup()
+
+	mocks.MRejectedMessageRepository.On("GetPage", transferID, "", int64(10), 100).Return([]entity.RejectedMessage{
+		{TransferID: transferID, Signer: "0xsigner", Reason: rejected_message.ReasonDuplicate},
+	}, nil)
+
+	messages, err := s.RejectedMessages(transferID, "", 10, 100)
+
+	assert.Nil(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "0xsigner", messages[0].Signer)
+	assert.Equal(t, rejected_message.ReasonDuplicate, messages[0].Reason)
+}
+
+func Test_ProcessSignatureRejectsEquivocation(t *testing.T) {
+	setup()
+
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey).String()
+	tm := signedMessageWith(t, key, "90")
+	tm.TransactionTimestamp = 20
+
+	mocks.MMessageRepository.On("Exist", transferID, mock.Anything, hashOf(t, "90")).Return(false, nil)
+	mocks.MBridgeContractService.On("IsMember", signer).Return(true)
+	mocks.MMessageRepository.On("Get", transferID).Return([]entity.Message{
+		{TransferID: transferID, Signature: "first", Hash: hashOf(t, "80"), Signer: signer, TransactionTimestamp: 10},
+	}, nil)
+	mocks.MRejectedMessageRepository.On("Get", transferID, signer).Return([]entity.RejectedMessage{}, nil)
+	mocks.MEquivocationRepository.On("Create", mock.Anything).Return(nil)
+	mocks.MRejectedMessageRepository.On("Create", mock.Anything).Return(nil)
+
+	err := s.ProcessSignature(*tm)
+
+	assert.Error(t, err)
+	mocks.MMessageRepository.AssertNotCalled(t, "Create", mock.Anything)
+	equivocation := mocks.MEquivocationRepository.Calls[0].Arguments.Get(0).(*entity.Equivocation)
+	assert.Equal(t, signer, equivocation.Signer)
+	assert.Equal(t, "first", equivocation.FirstSignature)
+	assert.Equal(t, hashOf(t, "80"), equivocation.FirstHash)
+	assert.Equal(t, int64(10), equivocation.FirstTimestamp)
+	assert.Equal(t, tm.GetSignature(), equivocation.SecondSignature)
+	assert.Equal(t, hashOf(t, "90"), equivocation.SecondHash)
+	assert.Equal(t, int64(20), equivocation.SecondTimestamp)
+	rejected := mocks.MRejectedMessageRepository.Calls[1].Arguments.Get(0).(*entity.RejectedMessage)
+	assert.Equal(t, rejected_message.ReasonEquivocation, rejected.Reason)
+}
+
+func Test_ProcessSignatureDetectsEquivocationWithRejected(t *testing.T) {
+	setup()
+
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey).String()
+	tm := signedMessageWith(t, key, "90")
+
+	mocks.MMessageRepository.On("Exist", transferID, mock.Anything, hashOf(t, "90")).Return(false, nil)
+	mocks.MBridgeContractService.On("IsMember", signer).Return(true)
+	mocks.MMessageRepository.On("Get", transferID).Return([]entity.Message{}, nil)
+	mocks.MRejectedMessageRepository.On("Get", transferID, signer).Return([]entity.RejectedMessage{
+		{TransferID: transferID, Signature: "first", Signer: signer, Reason: rejected_message.ReasonSanityMismatch,
+			RouterAddress: routerAddress, Receiver: receiver, Amount: "1000", WrappedAsset: wrappedAsset, TransactionTimestamp: 5},
+	}, nil)
+	mocks.MEquivocationRepository.On("Create", mock.Anything).Return(nil)
+	mocks.MMessageRepository.On("Create", mock.Anything).Return(nil)
+
+	err := s.ProcessSignature(*tm)
+
+	assert.Nil(t, err)
+	mocks.MMessageRepository.AssertNumberOfCalls(t, "Create", 1)
+	equivocation := mocks.MEquivocationRepository.Calls[0].Arguments.Get(0).(*entity.Equivocation)
+	assert.Equal(t, hashOf(t, "1000"), equivocation.FirstHash)
+	assert.Equal(t, int64(5), equivocation.FirstTimestamp)
+}
+
+func Test_ProcessSignatureRejectsConcurrentDuplicate(t *testing.T) {
+	setup()
+
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey).String()
+	tm := signedMessageWith(t, key, "90")
+
+	mocks.MMessageRepository.On("Exist", transferID, mock.Anything, hashOf(t, "90")).Return(false, nil)
+	mocks.MBridgeContractService.On("IsMember", signer).Return(true)
+	mocks.MMessageRepository.On("Get", transferID).Return([]entity.Message{}, nil)
+	mocks.MRejectedMessageRepository.On("Get", transferID, signer).Return([]entity.RejectedMessage{}, nil)
+	mocks.MMessageRepository.On("Create", mock.Anything).Return(repository.ErrDuplicate)
+	mocks.MRejectedMessageRepository.On("Create", mock.Anything).Return(nil)
+
+	err := s.ProcessSignature(*tm)
+
+	assert.Nil(t, err)
+	rejected := mocks.MRejectedMessageRepository.Calls[1].Arguments.Get(0).(*entity.RejectedMessage)
+	assert.Equal(t, rejected_message.ReasonDuplicate, rejected.Reason)
+	assert.Equal(t, signer, rejected.Signer)
+}
+
+func Test_ProcessedAccepted(t *testing.T) {
+	setup()
+
+	tm, _ := signedMessage(t, "90")
+	mocks.MMessageRepository.On("Exist", transferID, mock.Anything, hashOf(t, "90")).Return(true, nil)
+
+	processed, err := s.Processed(*tm)
+
+	assert.Nil(t, err)
+	assert.True(t, processed)
+	mocks.MRejectedMessageRepository.AssertNotCalled(t, "Get", transferID, "")
+}
+
+func Test_ProcessedRejected(t *testing.T) {
+	setup()
+
+	tm := message.NewSignature(transferID, routerAddress, receiver, "90", "invalid", wrappedAsset)
+	tm.TransactionTimestamp = 10
+	mocks.MRejectedMessageRepository.On("Get", transferID, "").Return([]entity.RejectedMessage{
+		{TransferID: transferID, Signature: "invalid", Reason: rejected_message.ReasonDecodeFailure, TransactionTimestamp: 10},
+	}, nil)
+
+	processed, err := s.Processed(*tm)
+
+	assert.Nil(t, err)
+	assert.True(t, processed)
+}
+
+func Test_ProcessedNew(t *testing.T) {
+	setup()
+
+	tm, _ := signedMessage(t, "90")
+	tm.TransactionTimestamp = 10
+	mocks.MMessageRepository.On("Exist", transferID, mock.Anything, hashOf(t, "90")).Return(false, nil)
+	mocks.MRejectedMessageRepository.On("Get", transferID, "").Return([]entity.RejectedMessage{
+		{TransferID: transferID, Signature: tm.GetSignature(), Reason: rejected_message.ReasonDuplicate, TransactionTimestamp: 20},
+	}, nil)
+
+	processed, err := s.Processed(*tm)
+
+	assert.Nil(t, err)
+	assert.False(t, processed)
+}
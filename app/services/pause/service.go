@@ -0,0 +1,392 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pause
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/client"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	ethhelper "github.com/limechain/hedera-eth-bridge-validator/app/helper/ethereum"
+	auth_message "github.com/limechain/hedera-eth-bridge-validator/app/model/auth-message"
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/message"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	control_action "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/control-action"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	paused = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "validator_paused",
+		Help: "Whether the bridge operations are paused by the Bridge members.",
+	})
+	queued = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "validator_paused_queued_operations",
+		Help: "The number of bridge operations queued while paused.",
+	})
+)
+
+type Service struct {
+	topicID    hedera.TopicID
+	hederaNode client.HederaNode
+	contracts  service.Contracts
+	signer     service.Signer
+	quorum     service.Quorum
+	repository repository.ControlAction
+	mu         sync.Mutex
+	latest     *entity.ControlAction
+	order      []string
+	operations map[string]func()
+	logger     *log.Entry
+}
+
+func New(
+	cfg config.Pause,
+	topicID string,
+	hederaNode client.HederaNode,
+	contracts service.Contracts,
+	signer service.Signer,
+	quorum service.Quorum,
+	repository repository.ControlAction) *Service {
+	tID, err := hedera.TopicIDFromString(topicID)
+	if err != nil {
+		log.Fatalf("Invalid monitoring Topic ID [%s] - Error: [%s]", topicID, err)
+	}
+
+	if cfg.RetryInterval <= 0 {
+		log.Fatalf("Invalid pause retry interval: [%d].", cfg.RetryInterval)
+	}
+
+	latest, err := repository.GetLatest()
+	if err != nil {
+		log.Fatalf("Failed to load the last control action. Error: [%s]", err)
+	}
+
+	s := &Service{
+		topicID:    tID,
+		hederaNode: hederaNode,
+		contracts:  contracts,
+		signer:     signer,
+		quorum:     quorum,
+		repository: repository,
+		latest:     latest,
+		operations: make(map[string]func()),
+		logger:     config.GetLoggerFor("Pause Service"),
+	}
+	if s.paused() {
+		paused.Set(1)
+		s.logger.Warnf("Bridge operations are paused by [%s]. Reason: [%s]", latest.Signer, latest.Reason)
+	}
+
+	go s.retry(cfg.RetryInterval * time.Second)
+
+	return s
+}
+
+// Paused returns whether the bridge operations are paused by the Bridge members
+func (s *Service) Paused() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paused()
+}
+
+// Queue queues the operation, if the bridge or the wrapped token (unless empty) is paused.
+// Queued operations are run once resumed. Returns whether the operation was queued.
+// The queue is kept in memory. It is rebuilt on start, as the recovery processes the unfinished operations again
+func (s *Service) Queue(id, wrappedAsset string, operation func()) bool {
+	s.mu.Lock()
+	if s.paused() {
+		s.enqueue(id, operation)
+		s.mu.Unlock()
+		return true
+	}
+	s.mu.Unlock()
+
+	if wrappedAsset == "" {
+		return false
+	}
+
+	tokenPaused, err := s.contracts.WrappedPaused(wrappedAsset)
+	if err != nil {
+		// The operation proceeds, as the token contract rejects it anyway, if paused
+		s.logger.Errorf("[%s] - Failed to check whether wrapped token [%s] is paused. Error: [%s]", id, wrappedAsset, err)
+		return false
+	}
+	if !tokenPaused {
+		return false
+	}
+
+	s.logger.Infof("[%s] - Wrapped token [%s] is paused.", id, wrappedAsset)
+	s.mu.Lock()
+	s.enqueue(id, operation)
+	s.mu.Unlock()
+	return true
+}
+
+// Submit signs the control action and submits it to the Bridge topic. A zero nonce proposes a new action,
+// otherwise the proposed action with the given nonce is signed
+func (s *Service) Submit(action, reason string, nonce int64) (int64, error) {
+	if action != control_action.ActionPause && action != control_action.ActionResume {
+		return 0, errors.New(fmt.Sprintf("invalid control action [%s]", action))
+	}
+	if nonce < 0 {
+		return 0, errors.New(fmt.Sprintf("invalid control action nonce [%d]", nonce))
+	}
+
+	if nonce == 0 {
+		nonce = time.Now().UnixNano()
+	}
+	authMsgBytes, err := auth_message.EncodeControlBytesFrom(s.topicID.String(), action, reason, nonce)
+	if err != nil {
+		s.logger.Errorf("Failed to encode the control action [%s]. Error: [%s]", action, err)
+		return 0, err
+	}
+
+	signatureBytes, err := s.signer.Sign(authMsgBytes)
+	if err != nil {
+		s.logger.Errorf("Failed to sign the control action [%s]. Error: [%s]", action, err)
+		return 0, err
+	}
+
+	controlMsgBytes, err := message.NewControl(action, reason, nonce, hex.EncodeToString(signatureBytes)).ToBytes()
+	if err != nil {
+		s.logger.Errorf("Failed to encode Control Message to bytes. Error: [%s]", err)
+		return 0, err
+	}
+
+	transactionID, err := s.hederaNode.SubmitTopicConsensusMessage(s.topicID, controlMsgBytes)
+	if err != nil {
+		s.logger.Errorf("Failed to submit Control Message [%s] to Topic. Error: [%s]", action, err)
+		return 0, err
+	}
+
+	s.logger.Infof("Submitted Control Message [%s] with nonce [%d] on Topic [%s] with transaction [%s]", action, nonce, s.topicID, transactionID)
+	return nonce, nil
+}
+
+// ProcessControlMessage verifies the control message and records its signature. The action is applied once
+// it has the required signatures, if it is newer than the last applied one
+func (s *Service) ProcessControlMessage(msg message.Message) error {
+	control := msg.GetControl()
+	if control == nil {
+		return errors.New("not a control message")
+	}
+	if control.Action != control_action.ActionPause && control.Action != control_action.ActionResume {
+		return errors.New(fmt.Sprintf("invalid control action [%s]", control.Action))
+	}
+	// The nonce is set before the submission, so it cannot be after the consensus timestamp
+	if control.Nonce > msg.TransactionTimestamp {
+		return errors.New(fmt.Sprintf("control action nonce [%d] is after its consensus timestamp [%d]", control.Nonce, msg.TransactionTimestamp))
+	}
+
+	authMsgBytes, err := auth_message.EncodeControlBytesFrom(s.topicID.String(), control.Action, control.Reason, control.Nonce)
+	if err != nil {
+		return err
+	}
+
+	signer, _, err := ethhelper.RecoverSignerFromStr(control.Signature, authMsgBytes)
+	if err != nil {
+		return err
+	}
+	if !s.contracts.IsMember(signer) {
+		return errors.New(fmt.Sprintf("control action [%s] is not signed by Bridge member, but [%s]", control.Action, signer))
+	}
+
+	s.mu.Lock()
+	if s.latest != nil && control.Nonce <= s.latest.Nonce {
+		s.mu.Unlock()
+		s.logger.Debugf("Ignoring control action [%s] of [%s] with nonce [%d]. Last applied nonce is [%d].", control.Action, signer, control.Nonce, s.latest.Nonce)
+		return nil
+	}
+
+	err = s.repository.CreateSignature(&entity.ControlSignature{
+		Nonce:     control.Nonce,
+		Action:    control.Action,
+		Signer:    signer,
+		Reason:    control.Reason,
+		Signature: control.Signature,
+		Timestamp: msg.TransactionTimestamp,
+	})
+	if err != nil {
+		s.mu.Unlock()
+		s.logger.Errorf("Failed to save signature of [%s] on control action [%s]. Error: [%s]", signer, control.Action, err)
+		return err
+	}
+
+	signatures, err := s.repository.GetSignatures(control.Nonce, control.Action)
+	if err != nil {
+		s.mu.Unlock()
+		s.logger.Errorf("Failed to get signatures on control action [%s] with nonce [%d]. Error: [%s]", control.Action, control.Nonce, err)
+		return err
+	}
+	collected, required := s.collected(control.Action, signatures)
+	if collected < required {
+		s.mu.Unlock()
+		s.logger.Infof("Collected [%d/%d] signatures on control action [%s] with nonce [%d].", collected, required, control.Action, control.Nonce)
+		return nil
+	}
+
+	action := &entity.ControlAction{
+		Nonce:     control.Nonce,
+		Action:    control.Action,
+		Reason:    control.Reason,
+		Signer:    signer,
+		Signature: control.Signature,
+		Timestamp: msg.TransactionTimestamp,
+	}
+	err = s.repository.Create(action)
+	if err != nil {
+		s.mu.Unlock()
+		s.logger.Errorf("Failed to save control action [%s] of [%s]. Error: [%s]", control.Action, signer, err)
+		return err
+	}
+	s.latest = action
+
+	var operations []func()
+	if action.Action == control_action.ActionPause {
+		paused.Set(1)
+		s.logger.Warnf("Bridge operations paused by [%s]. Reason: [%s]", signer, action.Reason)
+	} else {
+		paused.Set(0)
+		operations = s.dequeue()
+		s.logger.Infof("Bridge operations resumed by [%s]. Reason: [%s]. Running [%d] queued operations.", signer, action.Reason, len(operations))
+	}
+	s.mu.Unlock()
+
+	go s.run(operations)
+	return nil
+}
+
+// Status returns the current pause state
+func (s *Service) Status() service.PauseStatus {
+	s.mu.Lock()
+	status := service.PauseStatus{
+		Paused: s.paused(),
+		Queued: append([]string{}, s.order...),
+	}
+	if s.latest != nil {
+		status.Reason = s.latest.Reason
+		status.Signer = s.latest.Signer
+		status.Nonce = s.latest.Nonce
+		status.Timestamp = s.latest.Timestamp
+	}
+	s.mu.Unlock()
+
+	signatures, err := s.repository.GetSignaturesAfter(status.Nonce)
+	if err != nil {
+		s.logger.Errorf("Failed to get the signatures on proposed control actions. Error: [%s]", err)
+		return status
+	}
+	for _, signature := range signatures {
+		last := len(status.Pending) - 1
+		if last < 0 || status.Pending[last].Nonce != signature.Nonce || status.Pending[last].Action != signature.Action {
+			status.Pending = append(status.Pending, service.PendingControlAction{
+				Action: signature.Action,
+				Nonce:  signature.Nonce,
+				Reason: signature.Reason,
+			})
+			last++
+		}
+		status.Pending[last].Signers = append(status.Pending[last].Signers, signature.Signer)
+	}
+	return status
+}
+
+// collected returns the number of signatures of distinct members on the control action and the number required.
+// A pause is applied with the signature of any member, so that it is not delayed, while an incident is ongoing.
+// A resume requires the signatures of the quorum of the members
+func (s *Service) collected(action string, signatures []entity.ControlSignature) (collected, required int) {
+	if action == control_action.ActionPause {
+		return len(signatures), 1
+	}
+
+	signers, required := s.quorum.Snapshot()
+	eligible := make(map[string]bool)
+	for _, signer := range signers {
+		eligible[strings.ToLower(signer)] = true
+	}
+
+	counted := make(map[string]bool)
+	for _, signature := range signatures {
+		signer := strings.ToLower(signature.Signer)
+		if eligible[signer] && !counted[signer] {
+			counted[signer] = true
+		}
+	}
+	return len(counted), required
+}
+
+func (s *Service) paused() bool {
+	return s.latest != nil && s.latest.Action == control_action.ActionPause
+}
+
+// enqueue adds the operation to the queue. An operation, queued again, keeps its place
+func (s *Service) enqueue(id string, operation func()) {
+	if _, exists := s.operations[id]; !exists {
+		s.order = append(s.order, id)
+	}
+	s.operations[id] = operation
+	queued.Set(float64(len(s.order)))
+	s.logger.Infof("[%s] - Queued until the bridge operations are resumed.", id)
+}
+
+// dequeue empties the queue, returning the operations in the order of their queueing
+func (s *Service) dequeue() []func() {
+	operations := make([]func(), len(s.order))
+	for i, id := range s.order {
+		operations[i] = s.operations[id]
+	}
+	s.order = nil
+	s.operations = make(map[string]func())
+	queued.Set(0)
+	return operations
+}
+
+// run runs the operations one by one. Operations, which are still paused, queue themselves again
+func (s *Service) run(operations []func()) {
+	for _, operation := range operations {
+		operation()
+	}
+}
+
+// retry periodically runs the queued operations while the bridge is not paused,
+// so that operations, queued because of a paused wrapped token, proceed once it is unpaused
+func (s *Service) retry(interval time.Duration) {
+	for {
+		time.Sleep(interval)
+
+		s.mu.Lock()
+		if s.paused() || len(s.order) == 0 {
+			s.mu.Unlock()
+			continue
+		}
+		operations := s.dequeue()
+		s.mu.Unlock()
+
+		s.run(operations)
+	}
+}
@@ -0,0 +1,283 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pause
+
+import (
+	"encoding/hex"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/message"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	control_action "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/control-action"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/signer/eth"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	topicID      = "0.0.1234"
+	wrappedAsset = "0x0000000000000000000000000000000000000002"
+)
+
+func newService(t *testing.T, latest *entity.ControlAction) (*Service, *eth.Signer) {
+	mocks.Setup()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := eth.NewEthSigner(hex.EncodeToString(crypto.FromECDSA(key)))
+
+	mocks.MControlActionRepository.On("GetLatest").Return(latest, nil)
+	mocks.MControlActionRepository.On("Create", mock.Anything).Return(nil)
+	mocks.MControlActionRepository.On("CreateSignature", mock.Anything).Return(nil)
+	mocks.MControlActionRepository.On("GetSignaturesAfter", mock.Anything).Return([]entity.ControlSignature{}, nil)
+	mocks.MBridgeContractService.On("IsMember", signer.Address()).Return(true)
+	mocks.MBridgeContractService.On("IsMember", mock.Anything).Return(false)
+
+	s := New(
+		config.Pause{RetryInterval: 3600},
+		topicID,
+		mocks.MHederaNodeClient,
+		mocks.MBridgeContractService,
+		signer,
+		mocks.MQuorumService,
+		mocks.MControlActionRepository)
+	return s, signer
+}
+
+// expectSignatures sets the signatures, recorded on control actions, and the quorum of the members
+func expectSignatures(members []string, required int, signers ...string) {
+	signatures := make([]entity.ControlSignature, len(signers))
+	for i, signer := range signers {
+		signatures[i] = entity.ControlSignature{Signer: signer}
+	}
+	mocks.MControlActionRepository.On("GetSignatures", mock.Anything, mock.Anything).Return(signatures, nil)
+	mocks.MQuorumService.On("Snapshot").Return(members, required)
+}
+
+// submit submits the control action and returns the message, as received from the topic
+func submit(t *testing.T, s *Service, action string) message.Message {
+	return submitNonce(t, s, action, 0)
+}
+
+// submitNonce signs the proposed control action with the given nonce and returns the message, as received from the topic
+func submitNonce(t *testing.T, s *Service, action string, nonce int64) message.Message {
+	var submitted []byte
+	mocks.MHederaNodeClient.ExpectedCalls = nil
+	mocks.MHederaNodeClient.On("SubmitTopicConsensusMessage", s.topicID, mock.Anything).
+		Run(func(args mock.Arguments) { submitted = args.Get(1).([]byte) }).
+		Return(&hedera.TransactionID{}, nil)
+
+	nonce, err := s.Submit(action, "incident", nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := message.FromBytesWithTS(submitted, nonce+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *msg
+}
+
+func Test_New(t *testing.T) {
+	s, _ := newService(t, &entity.ControlAction{Nonce: 1, Action: control_action.ActionPause})
+
+	assert.True(t, s.Paused())
+}
+
+func Test_QueueNotPaused(t *testing.T) {
+	s, _ := newService(t, nil)
+	mocks.MBridgeContractService.On("WrappedPaused", wrappedAsset).Return(false, nil)
+
+	assert.False(t, s.Queue("1", wrappedAsset, func() {}))
+	assert.False(t, s.Queue("2", "", func() {}))
+	assert.Empty(t, s.Status().Queued)
+}
+
+func Test_QueueWrappedTokenPaused(t *testing.T) {
+	s, _ := newService(t, nil)
+	mocks.MBridgeContractService.On("WrappedPaused", wrappedAsset).Return(true, nil)
+
+	assert.True(t, s.Queue("1", wrappedAsset, func() {}))
+	assert.Equal(t, []string{"1"}, s.Status().Queued)
+}
+
+func Test_QueueWrappedTokenCheckFails(t *testing.T) {
+	s, _ := newService(t, nil)
+	mocks.MBridgeContractService.On("WrappedPaused", wrappedAsset).Return(false, errors.New("connection refused"))
+
+	assert.False(t, s.Queue("1", wrappedAsset, func() {}))
+}
+
+func Test_PauseAndResume(t *testing.T) {
+	s, signer := newService(t, nil)
+	expectSignatures([]string{signer.Address()}, 1, signer.Address())
+
+	err := s.ProcessControlMessage(submit(t, s, control_action.ActionPause))
+
+	assert.Nil(t, err)
+	assert.True(t, s.Paused())
+	assert.Equal(t, signer.Address(), s.Status().Signer)
+	assert.Equal(t, "incident", s.Status().Reason)
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	var ran []string
+	assert.True(t, s.Queue("1", wrappedAsset, func() { ran = append(ran, "1"); wg.Done() }))
+	assert.True(t, s.Queue("2", "", func() { ran = append(ran, "2"); wg.Done() }))
+	assert.True(t, s.Queue("1", wrappedAsset, func() { ran = append(ran, "1"); wg.Done() }))
+	assert.Equal(t, []string{"1", "2"}, s.Status().Queued)
+
+	err = s.ProcessControlMessage(submit(t, s, control_action.ActionResume))
+	wg.Wait()
+
+	assert.Nil(t, err)
+	assert.False(t, s.Paused())
+	assert.Equal(t, []string{"1", "2"}, ran)
+	assert.Empty(t, s.Status().Queued)
+	mocks.MControlActionRepository.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func Test_ProcessControlMessageIgnoresStaleAction(t *testing.T) {
+	s, signer := newService(t, nil)
+	expectSignatures([]string{signer.Address()}, 1, signer.Address())
+	resume := submit(t, s, control_action.ActionResume)
+	pause := submit(t, s, control_action.ActionPause)
+
+	assert.Nil(t, s.ProcessControlMessage(pause))
+	assert.Nil(t, s.ProcessControlMessage(resume))
+
+	assert.True(t, s.Paused())
+	mocks.MControlActionRepository.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func Test_ProcessControlMessageRejectsNonMember(t *testing.T) {
+	s, _ := newService(t, nil)
+	key, _ := crypto.GenerateKey()
+	s.signer = eth.NewEthSigner(hex.EncodeToString(crypto.FromECDSA(key)))
+
+	err := s.ProcessControlMessage(submit(t, s, control_action.ActionPause))
+
+	assert.Error(t, err)
+	assert.False(t, s.Paused())
+	mocks.MControlActionRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_ProcessControlMessageRejectsTamperedReason(t *testing.T) {
+	s, _ := newService(t, nil)
+	msg := submit(t, s, control_action.ActionPause)
+	msg.Control.Reason = "other"
+
+	err := s.ProcessControlMessage(msg)
+
+	assert.Error(t, err)
+	assert.False(t, s.Paused())
+}
+
+func Test_ProcessControlMessageRejectsFutureNonce(t *testing.T) {
+	s, _ := newService(t, nil)
+	msg := submit(t, s, control_action.ActionPause)
+	msg.TransactionTimestamp = msg.Control.Nonce - 1
+
+	err := s.ProcessControlMessage(msg)
+
+	assert.Error(t, err)
+	assert.False(t, s.Paused())
+}
+
+func Test_ResumeRequiresQuorum(t *testing.T) {
+	s, signer := newService(t, &entity.ControlAction{Nonce: 1, Action: control_action.ActionPause})
+	other := "0x0000000000000000000000000000000000000003"
+	expectSignatures([]string{signer.Address(), other}, 2, signer.Address())
+
+	err := s.ProcessControlMessage(submit(t, s, control_action.ActionResume))
+
+	assert.Nil(t, err)
+	assert.True(t, s.Paused())
+	mocks.MControlActionRepository.AssertCalled(t, "CreateSignature", mock.Anything)
+	mocks.MControlActionRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_ResumeWithQuorum(t *testing.T) {
+	s, signer := newService(t, &entity.ControlAction{Nonce: 1, Action: control_action.ActionPause})
+	other := "0x0000000000000000000000000000000000000003"
+	expectSignatures([]string{signer.Address(), other}, 2, other, signer.Address())
+
+	err := s.ProcessControlMessage(submitNonce(t, s, control_action.ActionResume, 2))
+
+	assert.Nil(t, err)
+	assert.False(t, s.Paused())
+	assert.Equal(t, int64(2), s.Status().Nonce)
+	mocks.MControlActionRepository.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func Test_Collected(t *testing.T) {
+	s, _ := newService(t, nil)
+	members := []string{"0xAA", "0xBB", "0xCC"}
+	mocks.MQuorumService.On("Snapshot").Return(members, 2)
+	signatures := []entity.ControlSignature{{Signer: "0xaa"}, {Signer: "0xAA"}, {Signer: "0xDD"}}
+
+	collected, required := s.collected(control_action.ActionResume, signatures)
+	assert.Equal(t, 1, collected)
+	assert.Equal(t, 2, required)
+
+	collected, required = s.collected(control_action.ActionPause, signatures[:1])
+	assert.Equal(t, 1, collected)
+	assert.Equal(t, 1, required)
+}
+
+func Test_StatusPending(t *testing.T) {
+	s, signer := newService(t, &entity.ControlAction{Nonce: 1, Action: control_action.ActionPause})
+	mocks.MControlActionRepository.ExpectedCalls = nil
+	mocks.MControlActionRepository.On("GetSignaturesAfter", int64(1)).Return([]entity.ControlSignature{
+		{Nonce: 2, Action: control_action.ActionResume, Signer: signer.Address(), Reason: "resolved"},
+		{Nonce: 2, Action: control_action.ActionResume, Signer: "0x0000000000000000000000000000000000000003", Reason: "resolved"},
+		{Nonce: 3, Action: control_action.ActionResume, Signer: signer.Address()},
+	}, nil)
+
+	status := s.Status()
+
+	assert.Equal(t, []service.PendingControlAction{
+		{Action: control_action.ActionResume, Nonce: 2, Reason: "resolved", Signers: []string{signer.Address(), "0x0000000000000000000000000000000000000003"}},
+		{Action: control_action.ActionResume, Nonce: 3, Signers: []string{signer.Address()}},
+	}, status.Pending)
+}
+
+func Test_SubmitSignsProposedAction(t *testing.T) {
+	s, _ := newService(t, nil)
+
+	msg := submitNonce(t, s, control_action.ActionResume, 42)
+
+	assert.Equal(t, int64(42), msg.Control.Nonce)
+}
+
+func Test_SubmitInvalidAction(t *testing.T) {
+	s, _ := newService(t, nil)
+
+	_, err := s.Submit("STOP", "", 0)
+
+	assert.Error(t, err)
+	mocks.MHederaNodeClient.AssertNotCalled(t, "SubmitTopicConsensusMessage", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pending
+
+import (
+	"sync"
+	"time"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/message"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	log "github.com/sirupsen/logrus"
+)
+
+type Service struct {
+	ttl                      int64
+	expirationInterval       time.Duration
+	transferRepository       repository.Transfer
+	pendingMessageRepository repository.PendingMessage
+	mu                       sync.RWMutex
+	handler                  func(tm message.Message)
+	logger                   *log.Entry
+}
+
+func New(
+	cfg config.PendingSignatures,
+	transferRepository repository.Transfer,
+	pendingMessageRepository repository.PendingMessage) *Service {
+	if cfg.TTL <= 0 {
+		log.Fatalf("Invalid pending signatures TTL: [%d].", cfg.TTL)
+	}
+
+	if cfg.ExpirationInterval <= 0 {
+		log.Fatalf("Invalid pending signatures expiration interval: [%d].", cfg.ExpirationInterval)
+	}
+
+	s := &Service{
+		ttl:                      int64(cfg.TTL),
+		expirationInterval:       cfg.ExpirationInterval * time.Second,
+		transferRepository:       transferRepository,
+		pendingMessageRepository: pendingMessageRepository,
+		logger:                   config.GetLoggerFor("Pending Signatures Service"),
+	}
+
+	return s
+}
+
+// Start periodically removes the buffered signature messages, whose transfer was not processed in time
+func (s *Service) Start() {
+	go s.expire(s.expirationInterval)
+}
+
+// Add buffers the signature message until its transfer is ready to be processed or until it expires.
+// The transfer is checked after the message is buffered, so that a message is not left behind,
+// in case the transfer became ready in the meantime
+func (s *Service) Add(tm message.Message) error {
+	bytes, err := tm.ToBytes()
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to encode signature message. Error: [%s]", tm.TransferID, err)
+		return err
+	}
+
+	err = s.pendingMessageRepository.Create(&entity.PendingMessage{
+		Signature:            tm.Signature,
+		TransferID:           tm.TransferID,
+		Message:              bytes,
+		TransactionTimestamp: tm.TransactionTimestamp,
+		ExpiresAt:            time.Now().Unix() + s.ttl,
+	})
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to buffer signature message [%s]. Error: [%s]", tm.TransferID, tm.Signature, err)
+		return err
+	}
+	s.logger.Debugf("[%s] - Buffered signature message [%s] until the transfer is processed.", tm.TransferID, tm.Signature)
+
+	t, err := s.transferRepository.GetWithFee(tm.TransferID)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to retrieve Transaction Record. Error: [%s]", tm.TransferID, err)
+		return err
+	}
+	if t != nil && t.Fee.TransactionID != "" {
+		s.Release(tm.TransferID)
+	}
+
+	return nil
+}
+
+// Release hands the buffered signature messages of the transfer over to the subscribed handler
+func (s *Service) Release(transferID string) {
+	s.mu.RLock()
+	handler := s.handler
+	s.mu.RUnlock()
+	if handler == nil {
+		return
+	}
+
+	pending, err := s.pendingMessageRepository.Pop(transferID)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to retrieve buffered signature messages. Error: [%s]", transferID, err)
+		return
+	}
+
+	for _, pm := range pending {
+		tm, err := message.FromBytesWithTS(pm.Message, pm.TransactionTimestamp)
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to decode buffered signature message [%s]. Error: [%s]", transferID, pm.Signature, err)
+			continue
+		}
+
+		s.logger.Debugf("[%s] - Releasing buffered signature message [%s].", transferID, pm.Signature)
+		go handler(*tm)
+	}
+}
+
+// Subscribe sets the handler of the released signature messages
+func (s *Service) Subscribe(handler func(tm message.Message)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = handler
+}
+
+// expire periodically removes the buffered signature messages, whose transfer was not processed in time
+func (s *Service) expire(interval time.Duration) {
+	for {
+		s.deleteExpired(time.Now().Unix())
+		time.Sleep(interval)
+	}
+}
+
+func (s *Service) deleteExpired(now int64) {
+	expired, err := s.pendingMessageRepository.DeleteExpired(now)
+	if err != nil {
+		s.logger.Errorf("Failed to remove expired signature messages. Error: [%s]", err)
+		return
+	}
+
+	for _, pm := range expired {
+		s.logger.Warnf("[%s] - Dropped signature message [%s]. Reason: transfer was not processed within [%d] seconds.", pm.TransferID, pm.Signature, s.ttl)
+	}
+}
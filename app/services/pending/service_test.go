@@ -0,0 +1,115 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pending
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/message"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	s          = &Service{}
+	transferID = "0.0.123456-1620000000-000000000"
+	tm         = message.NewSignature(transferID, "0xrouter", "0xreceiver", "100", "signature", "0xwrapped")
+)
+
+func setup() {
+	mocks.Setup()
+	s = &Service{
+		ttl:                      60,
+		transferRepository:       mocks.MTransferRepository,
+		pendingMessageRepository: mocks.MPendingMessageRepository,
+		logger:                   config.GetLoggerFor("Pending Signatures Service"),
+	}
+}
+
+func Test_AddBuffersMessage(t *testing.T) {
+	setup()
+
+	mocks.MPendingMessageRepository.On("Create", mock.Anything).Return(nil)
+	mocks.MTransferRepository.On("GetWithFee", transferID).Return((*entity.Transfer)(nil), nil)
+
+	err := s.Add(*tm)
+
+	assert.Nil(t, err)
+	pm := mocks.MPendingMessageRepository.Calls[0].Arguments.Get(0).(*entity.PendingMessage)
+	assert.Equal(t, transferID, pm.TransferID)
+	assert.Equal(t, "signature", pm.Signature)
+	assert.InDelta(t, time.Now().Unix()+60, pm.ExpiresAt, 1)
+	mocks.MPendingMessageRepository.AssertNotCalled(t, "Pop", transferID)
+}
+
+func Test_AddReleasesWhenTransferIsReady(t *testing.T) {
+	setup()
+
+	bytes, _ := tm.ToBytes()
+	handled := make(chan message.Message, 1)
+	s.Subscribe(func(m message.Message) { handled <- m })
+
+	mocks.MPendingMessageRepository.On("Create", mock.Anything).Return(nil)
+	mocks.MTransferRepository.On("GetWithFee", transferID).Return(&entity.Transfer{Fee: entity.Fee{TransactionID: transferID}}, nil)
+	mocks.MPendingMessageRepository.On("Pop", transferID).Return([]entity.PendingMessage{{Signature: "signature", TransferID: transferID, Message: bytes, TransactionTimestamp: 10}}, nil)
+
+	err := s.Add(*tm)
+
+	assert.Nil(t, err)
+	select {
+	case m := <-handled:
+		assert.Equal(t, transferID, m.TransferID)
+		assert.Equal(t, "signature", m.Signature)
+		assert.Equal(t, int64(10), m.TransactionTimestamp)
+	case <-time.After(time.Second):
+		t.Fatal("buffered message was not released")
+	}
+}
+
+func Test_AddFails(t *testing.T) {
+	setup()
+
+	mocks.MPendingMessageRepository.On("Create", mock.Anything).Return(errors.New("some-error"))
+
+	err := s.Add(*tm)
+
+	assert.Error(t, err)
+	mocks.MTransferRepository.AssertNotCalled(t, "GetWithFee", transferID)
+}
+
+func Test_ReleaseWithoutSubscriber(t *testing.T) {
+	setup()
+
+	s.Release(transferID)
+
+	mocks.MPendingMessageRepository.AssertNotCalled(t, "Pop", transferID)
+}
+
+func Test_DeleteExpired(t *testing.T) {
+	setup()
+
+	mocks.MPendingMessageRepository.On("DeleteExpired", int64(100)).Return([]entity.PendingMessage{{Signature: "signature", TransferID: transferID}}, nil)
+
+	s.deleteExpired(100)
+
+	mocks.MPendingMessageRepository.AssertCalled(t, "DeleteExpired", int64(100))
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	big_numbers "github.com/limechain/hedera-eth-bridge-validator/app/helper/big-numbers"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ViolationNotAllowed       = "ASSET_NOT_ALLOWED"
+	ViolationPaused           = "ASSET_PAUSED"
+	ViolationMinAmount        = "BELOW_MIN_AMOUNT"
+	ViolationMaxAmount        = "ABOVE_MAX_AMOUNT"
+	ViolationDailyCap         = "DAILY_CAP_EXCEEDED"
+	ViolationReceiverDailyCap = "RECEIVER_DAILY_CAP_EXCEEDED"
+)
+
+// day is the length (in seconds) of the rolling window of the daily caps
+const day = 24 * 60 * 60
+
+type Service struct {
+	assets     map[string]config.AssetLimits
+	repository repository.Volume
+	mutex      sync.Mutex
+	logger     *log.Entry
+}
+
+// New creates an asset policy service for the configured asset limits
+func New(policy config.AssetPolicy, repository repository.Volume) *Service {
+	for asset, limits := range policy.Assets {
+		if limits.MinAmount < 0 || limits.MaxAmount < 0 || limits.DailyCap < 0 || limits.ReceiverDailyCap < 0 {
+			log.Fatalf("Invalid limits for asset [%s] - limits cannot be negative.", asset)
+		}
+		if limits.MaxAmount > 0 && limits.MinAmount > limits.MaxAmount {
+			log.Fatalf("Invalid limits for asset [%s] - min amount [%d] is greater than max amount [%d].", asset, limits.MinAmount, limits.MaxAmount)
+		}
+	}
+
+	return &Service{
+		assets:     policy.Assets,
+		repository: repository,
+		logger:     config.GetLoggerFor("Asset Policy Service"),
+	}
+}
+
+// Check verifies the operation against the limits of its native asset and accounts its amount
+// in the daily volumes of the asset in the direction. Returns the violated limit or an empty string if the operation is allowed.
+// The amount is in native asset units and the timestamp of the operation is in seconds
+func (s *Service) Check(id, direction, nativeAsset, receiver string, amount *big.Int, timestamp int64) (violation string, err error) {
+	return s.check(id, direction, nativeAsset, receiver, amount, timestamp, true)
+}
+
+// Preview verifies the operation like Check, without accounting its amount in the daily volumes of the asset
+func (s *Service) Preview(id, direction, nativeAsset, receiver string, amount *big.Int, timestamp int64) (violation string, err error) {
+	return s.check(id, direction, nativeAsset, receiver, amount, timestamp, false)
+}
+
+func (s *Service) check(id, direction, nativeAsset, receiver string, amount *big.Int, timestamp int64, record bool) (violation string, err error) {
+	if len(s.assets) == 0 {
+		return "", nil
+	}
+
+	limits, ok := s.assets[nativeAsset]
+	if !ok {
+		return ViolationNotAllowed, nil
+	}
+	if limits.Paused {
+		return ViolationPaused, nil
+	}
+	if limits.MinAmount > 0 && amount.Cmp(big.NewInt(limits.MinAmount)) < 0 {
+		return ViolationMinAmount, nil
+	}
+	if limits.MaxAmount > 0 && amount.Cmp(big.NewInt(limits.MaxAmount)) > 0 {
+		return ViolationMaxAmount, nil
+	}
+
+	// Volumes are checked and recorded one operation at a time, so that concurrent operations cannot exceed the caps
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, err := s.repository.Get(id)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to query volume. Error: [%s]", id, err)
+		return "", err
+	}
+	if existing != nil {
+		// Already accounted operations (f.e. processed again after a restart) are allowed
+		return "", nil
+	}
+
+	volumes, err := s.repository.GetBetween(nativeAsset, direction, timestamp-day, timestamp)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to query volumes of asset [%s]. Error: [%s]", id, nativeAsset, err)
+		return "", err
+	}
+
+	total := new(big.Int).Set(amount)
+	receiverTotal := new(big.Int).Set(amount)
+	for _, v := range volumes {
+		if !precedes(v, id, timestamp) {
+			// Only the operations preceding this one are accounted, so that the outcome
+			// does not depend on the order, in which the validator has processed them
+			continue
+		}
+		volumeAmount, err := big_numbers.ToBigInt(v.Amount)
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to parse volume amount of [%s]. Error: [%s]", id, v.ID, err)
+			return "", err
+		}
+		total.Add(total, volumeAmount)
+		if v.Receiver == receiver {
+			receiverTotal.Add(receiverTotal, volumeAmount)
+		}
+	}
+
+	if limits.DailyCap > 0 && total.Cmp(big.NewInt(limits.DailyCap)) > 0 {
+		return ViolationDailyCap, nil
+	}
+	if limits.ReceiverDailyCap > 0 && receiverTotal.Cmp(big.NewInt(limits.ReceiverDailyCap)) > 0 {
+		return ViolationReceiverDailyCap, nil
+	}
+	if !record {
+		return "", nil
+	}
+
+	err = s.repository.Create(&entity.Volume{
+		ID:          id,
+		NativeAsset: nativeAsset,
+		Direction:   direction,
+		Receiver:    receiver,
+		Amount:      amount.String(),
+		Timestamp:   timestamp,
+	})
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to record volume. Error: [%s]", id, err)
+		return "", err
+	}
+	return "", nil
+}
+
+// precedes returns whether the volume precedes the operation with the given id and timestamp.
+// Operations with equal timestamps are ordered by their ids
+func precedes(v entity.Volume, id string, timestamp int64) bool {
+	return v.Timestamp < timestamp || (v.Timestamp == timestamp && v.ID < id)
+}
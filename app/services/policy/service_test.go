@@ -0,0 +1,213 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/volume"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/constants"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	id        = "0.0.123456-1620000000-000000000"
+	receiver  = "0x0000000000000000000000000000000000000003"
+	timestamp = int64(1620000000)
+	direction = volume.DirectionHederaToEVM
+	limits    = config.AssetLimits{
+		MinAmount:        10,
+		MaxAmount:        1000,
+		DailyCap:         2000,
+		ReceiverDailyCap: 1500,
+	}
+)
+
+func newService(assets map[string]config.AssetLimits) *Service {
+	mocks.Setup()
+	return New(config.AssetPolicy{Assets: assets}, mocks.MVolumeRepository)
+}
+
+func expectVolumes(volumes []entity.Volume) {
+	mocks.MVolumeRepository.On("Get", id).Return(nil, nil)
+	mocks.MVolumeRepository.On("GetBetween", constants.Hbar, direction, timestamp-day, timestamp).Return(volumes, nil)
+	mocks.MVolumeRepository.On("Create", mock.Anything).Return(nil)
+}
+
+func Test_CheckWithoutPolicy(t *testing.T) {
+	s := newService(nil)
+
+	violation, err := s.Check(id, direction, constants.Hbar, receiver, big.NewInt(1), timestamp)
+
+	assert.Nil(t, err)
+	assert.Empty(t, violation)
+	mocks.MVolumeRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_CheckNotAllowed(t *testing.T) {
+	s := newService(map[string]config.AssetLimits{"0.0.1234": limits})
+
+	violation, err := s.Check(id, direction, constants.Hbar, receiver, big.NewInt(100), timestamp)
+
+	assert.Nil(t, err)
+	assert.Equal(t, ViolationNotAllowed, violation)
+}
+
+func Test_CheckPaused(t *testing.T) {
+	s := newService(map[string]config.AssetLimits{constants.Hbar: {Paused: true}})
+
+	violation, err := s.Check(id, direction, constants.Hbar, receiver, big.NewInt(100), timestamp)
+
+	assert.Nil(t, err)
+	assert.Equal(t, ViolationPaused, violation)
+}
+
+func Test_CheckAmounts(t *testing.T) {
+	s := newService(map[string]config.AssetLimits{constants.Hbar: limits})
+
+	violation, err := s.Check(id, direction, constants.Hbar, receiver, big.NewInt(9), timestamp)
+	assert.Nil(t, err)
+	assert.Equal(t, ViolationMinAmount, violation)
+
+	violation, err = s.Check(id, direction, constants.Hbar, receiver, big.NewInt(1001), timestamp)
+	assert.Nil(t, err)
+	assert.Equal(t, ViolationMaxAmount, violation)
+
+	mocks.MVolumeRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_CheckRecordsVolume(t *testing.T) {
+	s := newService(map[string]config.AssetLimits{constants.Hbar: limits})
+	expectVolumes([]entity.Volume{{ID: "other", NativeAsset: constants.Hbar, Receiver: receiver, Amount: "500"}})
+
+	violation, err := s.Check(id, direction, constants.Hbar, receiver, big.NewInt(1000), timestamp)
+
+	assert.Nil(t, err)
+	assert.Empty(t, violation)
+	mocks.MVolumeRepository.AssertCalled(t, "Create", &entity.Volume{
+		ID:          id,
+		NativeAsset: constants.Hbar,
+		Direction:   direction,
+		Receiver:    receiver,
+		Amount:      "1000",
+		Timestamp:   timestamp,
+	})
+}
+
+func Test_PreviewDoesNotRecordVolume(t *testing.T) {
+	s := newService(map[string]config.AssetLimits{constants.Hbar: limits})
+	expectVolumes([]entity.Volume{{ID: "other", NativeAsset: constants.Hbar, Receiver: receiver, Amount: "500"}})
+
+	violation, err := s.Preview(id, direction, constants.Hbar, receiver, big.NewInt(1000), timestamp)
+
+	assert.Nil(t, err)
+	assert.Empty(t, violation)
+	mocks.MVolumeRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_CheckDailyCap(t *testing.T) {
+	s := newService(map[string]config.AssetLimits{constants.Hbar: limits})
+	expectVolumes([]entity.Volume{
+		{ID: "first", NativeAsset: constants.Hbar, Receiver: "0x01", Amount: "1000"},
+		{ID: "second", NativeAsset: constants.Hbar, Receiver: "0x02", Amount: "900"},
+	})
+
+	violation, err := s.Check(id, direction, constants.Hbar, receiver, big.NewInt(101), timestamp)
+
+	assert.Nil(t, err)
+	assert.Equal(t, ViolationDailyCap, violation)
+	mocks.MVolumeRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_CheckDailyCapAccountsPrecedingVolumes(t *testing.T) {
+	s := newService(map[string]config.AssetLimits{constants.Hbar: limits})
+	expectVolumes([]entity.Volume{
+		{ID: "0.0.123456-1619999999-000000000", NativeAsset: constants.Hbar, Receiver: "0x01", Amount: "1000", Timestamp: timestamp - 1},
+		{ID: "0.0.123455-1620000000-000000000", NativeAsset: constants.Hbar, Receiver: "0x02", Amount: "500", Timestamp: timestamp},
+		// Accounted before the checked operation, but following it
+		{ID: "0.0.123457-1620000000-000000000", NativeAsset: constants.Hbar, Receiver: "0x03", Amount: "900", Timestamp: timestamp},
+	})
+
+	violation, err := s.Check(id, direction, constants.Hbar, receiver, big.NewInt(500), timestamp)
+
+	assert.Nil(t, err)
+	assert.Empty(t, violation)
+	mocks.MVolumeRepository.AssertCalled(t, "Create", mock.Anything)
+}
+
+func Test_CheckDailyCapPerDirection(t *testing.T) {
+	s := newService(map[string]config.AssetLimits{constants.Hbar: limits})
+	mocks.MVolumeRepository.On("Get", id).Return(nil, nil)
+	mocks.MVolumeRepository.On("GetBetween", constants.Hbar, volume.DirectionEVMToHedera, timestamp-day, timestamp).Return([]entity.Volume{}, nil)
+	mocks.MVolumeRepository.On("Create", mock.Anything).Return(nil)
+
+	violation, err := s.Check(id, volume.DirectionEVMToHedera, constants.Hbar, receiver, big.NewInt(1000), timestamp)
+
+	assert.Nil(t, err)
+	assert.Empty(t, violation)
+	mocks.MVolumeRepository.AssertNotCalled(t, "GetBetween", constants.Hbar, direction, timestamp-day, timestamp)
+	mocks.MVolumeRepository.AssertCalled(t, "Create", &entity.Volume{
+		ID:          id,
+		NativeAsset: constants.Hbar,
+		Direction:   volume.DirectionEVMToHedera,
+		Receiver:    receiver,
+		Amount:      "1000",
+		Timestamp:   timestamp,
+	})
+}
+
+func Test_CheckReceiverDailyCap(t *testing.T) {
+	s := newService(map[string]config.AssetLimits{constants.Hbar: limits})
+	expectVolumes([]entity.Volume{
+		{ID: "first", NativeAsset: constants.Hbar, Receiver: receiver, Amount: "1000"},
+		{ID: "second", NativeAsset: constants.Hbar, Receiver: "0x02", Amount: "100"},
+	})
+
+	violation, err := s.Check(id, direction, constants.Hbar, receiver, big.NewInt(501), timestamp)
+
+	assert.Nil(t, err)
+	assert.Equal(t, ViolationReceiverDailyCap, violation)
+	mocks.MVolumeRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_CheckAlreadyAccounted(t *testing.T) {
+	s := newService(map[string]config.AssetLimits{constants.Hbar: limits})
+	mocks.MVolumeRepository.On("Get", id).Return(&entity.Volume{ID: id}, nil)
+
+	violation, err := s.Check(id, direction, constants.Hbar, receiver, big.NewInt(100), timestamp)
+
+	assert.Nil(t, err)
+	assert.Empty(t, violation)
+	mocks.MVolumeRepository.AssertNotCalled(t, "GetBetween", constants.Hbar, direction, timestamp-day, timestamp)
+	mocks.MVolumeRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_CheckRepositoryFails(t *testing.T) {
+	s := newService(map[string]config.AssetLimits{constants.Hbar: limits})
+	mocks.MVolumeRepository.On("Get", id).Return(nil, nil)
+	mocks.MVolumeRepository.On("GetBetween", constants.Hbar, direction, timestamp-day, timestamp).Return(nil, errors.New("connection refused"))
+
+	_, err := s.Check(id, direction, constants.Hbar, receiver, big.NewInt(100), timestamp)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package quorum
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/helper/majority"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	TypeMajority = "majority"
+	TypeFraction = "fraction"
+	TypeAbsolute = "absolute"
+)
+
+type Service struct {
+	quorum         config.Quorum
+	memberRegistry service.MemberRegistry
+	logger         *log.Entry
+}
+
+// New creates a quorum service for the provided quorum configuration. Defaults to majority
+func New(quorum config.Quorum, memberRegistry service.MemberRegistry) *Service {
+	switch quorum.Type {
+	case "":
+		quorum.Type = TypeMajority
+	case TypeMajority:
+	case TypeFraction:
+		if quorum.Numerator <= 0 || quorum.Denominator <= 0 || quorum.Numerator > quorum.Denominator {
+			log.Fatalf("Invalid quorum fraction: [%d/%d].", quorum.Numerator, quorum.Denominator)
+		}
+	case TypeAbsolute:
+		if quorum.Signatures <= 0 {
+			log.Fatalf("Invalid quorum signatures: [%d].", quorum.Signatures)
+		}
+		// The quorum could never be reached
+		if members := len(memberRegistry.Signers()); quorum.Signatures > members {
+			log.Fatalf("Invalid quorum signatures: [%d] exceed the number of members [%d].", quorum.Signatures, members)
+		}
+	default:
+		log.Fatalf("Invalid quorum type: [%s].", quorum.Type)
+	}
+
+	return &Service{
+		quorum:         quorum,
+		memberRegistry: memberRegistry,
+		logger:         config.GetLoggerFor("Quorum Service"),
+	}
+}
+
+// Snapshot returns the currently eligible signers and the number of signatures required out of them
+func (s *Service) Snapshot() (signers []string, required int) {
+	signers = s.memberRegistry.Signers()
+	required = s.required(len(signers))
+	if required > len(signers) {
+		s.logger.Warnf("Required signatures [%d] exceed the number of members [%d]. Transfers cannot be completed.", required, len(signers))
+	}
+	return signers, required
+}
+
+// Reached returns the number of collected signatures from the eligible signers of the transfer,
+// the number of eligible signers and whether the required number of signatures is reached.
+// Transfers created without a snapshot fall back to the current signers
+func (s *Service) Reached(t *entity.Transfer, messages []entity.Message) (collected, eligible int, reached bool) {
+	signers, required := s.eligibleSigners(t)
+	collected = majority.Count(messages, signers)
+	return collected, len(signers), collected >= required
+}
+
+// Eligible returns the messages of the eligible signers of the transfer, a single one per signer.
+// Transfers created without a snapshot fall back to the current signers
+func (s *Service) Eligible(t *entity.Transfer, messages []entity.Message) []entity.Message {
+	signers, _ := s.eligibleSigners(t)
+	return majority.Eligible(messages, signers)
+}
+
+// eligibleSigners returns the signers eligible for the transfer and the number of signatures required out of them
+func (s *Service) eligibleSigners(t *entity.Transfer) (signers []string, required int) {
+	signers = majority.Signers(t)
+	if signers == nil {
+		return s.Snapshot()
+	}
+	return signers, t.RequiredSignatures
+}
+
+// required returns the number of signatures required out of the given number of signers
+func (s *Service) required(signersCount int) int {
+	switch s.quorum.Type {
+	case TypeFraction:
+		required := (signersCount*s.quorum.Numerator + s.quorum.Denominator - 1) / s.quorum.Denominator
+		if required == 0 {
+			return 1
+		}
+		return required
+	case TypeAbsolute:
+		return s.quorum.Signatures
+	default:
+		return signersCount/2 + 1
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package quorum
+
+import (
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/helper/majority"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	signers  = []string{"0xAa", "0xBb", "0xCc", "0xDd"}
+	messages = []entity.Message{{Signer: "0xAa"}, {Signer: "0xBb"}, {Signer: "0xEe"}}
+)
+
+func newService(quorum config.Quorum) *Service {
+	mocks.Setup()
+	mocks.MMemberRegistry.On("Signers").Return(signers)
+	return New(quorum, mocks.MMemberRegistry)
+}
+
+func Test_SnapshotMajority(t *testing.T) {
+	s := newService(config.Quorum{})
+
+	actualSigners, required := s.Snapshot()
+
+	assert.Equal(t, signers, actualSigners)
+	assert.Equal(t, 3, required)
+}
+
+func Test_SnapshotFraction(t *testing.T) {
+	s := newService(config.Quorum{Type: TypeFraction, Numerator: 2, Denominator: 3})
+
+	_, required := s.Snapshot()
+
+	assert.Equal(t, 3, required)
+}
+
+func Test_SnapshotAbsolute(t *testing.T) {
+	s := newService(config.Quorum{Type: TypeAbsolute, Signatures: 2})
+
+	_, required := s.Snapshot()
+
+	assert.Equal(t, 2, required)
+}
+
+func Test_ReachedSnapshot(t *testing.T) {
+	s := newService(config.Quorum{})
+	transfer := &entity.Transfer{
+		EligibleSigners:    majority.Join([]string{"0xAa", "0xBb", "0xEe"}),
+		RequiredSignatures: 3,
+	}
+
+	collected, eligible, reached := s.Reached(transfer, messages)
+
+	assert.Equal(t, 3, collected)
+	assert.Equal(t, 3, eligible)
+	assert.True(t, reached)
+}
+
+func Test_ReachedWithoutSnapshot(t *testing.T) {
+	s := newService(config.Quorum{})
+
+	collected, eligible, reached := s.Reached(&entity.Transfer{}, messages)
+
+	assert.Equal(t, 2, collected)
+	assert.Equal(t, 4, eligible)
+	assert.False(t, reached)
+}
+
+func Test_EligibleSnapshot(t *testing.T) {
+	s := newService(config.Quorum{})
+	transfer := &entity.Transfer{
+		EligibleSigners:    majority.Join([]string{"0xAa", "0xBb"}),
+		RequiredSignatures: 2,
+	}
+
+	eligible := s.Eligible(transfer, append(messages, entity.Message{Signer: "0xaa"}))
+
+	assert.Equal(t, []entity.Message{{Signer: "0xAa"}, {Signer: "0xBb"}}, eligible)
+}
@@ -0,0 +1,518 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package refund
+
+import (
+	"database/sql"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	big_numbers "github.com/limechain/hedera-eth-bridge-validator/app/helper/big-numbers"
+	hederahelper "github.com/limechain/hedera-eth-bridge-validator/app/helper/hedera"
+	model "github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/refund"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/fee/calculator"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// actor is the component recorded in the status transitions of the service
+const actor = "Refund Service"
+
+type Service struct {
+	enabled            bool
+	feePercentage      int64
+	bridgeAccount      hedera.AccountID
+	transferRepository repository.Transfer
+	refundRepository   repository.Refund
+	unitOfWork         repository.UnitOfWork
+	distributor        service.Distributor
+	scheduledService   service.Scheduled
+	feeSettlement      service.FeeSettlement
+	pause              service.Pause
+	webhooks           service.Webhooks
+	logger             *log.Entry
+}
+
+func New(
+	refundConfig config.Refund,
+	bridgeAccount string,
+	transferRepository repository.Transfer,
+	refundRepository repository.Refund,
+	unitOfWork repository.UnitOfWork,
+	distributor service.Distributor,
+	scheduled service.Scheduled,
+	feeSettlement service.FeeSettlement,
+	pause service.Pause,
+	webhooks service.Webhooks) *Service {
+	if refundConfig.FeePercentage < calculator.MinPercentage || refundConfig.FeePercentage > calculator.MaxPercentage {
+		log.Fatalf("Invalid refund fee percentage: [%d].", refundConfig.FeePercentage)
+	}
+
+	bridgeAcc, err := hedera.AccountIDFromString(bridgeAccount)
+	if err != nil {
+		log.Fatalf("Invalid bridge account: [%s].", bridgeAccount)
+	}
+
+	return &Service{
+		enabled:            refundConfig.Enabled,
+		feePercentage:      refundConfig.FeePercentage,
+		bridgeAccount:      bridgeAcc,
+		transferRepository: transferRepository,
+		refundRepository:   refundRepository,
+		unitOfWork:         unitOfWork,
+		distributor:        distributor,
+		scheduledService:   scheduled,
+		feeSettlement:      feeSettlement,
+		pause:              pause,
+		webhooks:           webhooks,
+		logger:             config.GetLoggerFor("Refund Service"),
+	}
+}
+
+// Refund records the rejected deposit and submits a scheduled transaction,
+// returning the deposit to its sender, reduced by the refund fee. Deposits of unsupported assets are returned without a fee
+func (s *Service) Refund(deposit model.Transfer, sender, reason string) error {
+	dbTransaction, err := s.transferRepository.GetByTransactionId(deposit.TransactionId)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to get db record. Error [%s]", deposit.TransactionId, err)
+		return err
+	}
+
+	if dbTransaction != nil {
+		s.logger.Infof("[%s] - Deposit already added with status [%s]", deposit.TransactionId, dbTransaction.Status)
+		return nil
+	}
+
+	if !s.enabled {
+		return s.createRejected(deposit, sender, reason)
+	}
+
+	senderAccount, err := hedera.AccountIDFromString(sender)
+	if err != nil {
+		s.logger.Errorf("[%s] - Invalid sender [%s]. Error [%s].", deposit.TransactionId, sender, err)
+		return s.rejectWithError(deposit, sender, reason, err)
+	}
+
+	amount, err := big_numbers.ToBigInt(deposit.Amount)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to parse amount. Error [%s].", deposit.TransactionId, err)
+		return s.rejectWithError(deposit, sender, reason, err)
+	}
+
+	feeAmount, remainder := s.calculateFee(amount, reason)
+	if remainder.Sign() <= 0 {
+		s.logger.Warnf("[%s] - Deposit of [%s] does not cover the refund fee. Skipping refund.", deposit.TransactionId, deposit.Amount)
+		return s.createRejected(deposit, sender, reason)
+	}
+
+	transfers, err := s.prepareTransfers(deposit.TransactionId, senderAccount, amount, feeAmount, remainder)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to prepare refund transfers. Error [%s].", deposit.TransactionId, err)
+		return s.rejectWithError(deposit, sender, reason, err)
+	}
+
+	// The rejected deposit is recorded together with its refund and accrued fee
+	err = s.unitOfWork.Do(func(tx repository.Transaction) error {
+		_, err := tx.Transfers().CreateRejected(&deposit)
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to create a rejected transaction record. Error [%s].", deposit.TransactionId, err)
+			return err
+		}
+
+		err = tx.Refunds().Create(&entity.Refund{
+			TransferID:  deposit.TransactionId,
+			Sender:      sender,
+			NativeAsset: deposit.NativeAsset,
+			Amount:      remainder.String(),
+			Fee:         feeAmount.String(),
+			Reason:      reason,
+			Status:      refund.StatusInitial,
+		})
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to create a refund record. Error [%s].", deposit.TransactionId, err)
+			return err
+		}
+
+		if s.feeSettlement.Enabled() && feeAmount.Sign() > 0 {
+			err = s.createAccruedFeeRecord(tx.Fees(), deposit, feeAmount)
+			if err != nil {
+				s.logger.Errorf("[%s] Fee - Failed to create accrued fee record. Error [%s].", deposit.TransactionId, err)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.Infof("[%s] - Rejected deposit of [%s] [%s] from [%s]. Reason: [%s]", deposit.TransactionId, deposit.Amount, deposit.NativeAsset, sender, reason)
+
+	s.schedule(deposit.TransactionId, deposit.NativeAsset, transfers, feeAmount.String())
+	return nil
+}
+
+// createRejected records the rejected deposit, which is not refunded
+func (s *Service) createRejected(deposit model.Transfer, sender, reason string) error {
+	_, err := s.transferRepository.CreateRejected(&deposit)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to create a rejected transaction record. Error [%s].", deposit.TransactionId, err)
+		return err
+	}
+	s.logger.Infof("[%s] - Rejected deposit of [%s] [%s] from [%s]. Reason: [%s]", deposit.TransactionId, deposit.Amount, deposit.NativeAsset, sender, reason)
+	return nil
+}
+
+// rejectWithError records the rejected deposit, which cannot be refunded, and returns the cause
+func (s *Service) rejectWithError(deposit model.Transfer, sender, reason string, cause error) error {
+	err := s.createRejected(deposit, sender, reason)
+	if err != nil {
+		return err
+	}
+	return cause
+}
+
+func (s *Service) schedule(id, nativeAsset string, transfers []model.Hedera, feeAmount string) {
+	if s.pause.Queue(id, "", func() { s.schedule(id, nativeAsset, transfers, feeAmount) }) {
+		return
+	}
+
+	onExecutionSuccess, onExecutionFail := s.scheduledTxExecutionCallbacks(id, feeAmount)
+	onSuccess, onFail := s.scheduledTxMinedCallbacks(id, feeAmount)
+
+	s.scheduledService.Execute(id, nativeAsset, transfers, onExecutionSuccess, onExecutionFail, onSuccess, onFail)
+}
+
+// Reconcile schedules again the refund of the given deposit, which is not submitted yet,
+// f.e. one queued while the bridge was paused, before the node was restarted. A scheduled transaction,
+// already executed with the deposit as its memo, is recorded and awaited instead of being executed again
+func (s *Service) Reconcile(transferID string) error {
+	record, err := s.refundRepository.Get(transferID)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to get refund. Error [%s].", transferID, err)
+		return err
+	}
+	if record == nil {
+		return service.ErrNotFound
+	}
+	if record.Status != refund.StatusInitial {
+		s.logger.Errorf("[%s] - Cannot reconcile refund with status [%s].", transferID, record.Status)
+		return service.ErrInvalidStatus
+	}
+
+	deposit, err := s.transferRepository.GetByTransactionId(transferID)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to get db record. Error [%s].", transferID, err)
+		return err
+	}
+	if deposit == nil {
+		return service.ErrNotFound
+	}
+	transactionID, scheduleID, err := s.scheduledService.Find(transferID, time.Unix(deposit.Timestamp, 0).UnixNano())
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to look up the scheduled transaction. Error [%s].", transferID, err)
+		return err
+	}
+	if transactionID != "" {
+		s.logger.Infof("[%s] - Found executed scheduled transaction [%s] of schedule [%s].", transferID, transactionID, scheduleID)
+		onExecutionSuccess, _ := s.scheduledTxExecutionCallbacks(transferID, record.Fee)
+		onExecutionSuccess(transactionID, scheduleID)
+
+		onSuccess, onFail := s.scheduledTxMinedCallbacks(transferID, record.Fee)
+		s.scheduledService.Await(transactionID, onSuccess, onFail)
+		return nil
+	}
+
+	senderAccount, err := hedera.AccountIDFromString(record.Sender)
+	if err != nil {
+		s.logger.Errorf("[%s] - Invalid sender [%s]. Error [%s].", transferID, record.Sender, err)
+		return err
+	}
+	remainder, err := big_numbers.ToBigInt(record.Amount)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to parse amount [%s]. Error [%s].", transferID, record.Amount, err)
+		return err
+	}
+	feeAmount, err := big_numbers.ToBigInt(record.Fee)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to parse fee [%s]. Error [%s].", transferID, record.Fee, err)
+		return err
+	}
+
+	amount := new(big.Int).Add(remainder, feeAmount)
+	transfers, err := s.prepareTransfers(transferID, senderAccount, amount, feeAmount, remainder)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to prepare refund transfers. Error [%s].", transferID, err)
+		return err
+	}
+
+	s.schedule(transferID, record.NativeAsset, transfers, record.Fee)
+	return nil
+}
+
+// RefundData returns the refund of the given deposit
+func (s *Service) RefundData(transferID string) (service.RefundData, error) {
+	record, err := s.refundRepository.Get(transferID)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to get refund. Error [%s].", transferID, err)
+		return service.RefundData{}, err
+	}
+
+	if record == nil {
+		return service.RefundData{}, service.ErrNotFound
+	}
+
+	return service.RefundData{
+		TransferID:    record.TransferID,
+		Sender:        record.Sender,
+		NativeAsset:   record.NativeAsset,
+		Amount:        record.Amount,
+		Fee:           record.Fee,
+		Reason:        record.Reason,
+		Status:        record.Status,
+		TransactionID: record.TransactionID.String,
+	}, nil
+}
+
+// calculateFee returns the refund fee of the deposit and the refunded remainder. No fee is taken from
+// deposits of unsupported assets, since it would be paid out to the members in an asset the bridge does not support
+func (s *Service) calculateFee(amount *big.Int, reason string) (feeAmount, remainder *big.Int) {
+	if reason == refund.ReasonUnsupportedAsset {
+		return big.NewInt(0), new(big.Int).Set(amount)
+	}
+
+	feeAmount = new(big.Int).Mul(amount, big.NewInt(s.feePercentage))
+	feeAmount.Quo(feeAmount, big.NewInt(calculator.MaxPercentage))
+	return feeAmount, new(big.Int).Sub(amount, feeAmount)
+}
+
+func (s *Service) prepareTransfers(id string, sender hedera.AccountID, amount, feeAmount, remainder *big.Int) (transfers []model.Hedera, err error) {
+	// Hedera transfers are in int64 units
+	hederaRemainder, err := big_numbers.ToInt64(remainder)
+	if err != nil {
+		return nil, err
+	}
+
+	// Accrued fees remain in the bridge account until their batch is settled
+	if s.feeSettlement.Enabled() {
+		return []model.Hedera{
+			{
+				AccountID: sender,
+				Amount:    hederaRemainder,
+			},
+			{
+				AccountID: s.bridgeAccount,
+				Amount:    -hederaRemainder,
+			},
+		}, nil
+	}
+
+	hederaAmount, err := big_numbers.ToInt64(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if feeAmount.Sign() > 0 {
+		transfers, err = s.distributor.CalculateMemberDistribution(id, feeAmount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(transfers,
+		model.Hedera{
+			AccountID: sender,
+			Amount:    hederaRemainder,
+		},
+		model.Hedera{
+			AccountID: s.bridgeAccount,
+			Amount:    -hederaAmount,
+		}), nil
+}
+
+// createAccruedFeeRecord persists the refund fee as owed to the members. It is paid out with the
+// settlement of the batch, corresponding to the valid start timestamp of the deposit.
+func (s *Service) createAccruedFeeRecord(feeRepository repository.Fee, deposit model.Transfer, feeAmount *big.Int) error {
+	txId, err := hederahelper.FromMirrorNodeTransactionID(deposit.TransactionId)
+	if err != nil {
+		return err
+	}
+
+	validStart, err := strconv.ParseInt(txId.Seconds, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	return feeRepository.Create(&entity.Fee{
+		TransactionID: deposit.TransactionId,
+		Amount:        feeAmount.String(),
+		Status:        fee.StatusAccrued,
+		TransferID: sql.NullString{
+			String: deposit.TransactionId,
+			Valid:  true,
+		},
+		NativeAsset: deposit.NativeAsset,
+		Batch:       s.feeSettlement.Batch(validStart),
+	})
+}
+
+// scheduledTxExecutionCallbacks update the refund together with its fee, so that they are
+// either both recorded or none of them is
+func (s *Service) scheduledTxExecutionCallbacks(id, feeAmount string) (onExecutionSuccess func(transactionID, scheduleID string), onExecutionFail func(transactionID string)) {
+	payFee := s.paysFee(feeAmount)
+
+	onExecutionSuccess = func(transactionID, scheduleID string) {
+		s.logger.Debugf("[%s] - Updating db status to Submitted with TransactionID [%s].", id, transactionID)
+		err := s.unitOfWork.Do(func(tx repository.Transaction) error {
+			err := tx.Refunds().UpdateStatusSubmitted(id, scheduleID, transactionID, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxSubmitted})
+			if err != nil {
+				s.logger.Errorf(
+					"[%s] - Failed to update submitted status with TransactionID [%s], ScheduleID [%s]. Error [%s].",
+					id, transactionID, scheduleID, err)
+				return err
+			}
+
+			if !payFee {
+				return nil
+			}
+
+			err = tx.Fees().Create(&entity.Fee{
+				TransactionID: transactionID,
+				ScheduleID: sql.NullString{
+					String: scheduleID,
+					Valid:  true,
+				},
+				Amount: feeAmount,
+				Status: fee.StatusSubmitted,
+				TransferID: sql.NullString{
+					String: id,
+					Valid:  true,
+				},
+			})
+			if err != nil {
+				s.logger.Errorf("[%s] Fee - Failed to create Fee Record [%s]. Error [%s].", id, transactionID, err)
+			}
+			return err
+		})
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to record submitted scheduled transaction [%s]. Error [%s].", id, transactionID, err)
+		}
+	}
+
+	onExecutionFail = func(transactionID string) {
+		err := s.unitOfWork.Do(func(tx repository.Transaction) error {
+			err := tx.Refunds().UpdateStatusFailed(id, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxSubmissionFailed})
+			if err != nil {
+				s.logger.Errorf("[%s] - Failed to update status failed. Error [%s].", id, err)
+				return err
+			}
+
+			if !payFee {
+				return nil
+			}
+
+			err = tx.Fees().Create(&entity.Fee{
+				TransactionID: transactionID,
+				Amount:        feeAmount,
+				Status:        fee.StatusFailed,
+				TransferID: sql.NullString{
+					String: id,
+					Valid:  true,
+				},
+			})
+			if err != nil {
+				s.logger.Errorf("[%s] Fee - Failed to create failed record. Error [%s].", id, err)
+			}
+			return err
+		})
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to record failed execution. Error [%s].", id, err)
+		}
+	}
+
+	return onExecutionSuccess, onExecutionFail
+}
+
+func (s *Service) scheduledTxMinedCallbacks(id, feeAmount string) (onSuccess, onFail func(transactionID string)) {
+	payFee := s.paysFee(feeAmount)
+
+	onSuccess = func(transactionID string) {
+		s.logger.Debugf("[%s] - Scheduled refund execution successful.", id)
+		err := s.unitOfWork.Do(func(tx repository.Transaction) error {
+			err := tx.Refunds().UpdateStatusCompleted(id, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxExecuted})
+			if err != nil {
+				s.logger.Errorf("[%s] - Failed to update status completed. Error [%s].", id, err)
+				return err
+			}
+
+			if !payFee {
+				return nil
+			}
+
+			err = tx.Fees().UpdateStatusCompleted(transactionID, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxExecuted})
+			if err != nil {
+				s.logger.Errorf("[%s] Fee - Failed to update status completed. Error [%s].", transactionID, err)
+			}
+			return err
+		})
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to record completed scheduled transaction [%s]. Error [%s].", id, transactionID, err)
+			return
+		}
+		if payFee {
+			s.webhooks.Notify(service.WebhookFeeCompleted, service.WebhookData{TransferID: id, TransactionID: transactionID})
+		}
+	}
+
+	onFail = func(transactionID string) {
+		s.logger.Debugf("[%s] - Scheduled refund execution failed.", id)
+		err := s.unitOfWork.Do(func(tx repository.Transaction) error {
+			err := tx.Refunds().UpdateStatusFailed(id, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxFailed})
+			if err != nil {
+				s.logger.Errorf("[%s] - Failed to update status failed. Error [%s].", id, err)
+				return err
+			}
+
+			if !payFee {
+				return nil
+			}
+
+			err = tx.Fees().UpdateStatusFailed(transactionID, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxFailed})
+			if err != nil {
+				s.logger.Errorf("[%s] Fee - Failed to update status failed. Error [%s].", transactionID, err)
+			}
+			return err
+		})
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to record failed scheduled transaction [%s]. Error [%s].", id, transactionID, err)
+		}
+	}
+
+	return onSuccess, onFail
+}
+
+// paysFee returns whether the fee is paid out with the refund. It is not, if the fee is accrued or there is no fee
+func (s *Service) paysFee(feeAmount string) bool {
+	return !s.feeSettlement.Enabled() && feeAmount != "0"
+}
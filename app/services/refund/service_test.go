@@ -0,0 +1,369 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package refund
+
+import (
+	"database/sql"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	model "github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	feeStatus "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/refund"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/constants"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	bridgeAccount = "0.0.100"
+	sender        = "0.0.200"
+	deposit       = model.Transfer{
+		TransactionId: "0.0.200-1620000000-000000000",
+		NativeAsset:   constants.Hbar,
+		Amount:        "1000",
+		RouterAddress: "0x0000000000000000000000000000000000000001",
+	}
+)
+
+func newService(enabled, feeSettlement bool) *Service {
+	mocks.Setup()
+	mocks.MFeeSettlementService.On("Enabled").Return(feeSettlement)
+	mocks.MPauseService.On("Queue", deposit.TransactionId, "", mock.Anything).Return(false)
+	mocks.MWebhooksService.On("Notify", mock.Anything, mock.Anything).Return()
+	return New(
+		config.Refund{Enabled: enabled, FeePercentage: 10000},
+		bridgeAccount,
+		mocks.MTransferRepository,
+		mocks.MRefundRepository,
+		mocks.MUnitOfWork,
+		mocks.MDistributorService,
+		mocks.MScheduledService,
+		mocks.MFeeSettlementService,
+		mocks.MPauseService,
+		mocks.MWebhooksService)
+}
+
+func Test_Refund(t *testing.T) {
+	s := newService(true, false)
+	distribution := []model.Hedera{{AccountID: hedera.AccountID{Account: 300}, Amount: 100}}
+	expectedTransfers := append(distribution,
+		model.Hedera{AccountID: hedera.AccountID{Account: 200}, Amount: 900},
+		model.Hedera{AccountID: hedera.AccountID{Account: 100}, Amount: -1000})
+
+	mocks.MTransferRepository.On("GetByTransactionId", deposit.TransactionId).Return((*entity.Transfer)(nil), nil)
+	mocks.MTransferRepository.On("CreateRejected", &deposit).Return(&entity.Transfer{}, nil)
+	mocks.MDistributorService.On("CalculateMemberDistribution", deposit.TransactionId, big.NewInt(100)).Return(distribution, nil)
+	mocks.MRefundRepository.On("Create", mock.Anything).Return(nil)
+	mocks.MScheduledService.On("Execute", deposit.TransactionId, constants.Hbar, expectedTransfers).Return()
+
+	err := s.Refund(deposit, sender, refund.ReasonInvalidMemo)
+
+	assert.Nil(t, err)
+	mocks.MRefundRepository.AssertCalled(t, "Create", &entity.Refund{
+		TransferID:  deposit.TransactionId,
+		Sender:      sender,
+		NativeAsset: constants.Hbar,
+		Amount:      "900",
+		Fee:         "100",
+		Reason:      refund.ReasonInvalidMemo,
+		Status:      refund.StatusInitial,
+	})
+	mocks.MScheduledService.AssertCalled(t, "Execute", deposit.TransactionId, constants.Hbar, expectedTransfers)
+}
+
+func Test_RefundQueuedWhilePaused(t *testing.T) {
+	s := newService(true, false)
+	mocks.MPauseService.ExpectedCalls = nil
+	mocks.MPauseService.On("Queue", deposit.TransactionId, "", mock.Anything).Return(true)
+
+	mocks.MTransferRepository.On("GetByTransactionId", deposit.TransactionId).Return((*entity.Transfer)(nil), nil)
+	mocks.MTransferRepository.On("CreateRejected", &deposit).Return(&entity.Transfer{}, nil)
+	mocks.MDistributorService.On("CalculateMemberDistribution", deposit.TransactionId, big.NewInt(100)).Return([]model.Hedera{}, nil)
+	mocks.MRefundRepository.On("Create", mock.Anything).Return(nil)
+
+	err := s.Refund(deposit, sender, refund.ReasonInvalidMemo)
+
+	assert.Nil(t, err)
+	mocks.MRefundRepository.AssertCalled(t, "Create", mock.Anything)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_Reconcile(t *testing.T) {
+	s := newService(true, true)
+	expectedTransfers := []model.Hedera{
+		{AccountID: hedera.AccountID{Account: 200}, Amount: 900},
+		{AccountID: hedera.AccountID{Account: 100}, Amount: -900},
+	}
+	mocks.MRefundRepository.On("Get", deposit.TransactionId).Return(&entity.Refund{
+		TransferID:  deposit.TransactionId,
+		Sender:      sender,
+		NativeAsset: constants.Hbar,
+		Amount:      "900",
+		Fee:         "100",
+		Status:      refund.StatusInitial,
+	}, nil)
+	mocks.MTransferRepository.On("GetByTransactionId", deposit.TransactionId).Return(&entity.Transfer{Timestamp: 1620000000}, nil)
+	mocks.MScheduledService.On("Find", deposit.TransactionId, int64(1620000000000000000)).Return("", "", nil)
+	mocks.MScheduledService.On("Execute", deposit.TransactionId, constants.Hbar, expectedTransfers).Return()
+
+	err := s.Reconcile(deposit.TransactionId)
+
+	assert.Nil(t, err)
+	mocks.MScheduledService.AssertCalled(t, "Execute", deposit.TransactionId, constants.Hbar, expectedTransfers)
+}
+
+func Test_ReconcileAlreadyExecuted(t *testing.T) {
+	s := newService(true, true)
+	mocks.MRefundRepository.On("Get", deposit.TransactionId).Return(&entity.Refund{
+		TransferID: deposit.TransactionId,
+		Fee:        "100",
+		Status:     refund.StatusInitial,
+	}, nil)
+	mocks.MTransferRepository.On("GetByTransactionId", deposit.TransactionId).Return(&entity.Transfer{Timestamp: 1620000000}, nil)
+	mocks.MScheduledService.On("Find", deposit.TransactionId, int64(1620000000000000000)).Return("0.0.1-1-1", "0.0.2", nil)
+	mocks.MRefundRepository.On("UpdateStatusSubmitted", deposit.TransactionId, "0.0.2", "0.0.1-1-1", mock.Anything).Return(nil)
+	mocks.MScheduledService.On("Await", "0.0.1-1-1").Return()
+
+	err := s.Reconcile(deposit.TransactionId)
+
+	assert.Nil(t, err)
+	mocks.MRefundRepository.AssertCalled(t, "UpdateStatusSubmitted", deposit.TransactionId, "0.0.2", "0.0.1-1-1", mock.Anything)
+	mocks.MScheduledService.AssertCalled(t, "Await", "0.0.1-1-1")
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_ReconcileSubmitted(t *testing.T) {
+	s := newService(true, true)
+	mocks.MRefundRepository.On("Get", deposit.TransactionId).Return(&entity.Refund{Status: refund.StatusSubmitted}, nil)
+
+	err := s.Reconcile(deposit.TransactionId)
+
+	assert.Equal(t, service.ErrInvalidStatus, err)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_RefundAccruesFee(t *testing.T) {
+	s := newService(true, true)
+	expectedTransfers := []model.Hedera{
+		{AccountID: hedera.AccountID{Account: 200}, Amount: 900},
+		{AccountID: hedera.AccountID{Account: 100}, Amount: -900},
+	}
+
+	mocks.MTransferRepository.On("GetByTransactionId", deposit.TransactionId).Return((*entity.Transfer)(nil), nil)
+	mocks.MTransferRepository.On("CreateRejected", &deposit).Return(&entity.Transfer{}, nil)
+	mocks.MRefundRepository.On("Create", mock.Anything).Return(nil)
+	mocks.MFeeSettlementService.On("Batch", int64(1620000000)).Return(int64(1619999000))
+	mocks.MFeeRepository.On("Create", mock.Anything).Return(nil)
+	mocks.MScheduledService.On("Execute", deposit.TransactionId, constants.Hbar, expectedTransfers).Return()
+
+	err := s.Refund(deposit, sender, refund.ReasonInvalidMemo)
+
+	assert.Nil(t, err)
+	mocks.MDistributorService.AssertNotCalled(t, "CalculateMemberDistribution", mock.Anything, mock.Anything)
+	mocks.MFeeRepository.AssertCalled(t, "Create", &entity.Fee{
+		TransactionID: deposit.TransactionId,
+		Amount:        "100",
+		Status:        feeStatus.StatusAccrued,
+		TransferID:    sql.NullString{String: deposit.TransactionId, Valid: true},
+		NativeAsset:   constants.Hbar,
+		Batch:         1619999000,
+	})
+	mocks.MScheduledService.AssertCalled(t, "Execute", deposit.TransactionId, constants.Hbar, expectedTransfers)
+}
+
+func Test_RefundUnsupportedAssetWithoutFee(t *testing.T) {
+	for _, feeSettlement := range []bool{false, true} {
+		s := newService(true, feeSettlement)
+		unsupported := deposit
+		unsupported.NativeAsset = "0.0.400"
+		expectedTransfers := []model.Hedera{
+			{AccountID: hedera.AccountID{Account: 200}, Amount: 1000},
+			{AccountID: hedera.AccountID{Account: 100}, Amount: -1000},
+		}
+
+		mocks.MTransferRepository.On("GetByTransactionId", unsupported.TransactionId).Return((*entity.Transfer)(nil), nil)
+		mocks.MTransferRepository.On("CreateRejected", &unsupported).Return(&entity.Transfer{}, nil)
+		mocks.MRefundRepository.On("Create", mock.Anything).Return(nil)
+		mocks.MScheduledService.On("Execute", unsupported.TransactionId, unsupported.NativeAsset, expectedTransfers).Return()
+
+		err := s.Refund(unsupported, sender, refund.ReasonUnsupportedAsset)
+
+		assert.Nil(t, err)
+		mocks.MRefundRepository.AssertCalled(t, "Create", &entity.Refund{
+			TransferID:  unsupported.TransactionId,
+			Sender:      sender,
+			NativeAsset: unsupported.NativeAsset,
+			Amount:      "1000",
+			Fee:         "0",
+			Reason:      refund.ReasonUnsupportedAsset,
+			Status:      refund.StatusInitial,
+		})
+		mocks.MDistributorService.AssertNotCalled(t, "CalculateMemberDistribution", mock.Anything, mock.Anything)
+		mocks.MFeeRepository.AssertNotCalled(t, "Create", mock.Anything)
+		mocks.MScheduledService.AssertCalled(t, "Execute", unsupported.TransactionId, unsupported.NativeAsset, expectedTransfers)
+	}
+}
+
+func Test_RefundAlreadyAdded(t *testing.T) {
+	s := newService(true, false)
+	mocks.MTransferRepository.On("GetByTransactionId", deposit.TransactionId).Return(&entity.Transfer{TransactionID: deposit.TransactionId}, nil)
+
+	err := s.Refund(deposit, sender, refund.ReasonInvalidMemo)
+
+	assert.Nil(t, err)
+	mocks.MTransferRepository.AssertNotCalled(t, "CreateRejected", mock.Anything)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_RefundDisabled(t *testing.T) {
+	s := newService(false, false)
+	mocks.MTransferRepository.On("GetByTransactionId", deposit.TransactionId).Return((*entity.Transfer)(nil), nil)
+	mocks.MTransferRepository.On("CreateRejected", &deposit).Return(&entity.Transfer{}, nil)
+
+	err := s.Refund(deposit, sender, refund.ReasonInvalidMemo)
+
+	assert.Nil(t, err)
+	mocks.MTransferRepository.AssertCalled(t, "CreateRejected", &deposit)
+	mocks.MRefundRepository.AssertNotCalled(t, "Create", mock.Anything)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_RefundDoesNotCoverFee(t *testing.T) {
+	s := newService(true, false)
+	s.feePercentage = 100000
+	mocks.MTransferRepository.On("GetByTransactionId", deposit.TransactionId).Return((*entity.Transfer)(nil), nil)
+	mocks.MTransferRepository.On("CreateRejected", &deposit).Return(&entity.Transfer{}, nil)
+
+	err := s.Refund(deposit, sender, refund.ReasonInvalidMemo)
+
+	assert.Nil(t, err)
+	mocks.MRefundRepository.AssertNotCalled(t, "Create", mock.Anything)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_RefundInvalidSender(t *testing.T) {
+	s := newService(true, false)
+	mocks.MTransferRepository.On("GetByTransactionId", deposit.TransactionId).Return((*entity.Transfer)(nil), nil)
+	mocks.MTransferRepository.On("CreateRejected", &deposit).Return(&entity.Transfer{}, nil)
+
+	err := s.Refund(deposit, "invalid", refund.ReasonInvalidMemo)
+
+	assert.Error(t, err)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_ScheduledCallbacks(t *testing.T) {
+	s := newService(true, false)
+	mocks.MRefundRepository.On("UpdateStatusSubmitted", deposit.TransactionId, "0.0.555", "0.0.100-1620000001-000000000", mock.Anything).Return(nil)
+	mocks.MRefundRepository.On("UpdateStatusCompleted", deposit.TransactionId, mock.Anything).Return(nil)
+	mocks.MFeeRepository.On("Create", mock.Anything).Return(nil)
+	mocks.MFeeRepository.On("UpdateStatusCompleted", "0.0.100-1620000001-000000000", mock.Anything).Return(nil)
+
+	onExecutionSuccess, _ := s.scheduledTxExecutionCallbacks(deposit.TransactionId, "100")
+	onSuccess, _ := s.scheduledTxMinedCallbacks(deposit.TransactionId, "100")
+	onExecutionSuccess("0.0.100-1620000001-000000000", "0.0.555")
+	onSuccess("0.0.100-1620000001-000000000")
+
+	mocks.MRefundRepository.AssertCalled(t, "UpdateStatusSubmitted", deposit.TransactionId, "0.0.555", "0.0.100-1620000001-000000000", mock.Anything)
+	mocks.MRefundRepository.AssertCalled(t, "UpdateStatusCompleted", deposit.TransactionId, mock.Anything)
+	mocks.MFeeRepository.AssertCalled(t, "Create", &entity.Fee{
+		TransactionID: "0.0.100-1620000001-000000000",
+		ScheduleID:    sql.NullString{String: "0.0.555", Valid: true},
+		Amount:        "100",
+		Status:        feeStatus.StatusSubmitted,
+		TransferID:    sql.NullString{String: deposit.TransactionId, Valid: true},
+	})
+	mocks.MWebhooksService.AssertCalled(t, "Notify", service.WebhookFeeCompleted, service.WebhookData{TransferID: deposit.TransactionId, TransactionID: "0.0.100-1620000001-000000000"})
+}
+
+func Test_RefundCreateFails(t *testing.T) {
+	s := newService(true, false)
+	mocks.MTransferRepository.On("GetByTransactionId", deposit.TransactionId).Return((*entity.Transfer)(nil), nil)
+	mocks.MTransferRepository.On("CreateRejected", &deposit).Return(&entity.Transfer{}, nil)
+	mocks.MDistributorService.On("CalculateMemberDistribution", deposit.TransactionId, big.NewInt(100)).Return([]model.Hedera{}, nil)
+	mocks.MRefundRepository.On("Create", mock.Anything).Return(errors.New("some-error"))
+
+	err := s.Refund(deposit, sender, refund.ReasonInvalidMemo)
+
+	assert.Error(t, err)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_ScheduledCallbacksWithoutFee(t *testing.T) {
+	s := newService(true, false)
+	mocks.MRefundRepository.On("UpdateStatusCompleted", deposit.TransactionId, mock.Anything).Return(nil)
+
+	onSuccess, _ := s.scheduledTxMinedCallbacks(deposit.TransactionId, "0")
+	onSuccess("0.0.100-1620000001-000000000")
+
+	mocks.MRefundRepository.AssertCalled(t, "UpdateStatusCompleted", deposit.TransactionId, mock.Anything)
+	mocks.MFeeRepository.AssertNotCalled(t, "UpdateStatusCompleted", mock.Anything, mock.Anything)
+	mocks.MWebhooksService.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
+
+func Test_RefundData(t *testing.T) {
+	s := newService(true, false)
+	mocks.MRefundRepository.On("Get", deposit.TransactionId).Return(&entity.Refund{
+		TransferID:    deposit.TransactionId,
+		Sender:        sender,
+		NativeAsset:   constants.Hbar,
+		Amount:        "900",
+		Fee:           "100",
+		Reason:        refund.ReasonInvalidMemo,
+		Status:        refund.StatusCompleted,
+		TransactionID: sql.NullString{String: "0.0.100-1620000001-000000000", Valid: true},
+	}, nil)
+
+	data, err := s.RefundData(deposit.TransactionId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, service.RefundData{
+		TransferID:    deposit.TransactionId,
+		Sender:        sender,
+		NativeAsset:   constants.Hbar,
+		Amount:        "900",
+		Fee:           "100",
+		Reason:        refund.ReasonInvalidMemo,
+		Status:        refund.StatusCompleted,
+		TransactionID: "0.0.100-1620000001-000000000",
+	}, data)
+}
+
+func Test_RefundDataNotFound(t *testing.T) {
+	s := newService(true, false)
+	mocks.MRefundRepository.On("Get", deposit.TransactionId).Return(nil, nil)
+
+	_, err := s.RefundData(deposit.TransactionId)
+
+	assert.Equal(t, service.ErrNotFound, err)
+}
+
+func Test_RefundDataFails(t *testing.T) {
+	s := newService(true, false)
+	mocks.MRefundRepository.On("Get", deposit.TransactionId).Return(nil, errors.New("connection refused"))
+
+	_, err := s.RefundData(deposit.TransactionId)
+
+	assert.Error(t, err)
+}
@@ -10,6 +10,9 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// scheduleCreate is the name of the schedule create transactions on the mirror node
+const scheduleCreate = "SCHEDULECREATE"
+
 type Service struct {
 	payerAccount     hedera.AccountID
 	hederaNodeClient client.HederaNode
@@ -75,6 +78,11 @@ func (s *Service) Execute(
 	transactionID := hederahelper.ToMirrorNodeTransactionID(txReceipt.ScheduledTransactionID.String())
 	onExecutionSuccess(transactionID, txReceipt.ScheduleID.String())
 
+	s.Await(transactionID, onSuccess, onFail)
+}
+
+// Await waits for the outcome of the submitted scheduled transaction on the mirror node and executes the provided functions
+func (s *Service) Await(transactionID string, onSuccess, onFail func(transactionID string)) {
 	onMinedSuccess := func() {
 		onSuccess(transactionID)
 	}
@@ -86,6 +94,52 @@ func (s *Service) Execute(
 	s.mirrorNodeClient.WaitForScheduledTransferTransaction(transactionID, onMinedSuccess, onMinedFail)
 }
 
+// Find returns the executed scheduled transaction, whose schedule has the given memo, submitted after the given
+// timestamp (in nanoseconds), and its schedule id. Returns empty ids, if there is none.
+// Scheduled transactions are paid by the payer account, so they are looked up among its debits
+func (s *Service) Find(memo string, from int64) (transactionID, scheduleID string, err error) {
+	transactions, err := s.mirrorNodeClient.GetScheduledTransactionsAfterTimestamp(s.payerAccount, from)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to get scheduled transactions of [%s]. Error [%s].", memo, s.payerAccount, err)
+		return "", "", err
+	}
+
+	for _, t := range transactions {
+		scheduleID, err = s.scheduleOf(t.TransactionID)
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to get the schedule of TX [%s]. Error [%s].", memo, t.TransactionID, err)
+			return "", "", err
+		}
+		if scheduleID == "" {
+			continue
+		}
+
+		schedule, err := s.mirrorNodeClient.GetSchedule(scheduleID)
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to get schedule [%s]. Error [%s].", memo, scheduleID, err)
+			return "", "", err
+		}
+		if schedule.Memo == memo {
+			return t.TransactionID, scheduleID, nil
+		}
+	}
+	return "", "", nil
+}
+
+// scheduleOf returns the id of the schedule, created by the transaction with the same id as the scheduled transaction
+func (s *Service) scheduleOf(transactionID string) (string, error) {
+	response, err := s.mirrorNodeClient.GetTransaction(transactionID)
+	if err != nil {
+		return "", err
+	}
+	for _, t := range response.Transactions {
+		if t.Name == scheduleCreate {
+			return t.EntityId, nil
+		}
+	}
+	return "", nil
+}
+
 func (s *Service) executeScheduledTransaction(id, nativeAsset string, transfers []transfer.Hedera) (*hedera.TransactionResponse, error) {
 	var tokenID hedera.TokenID
 	var transactionResponse *hedera.TransactionResponse
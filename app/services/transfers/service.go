@@ -27,6 +27,7 @@ import (
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/client"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	big_numbers "github.com/limechain/hedera-eth-bridge-validator/app/helper/big-numbers"
 	hederahelper "github.com/limechain/hedera-eth-bridge-validator/app/helper/hedera"
 	memo "github.com/limechain/hedera-eth-bridge-validator/app/helper/memo"
 	auth_message "github.com/limechain/hedera-eth-bridge-validator/app/model/auth-message"
@@ -34,11 +35,17 @@ import (
 	model "github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/status-transition"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/transfer"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	log "github.com/sirupsen/logrus"
+	"math/big"
 	"strconv"
 )
 
+// actor is the component recorded in the status transitions of the service
+const actor = "Transfers Service"
+
 type Service struct {
 	logger             *log.Entry
 	hederaNode         client.HederaNode
@@ -47,9 +54,17 @@ type Service struct {
 	ethSigner          service.Signer
 	transferRepository repository.Transfer
 	feeRepository      repository.Fee
+	statusTransitions  repository.StatusTransition
 	distributor        service.Distributor
 	feeService         service.Fee
 	scheduledService   service.Scheduled
+	feeSettlement      service.FeeSettlement
+	quorum             service.Quorum
+	pending            service.PendingSignatures
+	decimals           service.Decimals
+	pause              service.Pause
+	archive            service.Archive
+	webhooks           service.Webhooks
 	topicID            hedera.TopicID
 	bridgeAccountID    hedera.AccountID
 }
@@ -61,11 +76,19 @@ func NewService(
 	signer service.Signer,
 	transferRepository repository.Transfer,
 	feeRepository repository.Fee,
+	statusTransitions repository.StatusTransition,
 	feeService service.Fee,
 	distributor service.Distributor,
 	topicID string,
 	bridgeAccount string,
 	scheduledService service.Scheduled,
+	feeSettlement service.FeeSettlement,
+	quorum service.Quorum,
+	pending service.PendingSignatures,
+	decimals service.Decimals,
+	pause service.Pause,
+	archive service.Archive,
+	webhooks service.Webhooks,
 ) *Service {
 	tID, e := hedera.TopicIDFromString(topicID)
 	if e != nil {
@@ -84,11 +107,19 @@ func NewService(
 		ethSigner:          signer,
 		transferRepository: transferRepository,
 		feeRepository:      feeRepository,
+		statusTransitions:  statusTransitions,
 		topicID:            tID,
 		feeService:         feeService,
 		distributor:        distributor,
 		bridgeAccountID:    bridgeAccountID,
 		scheduledService:   scheduledService,
+		feeSettlement:      feeSettlement,
+		quorum:             quorum,
+		pending:            pending,
+		decimals:           decimals,
+		pause:              pause,
+		archive:            archive,
+		webhooks:           webhooks,
 	}
 }
 
@@ -110,7 +141,7 @@ func (ts *Service) SanityCheckTransfer(tx mirror_node.Transaction) (string, erro
 	}
 
 	if !verified {
-		return "", errors.New("invalid state proof")
+		return "", service.ErrInvalidStateProof
 	}
 
 	return m, nil
@@ -118,7 +149,7 @@ func (ts *Service) SanityCheckTransfer(tx mirror_node.Transaction) (string, erro
 
 // InitiateNewTransfer Stores the incoming transfer message into the Database aware of already processed transfers
 func (ts *Service) InitiateNewTransfer(tm model.Transfer) (*entity.Transfer, error) {
-	dbTransaction, err := ts.transferRepository.GetByTransactionId(tm.TransactionId)
+	dbTransaction, err := ts.recorded(tm.TransactionId)
 	if err != nil {
 		ts.logger.Errorf("[%s] - Failed to get db record. Error [%s]", tm.TransactionId, err)
 		return nil, err
@@ -130,16 +161,52 @@ func (ts *Service) InitiateNewTransfer(tm model.Transfer) (*entity.Transfer, err
 	}
 
 	ts.logger.Debugf("[%s] - Adding new Transaction Record", tm.TransactionId)
-	tx, err := ts.transferRepository.Create(&tm)
+	signers, requiredSignatures := ts.quorum.Snapshot()
+	tx, err := ts.transferRepository.Create(&tm, signers, requiredSignatures)
 	if err != nil {
 		ts.logger.Errorf("[%s] - Failed to create a transaction record. Error [%s].", tm.TransactionId, err)
 		return nil, err
 	}
+	ts.notifyTransferCreated(tm)
 	return tx, nil
 }
 
+// HoldTransfer stores the incoming transfer, which is outside of the asset policy, as held for manual review
+func (ts *Service) HoldTransfer(tm model.Transfer, violation string) error {
+	dbTransaction, err := ts.recorded(tm.TransactionId)
+	if err != nil {
+		ts.logger.Errorf("[%s] - Failed to get db record. Error [%s]", tm.TransactionId, err)
+		return err
+	}
+
+	if dbTransaction != nil {
+		ts.logger.Infof("[%s] - Transaction already added", tm.TransactionId)
+		return nil
+	}
+
+	_, err = ts.transferRepository.CreateHeld(&tm, violation)
+	if err != nil {
+		ts.logger.Errorf("[%s] - Failed to create a held transaction record. Error [%s].", tm.TransactionId, err)
+		return err
+	}
+	ts.logger.Warnf("[%s] - Transfer of [%s] [%s] to [%s] held for manual review. Violation: [%s]", tm.TransactionId, tm.Amount, tm.NativeAsset, tm.Receiver, violation)
+	ts.notifyTransferCreated(tm)
+	return nil
+}
+
+// recorded returns the transfer, if it is already recorded. Pruned transfers are looked up in the
+// archive, so that a recovery or a reset checkpoint, covering archived history, does not record them again
+func (ts *Service) recorded(txId string) (*entity.Transfer, error) {
+	t, err := ts.transferRepository.GetByTransactionId(txId)
+	if err != nil || t != nil {
+		return t, err
+	}
+	return ts.archive.Transfer(txId)
+}
+
 // SaveRecoveredTxn creates new Transaction record persisting the recovered Transfer TXn
 func (ts *Service) SaveRecoveredTxn(txId, amount, nativeAsset, wrappedAsset string, memo string) error {
+	signers, requiredSignatures := ts.quorum.Snapshot()
 	err := ts.transferRepository.SaveRecoveredTxn(&model.Transfer{
 		TransactionId: txId,
 		RouterAddress: ts.contractsService.Address().String(),
@@ -147,20 +214,27 @@ func (ts *Service) SaveRecoveredTxn(txId, amount, nativeAsset, wrappedAsset stri
 		Amount:        amount,
 		NativeAsset:   nativeAsset,
 		WrappedAsset:  wrappedAsset,
-	})
+	}, signers, requiredSignatures)
 	if err != nil {
 		ts.logger.Errorf("[%s] - Something went wrong while saving new Recovered Transaction. Error [%s]", txId, err)
 		return err
 	}
 
 	ts.logger.Infof("Added new Transaction Record with Txn ID [%s]", txId)
+	ts.webhooks.Notify(service.WebhookTransferCreated, service.WebhookData{
+		TransferID:   txId,
+		Receiver:     memo,
+		Amount:       amount,
+		NativeAsset:  nativeAsset,
+		WrappedAsset: wrappedAsset,
+	})
 	return err
 }
 
 func (ts *Service) authMessageSubmissionCallbacks(txId string) (onSuccess, onRevert func()) {
 	onSuccess = func() {
 		ts.logger.Debugf("Authorisation Signature TX successfully executed for TX [%s]", txId)
-		err := ts.transferRepository.UpdateStatusSignatureMined(txId)
+		err := ts.transferRepository.UpdateStatusSignatureMined(txId, repository.Cause{Actor: actor, Reason: status_transition.ReasonSignatureMined})
 		if err != nil {
 			ts.logger.Errorf("[%s] - Failed to update status signature mined. Error [%s].", txId, err)
 			return
@@ -169,7 +243,7 @@ func (ts *Service) authMessageSubmissionCallbacks(txId string) (onSuccess, onRev
 
 	onRevert = func() {
 		ts.logger.Debugf("Authorisation Signature TX failed for TX ID [%s]", txId)
-		err := ts.transferRepository.UpdateStatusSignatureFailed(txId)
+		err := ts.transferRepository.UpdateStatusSignatureFailed(txId, repository.Cause{Actor: actor, Reason: status_transition.ReasonSignatureFailed})
 		if err != nil {
 			ts.logger.Errorf("[%s] - Failed to update status signature failed. Error [%s].", txId, err)
 			return
@@ -179,23 +253,51 @@ func (ts *Service) authMessageSubmissionCallbacks(txId string) (onSuccess, onRev
 }
 
 func (ts *Service) ProcessTransfer(tm model.Transfer) error {
-	intAmount, err := strconv.ParseInt(tm.Amount, 10, 64)
+	queued := ts.pause.Queue(tm.TransactionId, tm.WrappedAsset, func() {
+		err := ts.ProcessTransfer(tm)
+		if err != nil {
+			ts.logger.Errorf("[%s] - Processing of queued transfer failed. Error: [%s]", tm.TransactionId, err)
+		}
+	})
+	if queued {
+		return nil
+	}
+
+	fee, wrappedAmount, err := ts.calculateFee(tm)
 	if err != nil {
-		ts.logger.Errorf("[%s] - Failed to parse amount. Error: [%s]", tm.TransactionId, err)
 		return err
 	}
 
-	fee, remainder := ts.feeService.CalculateFee(intAmount)
-	validFee := ts.distributor.ValidAmount(fee)
-	if validFee != fee {
-		remainder += fee - validFee
+	err = ts.processFee(tm, fee)
+	if err != nil {
+		return err
 	}
+	return ts.submitSignature(tm, wrappedAmount)
+}
 
-	go ts.processFeeTransfer(tm.TransactionId, validFee, tm.NativeAsset)
-
-	wrappedAmount := strconv.FormatInt(remainder, 10)
+// processFee records the zero or accrued fee of the transfer or pays it out to the members
+func (ts *Service) processFee(tm model.Transfer, fee *big.Int) error {
+	if fee.Sign() == 0 {
+		err := ts.createZeroFeeRecord(tm.TransactionId)
+		if err != nil {
+			return err
+		}
+		ts.pending.Release(tm.TransactionId)
+	} else if ts.feeSettlement.Enabled() {
+		err := ts.createAccruedFeeRecord(tm.TransactionId, fee, tm.NativeAsset)
+		if err != nil {
+			return err
+		}
+		ts.pending.Release(tm.TransactionId)
+	} else {
+		go ts.processFeeTransfer(tm.TransactionId, fee, tm.NativeAsset)
+	}
+	return nil
+}
 
-	authMsgHash, err := auth_message.EncodeBytesFrom(tm.TransactionId, tm.RouterAddress, tm.WrappedAsset, tm.Receiver, wrappedAmount)
+// submitSignature signs the authorisation of the transfer and submits it to the Bridge topic
+func (ts *Service) submitSignature(tm model.Transfer, wrappedAmount *big.Int) error {
+	authMsgHash, err := auth_message.EncodeBytesFrom(tm.TransactionId, tm.RouterAddress, tm.WrappedAsset, tm.Receiver, wrappedAmount.String())
 	if err != nil {
 		ts.logger.Errorf("[%s] - Failed to encode the authorisation signature. Error: [%s]", tm.TransactionId, err)
 		return err
@@ -212,7 +314,7 @@ func (ts *Service) ProcessTransfer(tm model.Transfer) error {
 		tm.TransactionId,
 		tm.RouterAddress,
 		tm.Receiver,
-		wrappedAmount,
+		wrappedAmount.String(),
 		signature,
 		tm.WrappedAsset)
 
@@ -231,11 +333,15 @@ func (ts *Service) ProcessTransfer(tm model.Transfer) error {
 	}
 
 	// Update Transfer Record
-	err = ts.transferRepository.UpdateStatusSignatureSubmitted(signatureMessage.TransferID)
+	err = ts.transferRepository.UpdateStatusSignatureSubmitted(signatureMessage.TransferID, repository.Cause{Actor: actor, Reason: status_transition.ReasonSignatureSubmitted})
 	if err != nil {
 		ts.logger.Errorf("[%s] - Failed to update. Error [%s].", signatureMessage.TransferID, err)
 		return err
 	}
+	ts.webhooks.Notify(service.WebhookSignatureSubmitted, service.WebhookData{
+		TransferID:    signatureMessage.TransferID,
+		TransactionID: messageTxId.String(),
+	})
 
 	// Attach update callbacks on Signature HCS Message
 	ts.logger.Infof("[%s] - Submitted signature on Topic [%s]", signatureMessage.TransferID, ts.topicID)
@@ -244,8 +350,127 @@ func (ts *Service) ProcessTransfer(tm model.Transfer) error {
 	return nil
 }
 
-func (ts *Service) processFeeTransfer(transferID string, feeAmount int64, nativeAsset string) {
-	transfers, err := ts.distributor.CalculateMemberDistribution(feeAmount)
+// ReprocessTransfer processes the stored transfer again, if it is initial, held for manual review
+// or its signature submission failed. If the fee of the transfer is already recorded, only the signature is submitted again
+func (ts *Service) ReprocessTransfer(txId string) error {
+	t, err := ts.transferRepository.GetWithFee(txId)
+	if err != nil {
+		ts.logger.Errorf("[%s] - Failed to get db record. Error: [%s]", txId, err)
+		return err
+	}
+	if t == nil {
+		return service.ErrNotFound
+	}
+
+	switch t.Status {
+	case transfer.StatusInitial, transfer.StatusHeld, transfer.StatusSignatureFailed:
+	default:
+		ts.logger.Errorf("[%s] - Cannot reprocess transfer with status [%s].", txId, t.Status)
+		return service.ErrInvalidStatus
+	}
+
+	ts.logger.Infof("[%s] - Reprocessing transfer with status [%s].", txId, t.Status)
+	tm := *model.New(t.TransactionID, t.Receiver, t.NativeAsset, t.WrappedAsset, t.Amount, t.RouterAddress)
+	if t.Fee.TransactionID == "" {
+		return ts.ProcessTransfer(tm)
+	}
+
+	queued := ts.pause.Queue(tm.TransactionId, tm.WrappedAsset, func() {
+		err := ts.ReprocessTransfer(txId)
+		if err != nil {
+			ts.logger.Errorf("[%s] - Reprocessing of queued transfer failed. Error: [%s]", txId, err)
+		}
+	})
+	if queued {
+		return nil
+	}
+
+	_, wrappedAmount, err := ts.calculateFee(tm)
+	if err != nil {
+		return err
+	}
+	return ts.submitSignature(tm, wrappedAmount)
+}
+
+// ExecuteFee executes again the scheduled transaction paying out the fee of the processed transfer,
+// if it failed or was not submitted. The fee is calculated with the fee rules, effective at the valid start of the transfer
+func (ts *Service) ExecuteFee(txId string) error {
+	if ts.feeSettlement.Enabled() {
+		ts.logger.Errorf("[%s] Fee - Cannot execute fee, as fees are settled in batches.", txId)
+		return service.ErrInvalidStatus
+	}
+
+	t, err := ts.transferRepository.GetWithFee(txId)
+	if err != nil {
+		ts.logger.Errorf("[%s] - Failed to get db record. Error: [%s]", txId, err)
+		return err
+	}
+	if t == nil {
+		return service.ErrNotFound
+	}
+
+	switch t.Status {
+	case transfer.StatusInitial, transfer.StatusHeld, transfer.StatusRejected:
+		ts.logger.Errorf("[%s] Fee - Cannot execute fee of transfer with status [%s].", txId, t.Status)
+		return service.ErrInvalidStatus
+	}
+	if t.Fee.TransactionID != "" && t.Fee.Status != fee.StatusFailed {
+		ts.logger.Errorf("[%s] Fee - Cannot execute fee with status [%s].", txId, t.Fee.Status)
+		return service.ErrInvalidStatus
+	}
+
+	feeAmount, _, err := ts.calculateFee(*model.New(t.TransactionID, t.Receiver, t.NativeAsset, t.WrappedAsset, t.Amount, t.RouterAddress))
+	if err != nil {
+		return err
+	}
+	if feeAmount.Sign() == 0 {
+		return ts.createZeroFeeRecord(txId)
+	}
+
+	ts.logger.Infof("[%s] Fee - Executing fee of [%s].", txId, feeAmount)
+	ts.processFeeTransfer(txId, feeAmount, t.NativeAsset)
+	return nil
+}
+
+// calculateFee returns the fee of the transfer and the remainder in wrapped token units.
+// The dust, which cannot be represented with the wrapped token decimals, is added to the fee.
+// The fee rules are selected by the valid start of the transaction, on which all validators agree
+func (ts *Service) calculateFee(tm model.Transfer) (feeAmount, wrappedAmount *big.Int, err error) {
+	amount, err := big_numbers.ToBigInt(tm.Amount)
+	if err != nil {
+		ts.logger.Errorf("[%s] - Failed to parse amount. Error: [%s]", tm.TransactionId, err)
+		return nil, nil, err
+	}
+
+	timestamp, err := validStart(tm.TransactionId)
+	if err != nil {
+		ts.logger.Errorf("[%s] - Failed to parse transaction valid start. Error: [%s]", tm.TransactionId, err)
+		return nil, nil, err
+	}
+
+	feeAmount, remainder := ts.feeService.CalculateFee(tm.NativeAsset, tm.Receiver, amount, timestamp)
+
+	wrappedAmount, dust, err := ts.decimals.ToWrapped(tm.NativeAsset, tm.WrappedAsset, remainder)
+	if err != nil {
+		ts.logger.Errorf("[%s] - Failed to convert amount to wrapped decimals. Error: [%s]", tm.TransactionId, err)
+		return nil, nil, err
+	}
+	if dust.Sign() > 0 {
+		// The dust cannot be represented with the wrapped token decimals, so it is kept as part of the fee
+		ts.logger.Debugf("[%s] - Adding dust [%s] to the fee.", tm.TransactionId, dust)
+		feeAmount = new(big.Int).Add(feeAmount, dust)
+	}
+	return feeAmount, wrappedAmount, nil
+}
+
+func (ts *Service) processFeeTransfer(transferID string, feeAmount *big.Int, nativeAsset string) {
+	hederaFeeAmount, err := big_numbers.ToInt64(feeAmount)
+	if err != nil {
+		ts.logger.Errorf("[%s] Fee - Failed to convert fee amount. Error: [%s].", transferID, err)
+		return
+	}
+
+	transfers, err := ts.distributor.CalculateMemberDistribution(transferID, feeAmount)
 	if err != nil {
 		ts.logger.Errorf("[%s] Fee - Failed to Distribute to Members. Error: [%s].", transferID, err)
 		return
@@ -254,22 +479,69 @@ func (ts *Service) processFeeTransfer(transferID string, feeAmount int64, native
 	transfers = append(transfers,
 		model.Hedera{
 			AccountID: ts.bridgeAccountID,
-			Amount:    -feeAmount,
+			Amount:    -hederaFeeAmount,
 		})
 
-	onExecutionSuccess, onExecutionFail := ts.scheduledTxExecutionCallbacks(transferID, strconv.FormatInt(feeAmount, 10))
-	onSuccess, onFail := ts.scheduledTxMinedCallbacks()
+	onExecutionSuccess, onExecutionFail := ts.scheduledTxExecutionCallbacks(transferID, feeAmount.String())
+	onSuccess, onFail := ts.scheduledTxMinedCallbacks(transferID)
 
 	ts.scheduledService.Execute(transferID, nativeAsset, transfers, onExecutionSuccess, onExecutionFail, onSuccess, onFail)
 }
 
+// createZeroFeeRecord persists a completed Fee record for transfers, which are not charged
+// (e.g. whitelisted receivers). No scheduled transaction is submitted for these.
+func (ts *Service) createZeroFeeRecord(transferID string) error {
+	err := ts.feeRepository.Create(&entity.Fee{
+		TransactionID: transferID,
+		Amount:        "0",
+		Status:        fee.StatusCompleted,
+		TransferID: sql.NullString{
+			String: transferID,
+			Valid:  true,
+		},
+	})
+	if err != nil {
+		ts.logger.Errorf("[%s] Fee - Failed to create zero fee record. Error [%s].", transferID, err)
+	}
+	return err
+}
+
+// createAccruedFeeRecord persists the fee as owed to the members. It is paid out with the
+// settlement of the batch, corresponding to the valid start timestamp of the transfer.
+func (ts *Service) createAccruedFeeRecord(transferID string, feeAmount *big.Int, nativeAsset string) error {
+	validStart, err := validStart(transferID)
+	if err != nil {
+		ts.logger.Errorf("[%s] Fee - Failed to parse transaction valid start. Error [%s].", transferID, err)
+		return err
+	}
+
+	err = ts.feeRepository.Create(&entity.Fee{
+		TransactionID: transferID,
+		Amount:        feeAmount.String(),
+		Status:        fee.StatusAccrued,
+		TransferID: sql.NullString{
+			String: transferID,
+			Valid:  true,
+		},
+		NativeAsset: nativeAsset,
+		Batch:       ts.feeSettlement.Batch(validStart),
+	})
+	if err != nil {
+		ts.logger.Errorf("[%s] Fee - Failed to create accrued fee record. Error [%s].", transferID, err)
+	}
+	return err
+}
+
 func (ts *Service) scheduledTxExecutionCallbacks(transferID, feeAmount string) (onExecutionSuccess func(transactionID, scheduleID string), onExecutionFail func(transactionID string)) {
 	onExecutionSuccess = func(transactionID, scheduleID string) {
 		err := ts.feeRepository.Create(&entity.Fee{
 			TransactionID: transactionID,
-			ScheduleID:    scheduleID,
-			Amount:        feeAmount,
-			Status:        fee.StatusSubmitted,
+			ScheduleID: sql.NullString{
+				String: scheduleID,
+				Valid:  true,
+			},
+			Amount: feeAmount,
+			Status: fee.StatusSubmitted,
 			TransferID: sql.NullString{
 				String: transferID,
 				Valid:  true,
@@ -281,6 +553,8 @@ func (ts *Service) scheduledTxExecutionCallbacks(transferID, feeAmount string) (
 				transferID, transactionID, err)
 			return
 		}
+		// Signature messages of other validators are only handled once the fee of the transfer is recorded
+		ts.pending.Release(transferID)
 	}
 
 	onExecutionFail = func(transactionID string) {
@@ -301,20 +575,24 @@ func (ts *Service) scheduledTxExecutionCallbacks(transferID, feeAmount string) (
 	return onExecutionSuccess, onExecutionFail
 }
 
-func (ts *Service) scheduledTxMinedCallbacks() (onSuccess, onFail func(transactionID string)) {
+func (ts *Service) scheduledTxMinedCallbacks(transferID string) (onSuccess, onFail func(transactionID string)) {
 	onSuccess = func(transactionID string) {
 		ts.logger.Debugf("[%s] Fee - Scheduled TX execution successful.", transactionID)
 
-		err := ts.feeRepository.UpdateStatusCompleted(transactionID)
+		err := ts.feeRepository.UpdateStatusCompleted(transactionID, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxExecuted})
 		if err != nil {
 			ts.logger.Errorf("[%s] Fee - Failed to update status completed. Error [%s].", transactionID, err)
 			return
 		}
+		ts.webhooks.Notify(service.WebhookFeeCompleted, service.WebhookData{
+			TransferID:    transferID,
+			TransactionID: transactionID,
+		})
 	}
 
 	onFail = func(transactionID string) {
 		ts.logger.Debugf("[%s] Fee - Scheduled TX execution has failed.", transactionID)
-		err := ts.feeRepository.UpdateStatusFailed(transactionID)
+		err := ts.feeRepository.UpdateStatusFailed(transactionID, repository.Cause{Actor: actor, Reason: status_transition.ReasonScheduledTxFailed})
 		if err != nil {
 			ts.logger.Errorf("[%s] Fee - Failed to update status failed. Error [%s].", transactionID, err)
 			return
@@ -323,6 +601,16 @@ func (ts *Service) scheduledTxMinedCallbacks() (onSuccess, onFail func(transacti
 	return onSuccess, onFail
 }
 
+func (ts *Service) notifyTransferCreated(tm model.Transfer) {
+	ts.webhooks.Notify(service.WebhookTransferCreated, service.WebhookData{
+		TransferID:   tm.TransactionId,
+		Receiver:     tm.Receiver,
+		Amount:       tm.Amount,
+		NativeAsset:  tm.NativeAsset,
+		WrappedAsset: tm.WrappedAsset,
+	})
+}
+
 // TransferData returns from the database the given transfer, its signatures and
 // calculates if its messages have reached super majority
 func (ts *Service) TransferData(txId string) (service.TransferData, error) {
@@ -331,38 +619,102 @@ func (ts *Service) TransferData(txId string) (service.TransferData, error) {
 		ts.logger.Errorf("[%s] - Failed to query Transfer with messages. Error: [%s].", txId, err)
 		return service.TransferData{}, err
 	}
-	if t == nil || t.Fee.Amount == "" {
+	// Pruned transfers are looked up in the archive
+	if t == nil || t.TransactionID == "" {
+		t, err = ts.archive.Transfer(txId)
+		if err != nil {
+			ts.logger.Errorf("[%s] - Failed to query archived Transfer. Error: [%s].", txId, err)
+			return service.TransferData{}, err
+		}
+	}
+	// Rejected deposits are refunded and have no signatures
+	if t == nil || t.Fee.Amount == "" || t.Status == transfer.StatusRejected {
 		return service.TransferData{}, service.ErrNotFound
 	}
 
-	amount, err := strconv.ParseInt(t.Amount, 10, 64)
+	amount, err := big_numbers.ToBigInt(t.Amount)
 	if err != nil {
 		ts.logger.Errorf("[%s] - Failed to parse transfer amount. Error [%s]", t.TransactionID, err)
 		return service.TransferData{}, err
 	}
 
-	feeAmount, err := strconv.ParseInt(t.Fee.Amount, 10, 64)
+	feeAmount, err := big_numbers.ToBigInt(t.Fee.Amount)
 	if err != nil {
 		ts.logger.Errorf("[%s] - Failed to parse fee amount. Error [%s]", t.TransactionID, err)
 		return service.TransferData{}, err
 	}
-	signedAmount := strconv.FormatInt(amount-feeAmount, 10)
+	signedAmount, _, err := ts.decimals.ToWrapped(t.NativeAsset, t.WrappedAsset, new(big.Int).Sub(amount, feeAmount))
+	if err != nil {
+		ts.logger.Errorf("[%s] - Failed to convert amount to wrapped decimals. Error [%s]", t.TransactionID, err)
+		return service.TransferData{}, err
+	}
 
+	// Only the signatures of the eligible signers are counted, so only they are handed over for the mint
 	var signatures []string
-	for _, m := range t.Messages {
+	for _, m := range ts.quorum.Eligible(t, t.Messages) {
 		signatures = append(signatures, m.Signature)
 	}
 
-	requiredSigCount := len(ts.contractsService.GetMembers())/2 + 1
-	reachedMajority := len(t.Messages) >= requiredSigCount
+	_, _, reachedMajority := ts.quorum.Reached(t, t.Messages)
 
 	return service.TransferData{
 		Recipient:     t.Receiver,
 		RouterAddress: t.RouterAddress,
-		Amount:        signedAmount,
+		Amount:        signedAmount.String(),
 		NativeAsset:   t.NativeAsset,
 		WrappedAsset:  t.WrappedAsset,
 		Signatures:    signatures,
 		Majority:      reachedMajority,
 	}, nil
 }
+
+// Timeline returns the status transitions of the given transfer, its signature, fee and refund, ordered by time
+func (ts *Service) Timeline(txId string) ([]service.StatusTransition, error) {
+	transitions, err := ts.statusTransitions.GetForTransfer(txId)
+	if err != nil {
+		ts.logger.Errorf("[%s] - Failed to query status transitions. Error: [%s].", txId, err)
+		return nil, err
+	}
+
+	// The timeline of a transfer, which status has not changed yet, is empty
+	if len(transitions) == 0 {
+		t, err := ts.transferRepository.GetByTransactionId(txId)
+		if err != nil {
+			ts.logger.Errorf("[%s] - Failed to query Transfer. Error: [%s].", txId, err)
+			return nil, err
+		}
+		if t == nil {
+			t, err = ts.archive.Transfer(txId)
+			if err != nil {
+				ts.logger.Errorf("[%s] - Failed to query archived Transfer. Error: [%s].", txId, err)
+				return nil, err
+			}
+		}
+		if t == nil {
+			return nil, service.ErrNotFound
+		}
+	}
+
+	result := make([]service.StatusTransition, len(transitions))
+	for i, t := range transitions {
+		result[i] = service.StatusTransition{
+			Kind:      t.Kind,
+			RecordID:  t.RecordID,
+			From:      t.From,
+			To:        t.To,
+			Actor:     t.Actor,
+			Reason:    t.Reason,
+			Timestamp: t.Timestamp,
+		}
+	}
+	return result, nil
+}
+
+// validStart returns the valid start (in seconds) of the transaction with the given mirror node id
+func validStart(transactionID string) (int64, error) {
+	txId, err := hederahelper.FromMirrorNodeTransactionID(transactionID)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(txId.Seconds, 10, 64)
+}
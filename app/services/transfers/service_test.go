@@ -0,0 +1,166 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transfers
+
+import (
+	"database/sql"
+	"math/big"
+	"testing"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/fee"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/quorum"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/signer/eth"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/constants"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	s              = &Service{}
+	txId           = "0.0.123456-1620000000-000000000"
+	receiver       = "0x0000000000000000000000000000000000000003"
+	wrappedAsset   = "0x0000000000000000000000000000000000000004"
+	topicID        = hedera.TopicID{Topic: 777}
+	messageTxId, _ = hedera.TransactionIdFromString("0.0.123456@1620000001.000000000")
+)
+
+func setup() {
+	mocks.Setup()
+	mocks.MPauseService.On("Queue", txId, wrappedAsset, mock.Anything).Return(false)
+	mocks.MFeeService.On("CalculateFee", constants.Hbar, receiver, big.NewInt(100), int64(1620000000)).Return(big.NewInt(10), big.NewInt(90))
+	mocks.MDecimalsService.On("ToWrapped", constants.Hbar, wrappedAsset, big.NewInt(90)).Return(big.NewInt(90), big.NewInt(0), nil)
+	mocks.MHederaNodeClient.On("SubmitTopicConsensusMessage", topicID, mock.Anything).Return(&messageTxId, nil)
+	mocks.MTransferRepository.On("UpdateStatusSignatureSubmitted", txId, mock.Anything).Return(nil)
+	mocks.MWebhooksService.On("Notify", mock.Anything, mock.Anything).Return()
+	mocks.MHederaMirrorClient.On("WaitForTransaction", mock.Anything, mock.Anything, mock.Anything).Return()
+	s = &Service{
+		logger:             config.GetLoggerFor("Transfers Service"),
+		hederaNode:         mocks.MHederaNodeClient,
+		mirrorNode:         mocks.MHederaMirrorClient,
+		ethSigner:          eth.NewEthSigner("bb9282e2ff5c6e8f7c2e1ea3d2c8b1f3e9a1e9a6b4d1b2f2b0f7a7c1c3d5e6f7"),
+		transferRepository: mocks.MTransferRepository,
+		feeRepository:      mocks.MFeeRepository,
+		distributor:        mocks.MDistributorService,
+		feeService:         mocks.MFeeService,
+		scheduledService:   mocks.MScheduledService,
+		feeSettlement:      mocks.MFeeSettlementService,
+		pending:            mocks.MPendingSignaturesService,
+		decimals:           mocks.MDecimalsService,
+		pause:              mocks.MPauseService,
+		webhooks:           mocks.MWebhooksService,
+		topicID:            topicID,
+	}
+}
+
+func signatureFailedTransfer(feeRecord entity.Fee) *entity.Transfer {
+	return &entity.Transfer{
+		TransactionID: txId,
+		Receiver:      receiver,
+		NativeAsset:   constants.Hbar,
+		WrappedAsset:  wrappedAsset,
+		Amount:        "100",
+		Status:        transfer.StatusSignatureFailed,
+		Fee:           feeRecord,
+	}
+}
+
+func Test_ReprocessSignatureFailedWithSubmittedFee(t *testing.T) {
+	setup()
+	mocks.MFeeSettlementService.On("Enabled").Return(false)
+	mocks.MTransferRepository.On("GetWithFee", txId).Return(signatureFailedTransfer(entity.Fee{
+		TransactionID: "0.0.1-1620000001-000000000",
+		Amount:        "10",
+		Status:        fee.StatusSubmitted,
+		TransferID:    sql.NullString{String: txId, Valid: true},
+	}), nil)
+
+	err := s.ReprocessTransfer(txId)
+
+	assert.Nil(t, err)
+	mocks.MHederaNodeClient.AssertCalled(t, "SubmitTopicConsensusMessage", topicID, mock.Anything)
+	mocks.MTransferRepository.AssertCalled(t, "UpdateStatusSignatureSubmitted", txId, mock.Anything)
+	mocks.MDistributorService.AssertNotCalled(t, "CalculateMemberDistribution", mock.Anything, mock.Anything)
+	mocks.MScheduledService.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+	mocks.MFeeRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_ReprocessSignatureFailedWithAccruedFee(t *testing.T) {
+	setup()
+	mocks.MFeeSettlementService.On("Enabled").Return(true)
+	mocks.MTransferRepository.On("GetWithFee", txId).Return(signatureFailedTransfer(entity.Fee{
+		TransactionID: txId,
+		Amount:        "10",
+		Status:        fee.StatusAccrued,
+		TransferID:    sql.NullString{String: txId, Valid: true},
+	}), nil)
+
+	err := s.ReprocessTransfer(txId)
+
+	assert.Nil(t, err)
+	mocks.MHederaNodeClient.AssertCalled(t, "SubmitTopicConsensusMessage", topicID, mock.Anything)
+	mocks.MTransferRepository.AssertCalled(t, "UpdateStatusSignatureSubmitted", txId, mock.Anything)
+	mocks.MFeeRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_ReprocessHeldRecordsFee(t *testing.T) {
+	setup()
+	mocks.MFeeSettlementService.On("Enabled").Return(true)
+	mocks.MFeeSettlementService.On("Batch", int64(1620000000)).Return(int64(1620000000))
+	mocks.MFeeRepository.On("Create", mock.Anything).Return(nil)
+	mocks.MPendingSignaturesService.On("Release", txId).Return()
+	held := signatureFailedTransfer(entity.Fee{})
+	held.Status = transfer.StatusHeld
+	mocks.MTransferRepository.On("GetWithFee", txId).Return(held, nil)
+
+	err := s.ReprocessTransfer(txId)
+
+	assert.Nil(t, err)
+	mocks.MFeeRepository.AssertCalled(t, "Create", mock.Anything)
+	mocks.MHederaNodeClient.AssertCalled(t, "SubmitTopicConsensusMessage", topicID, mock.Anything)
+}
+
+func Test_TransferDataEligibleSignatures(t *testing.T) {
+	setup()
+	s.quorum = quorum.New(config.Quorum{}, mocks.MMemberRegistry)
+	mocks.MTransferRepository.On("GetWithPreloads", txId).Return(&entity.Transfer{
+		TransactionID:      txId,
+		Receiver:           receiver,
+		NativeAsset:        constants.Hbar,
+		WrappedAsset:       wrappedAsset,
+		Amount:             "100",
+		Status:             transfer.StatusCompleted,
+		EligibleSigners:    "0xAa,0xBb",
+		RequiredSignatures: 2,
+		Fee:                entity.Fee{TransactionID: txId, Amount: "10"},
+		Messages: []entity.Message{
+			{Signer: "0xAa", Signature: "first"},
+			{Signer: "0xEe", Signature: "not-eligible"},
+			{Signer: "0xBb", Signature: "second"},
+		},
+	}, nil)
+
+	data, err := s.TransferData(txId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"first", "second"}, data.Signatures)
+	assert.True(t, data.Majority)
+}
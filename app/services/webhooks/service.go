@@ -0,0 +1,257 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	webhook_delivery "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/webhook-delivery"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// Headers of the webhook deliveries
+const (
+	// SignatureHeader is the hex encoded HMAC-SHA256 of the payload with the secret of the subscription, prefixed with `sha256=`
+	SignatureHeader = "X-Webhook-Signature"
+	// EventHeader is the event of the payload
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader is the id of the delivery, which is the same for all of its attempts
+	DeliveryHeader = "X-Webhook-Delivery"
+)
+
+type Service struct {
+	subscriptions []config.WebhookSubscription
+	maxAttempts   int
+	backoff       time.Duration
+	client        *http.Client
+	repository    repository.WebhookDelivery
+	logger        *log.Entry
+}
+
+func New(webhooks config.Webhooks, repository repository.WebhookDelivery) *Service {
+	if len(webhooks.Subscriptions) > 0 {
+		for _, subscription := range webhooks.Subscriptions {
+			_, err := url.ParseRequestURI(subscription.URL)
+			if err != nil {
+				log.Fatalf("Invalid webhook url: [%s]. Error: [%s].", subscription.URL, err)
+			}
+			if subscription.Secret == "" {
+				log.Fatalf("Webhook secret of [%s] is not set.", subscription.URL)
+			}
+		}
+		if webhooks.MaxAttempts <= 0 {
+			log.Fatalf("Invalid webhook max attempts: [%d].", webhooks.MaxAttempts)
+		}
+		if webhooks.Backoff <= 0 {
+			log.Fatalf("Invalid webhook backoff: [%d].", webhooks.Backoff)
+		}
+		if webhooks.Timeout <= 0 {
+			log.Fatalf("Invalid webhook timeout: [%d].", webhooks.Timeout)
+		}
+	}
+
+	return &Service{
+		subscriptions: webhooks.Subscriptions,
+		maxAttempts:   webhooks.MaxAttempts,
+		backoff:       webhooks.Backoff * time.Second,
+		client:        &http.Client{Timeout: webhooks.Timeout * time.Second},
+		repository:    repository,
+		logger:        config.GetLoggerFor("Webhooks Service"),
+	}
+}
+
+// Enabled returns whether any subscriptions are configured
+func (s *Service) Enabled() bool {
+	return len(s.subscriptions) > 0
+}
+
+// Notify records a delivery of the event for every subscription of the event and delivers them in the background.
+// Failed deliveries are retried with backoff
+func (s *Service) Notify(event string, data service.WebhookData) {
+	if !s.Enabled() {
+		return
+	}
+
+	timestamp := time.Now().UnixNano()
+	payload, err := json.Marshal(service.WebhookPayload{
+		Event:     event,
+		Timestamp: timestamp,
+		Data:      data,
+	})
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to marshal [%s] payload. Error: [%s].", recordID(data), event, err)
+		return
+	}
+
+	for _, subscription := range s.subscriptions {
+		if !subscribed(subscription, event) {
+			continue
+		}
+
+		delivery := &entity.WebhookDelivery{
+			URL:       subscription.URL,
+			Event:     event,
+			RecordID:  recordID(data),
+			Payload:   string(payload),
+			Status:    webhook_delivery.StatusPending,
+			Timestamp: timestamp,
+		}
+		err := s.repository.Create(delivery)
+		if err != nil {
+			s.logger.Errorf("[%s] - Failed to record delivery of [%s] to [%s]. Error: [%s].", delivery.RecordID, event, subscription.URL, err)
+			continue
+		}
+
+		go s.deliver(subscription.Secret, delivery)
+	}
+}
+
+// Start resumes the pending deliveries, left unfinished by a restart. Deliveries to removed subscriptions are failed
+func (s *Service) Start() {
+	if !s.Enabled() {
+		return
+	}
+
+	deliveries, err := s.repository.GetPending()
+	if err != nil {
+		s.logger.Errorf("Failed to get pending deliveries. Error: [%s].", err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		subscription, ok := s.subscription(delivery.URL)
+		if !ok {
+			s.logger.Warnf("[%s] - Subscription [%s] of [%s] delivery is removed.", delivery.RecordID, delivery.URL, delivery.Event)
+			s.updateAttempt(delivery, webhook_delivery.StatusFailed, delivery.Attempts, delivery.ResponseCode, "subscription removed")
+			continue
+		}
+
+		go s.deliver(subscription.Secret, delivery)
+	}
+	s.logger.Infof("Resumed [%d] pending deliveries.", len(deliveries))
+}
+
+// deliver posts the payload, until it is accepted or all attempts fail, doubling the backoff after every failed attempt
+func (s *Service) deliver(secret string, delivery *entity.WebhookDelivery) {
+	backoff := s.backoff
+	for attempt := delivery.Attempts + 1; ; attempt++ {
+		responseCode, err := s.post(secret, delivery)
+		if err == nil {
+			s.logger.Debugf("[%s] - Delivered [%s] to [%s].", delivery.RecordID, delivery.Event, delivery.URL)
+			s.updateAttempt(delivery, webhook_delivery.StatusDelivered, attempt, responseCode, "")
+			return
+		}
+
+		if attempt >= s.maxAttempts {
+			s.logger.Errorf("[%s] - Failed to deliver [%s] to [%s] in [%d] attempts. Error: [%s].", delivery.RecordID, delivery.Event, delivery.URL, attempt, err)
+			s.updateAttempt(delivery, webhook_delivery.StatusFailed, attempt, responseCode, err.Error())
+			return
+		}
+
+		s.logger.Warnf("[%s] - Attempt [%d] to deliver [%s] to [%s] failed. Retrying in [%s]. Error: [%s].", delivery.RecordID, attempt, delivery.Event, delivery.URL, backoff, err)
+		s.updateAttempt(delivery, webhook_delivery.StatusPending, attempt, responseCode, err.Error())
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (s *Service) post(secret string, delivery *entity.WebhookDelivery) (responseCode int, err error) {
+	request, err := http.NewRequest(http.MethodPost, delivery.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, delivery.Event)
+	request.Header.Set(DeliveryHeader, strconv.FormatUint(delivery.ID, 10))
+	request.Header.Set(SignatureHeader, Sign(secret, []byte(delivery.Payload)))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	// The body is drained, so that the connection is reused
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return response.StatusCode, errors.New(fmt.Sprintf("unexpected status code [%d]", response.StatusCode))
+	}
+	return response.StatusCode, nil
+}
+
+func (s *Service) updateAttempt(delivery *entity.WebhookDelivery, status string, attempts, responseCode int, lastError string) {
+	err := s.repository.UpdateAttempt(delivery.ID, status, attempts, responseCode, lastError)
+	if err != nil {
+		s.logger.Errorf("[%s] - Failed to record attempt [%d] of [%s] delivery to [%s]. Error: [%s].", delivery.RecordID, attempts, delivery.Event, delivery.URL, err)
+	}
+}
+
+func (s *Service) subscription(address string) (config.WebhookSubscription, bool) {
+	for _, subscription := range s.subscriptions {
+		if subscription.URL == address {
+			return subscription, true
+		}
+	}
+	return config.WebhookSubscription{}, false
+}
+
+// Sign returns the value of the SignatureHeader of the payload, signed with the secret
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// subscribed returns whether the subscription receives the event. Subscriptions without events receive all of them
+func subscribed(subscription config.WebhookSubscription, event string) bool {
+	if len(subscription.Events) == 0 {
+		return true
+	}
+	for _, e := range subscription.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// recordID returns the id of the transfer or burn event, which the event is about
+func recordID(data service.WebhookData) string {
+	if data.TransferID != "" {
+		return data.TransferID
+	}
+	if data.BurnEventID != "" {
+		return data.BurnEventID
+	}
+	return data.TransactionID
+}
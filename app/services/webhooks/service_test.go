@@ -0,0 +1,209 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhooks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	webhook_delivery "github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity/webhook-delivery"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	"github.com/limechain/hedera-eth-bridge-validator/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const secret = "webhook-secret"
+
+var (
+	s    = &Service{}
+	data = service.WebhookData{
+		TransferID:   "0.0.1234-1620000000-000000000",
+		Receiver:     "0xsomeethaddress",
+		Amount:       "100",
+		NativeAsset:  "HBAR",
+		WrappedAsset: "0xwrapped",
+	}
+)
+
+// receiver is a local webhook subscription, which responds with the given status codes in order
+type receiver struct {
+	server    *httptest.Server
+	mutex     sync.Mutex
+	responses []int
+	requests  []*http.Request
+	bodies    [][]byte
+	received  chan struct{}
+}
+
+func newReceiver(responses ...int) *receiver {
+	r := &receiver{responses: responses, received: make(chan struct{}, 10)}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		body, _ := ioutil.ReadAll(request.Body)
+
+		r.mutex.Lock()
+		status := r.responses[len(r.requests)%len(r.responses)]
+		r.requests = append(r.requests, request)
+		r.bodies = append(r.bodies, body)
+		r.mutex.Unlock()
+
+		w.WriteHeader(status)
+		r.received <- struct{}{}
+	}))
+	return r
+}
+
+func setup(subscriptions ...config.WebhookSubscription) {
+	mocks.Setup()
+	s = &Service{
+		subscriptions: subscriptions,
+		maxAttempts:   3,
+		backoff:       time.Millisecond,
+		client:        &http.Client{Timeout: time.Second},
+		repository:    mocks.MWebhookDeliveryRepository,
+		logger:        config.GetLoggerFor("Webhooks Service"),
+	}
+}
+
+func Test_New(t *testing.T) {
+	setup(config.WebhookSubscription{URL: "http://localhost:8080/webhooks", Secret: secret})
+
+	actualService := New(config.Webhooks{
+		Subscriptions: s.subscriptions,
+		MaxAttempts:   3,
+		Backoff:       1,
+		Timeout:       1,
+	}, mocks.MWebhookDeliveryRepository)
+
+	assert.Equal(t, s.subscriptions, actualService.subscriptions)
+	assert.Equal(t, time.Second, actualService.backoff)
+	assert.Equal(t, time.Second, actualService.client.Timeout)
+	assert.True(t, actualService.Enabled())
+}
+
+func Test_NotifyDeliversSignedPayload(t *testing.T) {
+	r := newReceiver(http.StatusOK)
+	defer r.server.Close()
+	setup(config.WebhookSubscription{URL: r.server.URL, Secret: secret})
+	mocks.MWebhookDeliveryRepository.On("Create", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*entity.WebhookDelivery).ID = 1
+	})
+	delivered := make(chan struct{})
+	mocks.MWebhookDeliveryRepository.On("UpdateAttempt", uint64(1), webhook_delivery.StatusDelivered, 1, http.StatusOK, "").Return(nil).Run(func(args mock.Arguments) {
+		close(delivered)
+	})
+
+	s.Notify(service.WebhookTransferCreated, data)
+
+	<-r.received
+	<-delivered
+	request, body := r.requests[0], r.bodies[0]
+	assert.Equal(t, http.MethodPost, request.Method)
+	assert.Equal(t, service.WebhookTransferCreated, request.Header.Get(EventHeader))
+	assert.Equal(t, "1", request.Header.Get(DeliveryHeader))
+	assert.Equal(t, Sign(secret, body), request.Header.Get(SignatureHeader))
+
+	var payload service.WebhookPayload
+	err := json.Unmarshal(body, &payload)
+	assert.Nil(t, err)
+	assert.Equal(t, service.WebhookTransferCreated, payload.Event)
+	assert.Equal(t, data, payload.Data)
+	assert.NotZero(t, payload.Timestamp)
+
+	delivery := mocks.MWebhookDeliveryRepository.Calls[0].Arguments.Get(0).(*entity.WebhookDelivery)
+	assert.Equal(t, r.server.URL, delivery.URL)
+	assert.Equal(t, data.TransferID, delivery.RecordID)
+	assert.Equal(t, string(body), delivery.Payload)
+}
+
+func Test_NotifySubscribedEvents(t *testing.T) {
+	setup(config.WebhookSubscription{URL: "http://localhost:8080/webhooks", Secret: secret, Events: []string{service.WebhookBurnCompleted}})
+
+	s.Notify(service.WebhookTransferCreated, data)
+
+	mocks.MWebhookDeliveryRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_NotifyDisabled(t *testing.T) {
+	setup()
+
+	s.Notify(service.WebhookTransferCreated, data)
+
+	assert.False(t, s.Enabled())
+	mocks.MWebhookDeliveryRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func Test_DeliverRetries(t *testing.T) {
+	r := newReceiver(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusNoContent)
+	defer r.server.Close()
+	setup(config.WebhookSubscription{URL: r.server.URL, Secret: secret})
+	mocks.MWebhookDeliveryRepository.On("UpdateAttempt", uint64(1), webhook_delivery.StatusPending, mock.Anything, http.StatusInternalServerError, "unexpected status code [500]").Return(nil)
+	mocks.MWebhookDeliveryRepository.On("UpdateAttempt", uint64(1), webhook_delivery.StatusDelivered, 3, http.StatusNoContent, "").Return(nil)
+
+	s.deliver(secret, &entity.WebhookDelivery{ID: 1, URL: r.server.URL, Event: service.WebhookBurnCompleted, Payload: "{}"})
+
+	assert.Len(t, r.requests, 3)
+	mocks.MWebhookDeliveryRepository.AssertCalled(t, "UpdateAttempt", uint64(1), webhook_delivery.StatusPending, 1, http.StatusInternalServerError, "unexpected status code [500]")
+	mocks.MWebhookDeliveryRepository.AssertCalled(t, "UpdateAttempt", uint64(1), webhook_delivery.StatusPending, 2, http.StatusInternalServerError, "unexpected status code [500]")
+	mocks.MWebhookDeliveryRepository.AssertCalled(t, "UpdateAttempt", uint64(1), webhook_delivery.StatusDelivered, 3, http.StatusNoContent, "")
+}
+
+func Test_DeliverFails(t *testing.T) {
+	r := newReceiver(http.StatusBadGateway)
+	defer r.server.Close()
+	setup(config.WebhookSubscription{URL: r.server.URL, Secret: secret})
+	mocks.MWebhookDeliveryRepository.On("UpdateAttempt", uint64(1), mock.Anything, mock.Anything, http.StatusBadGateway, "unexpected status code [502]").Return(nil)
+
+	s.deliver(secret, &entity.WebhookDelivery{ID: 1, URL: r.server.URL, Event: service.WebhookBurnFailed, Payload: "{}"})
+
+	assert.Len(t, r.requests, 3)
+	mocks.MWebhookDeliveryRepository.AssertCalled(t, "UpdateAttempt", uint64(1), webhook_delivery.StatusFailed, 3, http.StatusBadGateway, "unexpected status code [502]")
+	mocks.MWebhookDeliveryRepository.AssertNumberOfCalls(t, "UpdateAttempt", 3)
+}
+
+func Test_StartResumesPending(t *testing.T) {
+	r := newReceiver(http.StatusOK)
+	defer r.server.Close()
+	setup(config.WebhookSubscription{URL: r.server.URL, Secret: secret})
+	pending := []*entity.WebhookDelivery{
+		{ID: 1, URL: r.server.URL, Event: service.WebhookMajorityReached, Payload: "{}", Status: webhook_delivery.StatusPending, Attempts: 1},
+		{ID: 2, URL: "http://localhost:8080/removed", Event: service.WebhookMajorityReached, Payload: "{}", Status: webhook_delivery.StatusPending, Attempts: 1, ResponseCode: http.StatusBadGateway},
+	}
+	mocks.MWebhookDeliveryRepository.On("GetPending").Return(pending, nil)
+	mocks.MWebhookDeliveryRepository.On("UpdateAttempt", uint64(2), webhook_delivery.StatusFailed, 1, http.StatusBadGateway, "subscription removed").Return(nil)
+	delivered := make(chan struct{})
+	mocks.MWebhookDeliveryRepository.On("UpdateAttempt", uint64(1), webhook_delivery.StatusDelivered, 2, http.StatusOK, "").Return(nil).Run(func(args mock.Arguments) {
+		close(delivered)
+	})
+
+	s.Start()
+
+	<-delivered
+	assert.Len(t, r.requests, 1)
+	mocks.MWebhookDeliveryRepository.AssertCalled(t, "UpdateAttempt", uint64(2), webhook_delivery.StatusFailed, 1, http.StatusBadGateway, "subscription removed")
+}
+
+func Test_Sign(t *testing.T) {
+	assert.Equal(t, "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", Sign("key", []byte("The quick brown fox jumps over the lazy dog")))
+}
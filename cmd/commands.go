@@ -0,0 +1,523 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/migration"
+	apirouter "github.com/limechain/hedera-eth-bridge-validator/app/router"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/archive"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/checkpoints"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/quorum"
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+)
+
+// command is a subcommand of the validator CLI. Subcommands are run with the loaded configuration
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(configuration config.Config, args []string) error
+}
+
+var commands = []command{
+	{name: "serve", description: "Starts the validator node (default)", run: serve},
+	{name: "migrate", usage: "[up | down <steps> | version]", description: "Applies the pending migrations, reverts the last ones or shows the schema version", run: migrate},
+	{name: "recover", usage: "--from <ns> [--to <ns>] [--scopes transfers,messages,burns] [--url <admin-api-url>]", description: "Recovers the interval [from; to) through the admin API of the running node and prints its results", run: recoverInterval},
+	{name: "status", description: "Shows the checkpoints of the watchers and the number of operations by status", run: showStatus},
+	{name: "transfer show", usage: "<transaction-id>", description: "Shows the transfer with its fee and signatures", run: showTransfer},
+	{name: "verify-config", description: "Verifies the configuration, without connecting to any service", run: verifyConfig},
+	{name: "keys show-address", description: "Shows the Ethereum address and the Hedera public key of the configured keys", run: showAddress},
+}
+
+// resolveCommand returns the subcommand and its arguments. The node is served, if no subcommand is provided
+func resolveCommand(args []string) (*command, []string, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return &commands[0], args, nil
+	}
+
+	for i := range commands {
+		words := strings.Fields(commands[i].name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == commands[i].name {
+			return &commands[i], args[len(words):], nil
+		}
+	}
+	return nil, nil, errors.New(fmt.Sprintf("unknown command [%s]", strings.Join(args, " ")))
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: validator <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-20s %s\n", c.name, c.description)
+		if c.usage != "" {
+			fmt.Fprintf(os.Stderr, "  %-20s   %s %s\n", "", c.name, c.usage)
+		}
+	}
+}
+
+// migrate applies the pending migrations (default), reverts the last `steps` migrations or prints the schema version
+func migrate(configuration config.Config, args []string) error {
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	migrator := migration.New(persistence.Connect(configuration.Validator.Database))
+	switch action {
+	case "up":
+		return migrator.Up()
+	case "down":
+		if len(args) != 2 {
+			return errors.New("expected the number of migrations to revert")
+		}
+		steps, err := strconv.Atoi(args[1])
+		if err != nil || steps <= 0 {
+			return errors.New(fmt.Sprintf("invalid number of migrations [%s]", args[1]))
+		}
+		return migrator.Down(steps)
+	case "version":
+		version, err := migrator.Version()
+		if err != nil {
+			return err
+		}
+		return printJSON(map[string]int64{"version": version})
+	default:
+		return errors.New(fmt.Sprintf("unknown migrate action [%s]", action))
+	}
+}
+
+// recoverInterval recovers the scopes in the interval [from; to) (in nanoseconds) through the admin API of the running node,
+// so that the recovery is run by the node, which holds the database, instead of a second process next to it.
+// It waits for the recovery to finish and prints its results
+func recoverInterval(configuration config.Config, args []string) error {
+	apiKey := ""
+	if len(configuration.Validator.Admin.APIKeys) > 0 {
+		apiKey = configuration.Validator.Admin.APIKeys[0]
+	}
+
+	flags := flag.NewFlagSet("recover", flag.ContinueOnError)
+	from := flags.Int64("from", 0, "Start of the interval (in nanoseconds)")
+	to := flags.Int64("to", time.Now().UnixNano(), "End of the interval (in nanoseconds), excluded")
+	scopes := flags.String("scopes", strings.Join(service.RecoveryScopes, ","), "Comma separated scopes to recover")
+	address := flags.String("url", adminURL(configuration.Validator), "URL of the admin API of the running node")
+	key := flags.String("api-key", apiKey, "API key of the admin API")
+	certFile := flags.String("cert-file", "", "Client certificate, if the admin API requires client certificates")
+	keyFile := flags.String("key-file", "", "Key of the client certificate")
+	caFile := flags.String("ca-file", "", "CA of the certificate of the admin API, if it is not signed by a system CA")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *from <= 0 || *to <= *from {
+		return errors.New(fmt.Sprintf("invalid interval [%d; %d)", *from, *to))
+	}
+
+	client, err := adminClient(*certFile, *keyFile, *caFile)
+	if err != nil {
+		return err
+	}
+	admin := adminAPI{client: client, url: strings.TrimSuffix(*address, "/"), apiKey: *key}
+
+	request := map[string]interface{}{"from": *from, "to": *to, "scopes": strings.Split(*scopes, ",")}
+	var progress service.RecoveryProgress
+	err = admin.do(http.MethodPost, "/recovery", request, &progress)
+	if err != nil {
+		return err
+	}
+	for progress.Running {
+		time.Sleep(time.Second)
+		err = admin.do(http.MethodGet, "/recovery", nil, &progress)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = printJSON(progress)
+	if err != nil {
+		return err
+	}
+	if progress.Error != "" {
+		return errors.New(progress.Error)
+	}
+	return nil
+}
+
+// adminURL returns the URL of the admin API of the node, served with the configuration
+func adminURL(validator config.Validator) string {
+	if validator.Admin.Port == "" {
+		return fmt.Sprintf("http://localhost:%s/api/admin", validator.Port)
+	}
+	scheme := "http"
+	if validator.Admin.TLS.CertFile != "" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://localhost:%s/api/admin", scheme, validator.Admin.Port)
+}
+
+// adminClient returns the HTTP client of the admin API, presenting the client certificate and trusting the CA, if provided
+func adminClient(certFile, keyFile, caFile string) (*http.Client, error) {
+	tlsConfig := &tls.Config{}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("failed to load client certificate [%s]: [%s]", certFile, err))
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		ca, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("failed to read CA [%s]: [%s]", caFile, err))
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.New(fmt.Sprintf("invalid CA [%s]", caFile))
+		}
+	}
+
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}
+
+// adminAPI requests the admin API of the running node
+type adminAPI struct {
+	client *http.Client
+	url    string
+	apiKey string
+}
+
+// do sends the request with the JSON body (if any) and decodes the JSON response into out
+func (a adminAPI) do(method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	request, err := http.NewRequest(method, a.url+path, reader)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if a.apiKey != "" {
+		request.Header.Set(apirouter.APIKeyHeader, a.apiKey)
+	}
+
+	response, err := a.client.Do(request)
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to reach the admin API of the node at [%s]: [%s]", a.url, err))
+	}
+	defer response.Body.Close()
+
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return errors.New(fmt.Sprintf("admin API responded to [%s %s] with [%d]: [%s]", method, path, response.StatusCode, strings.TrimSpace(string(content))))
+	}
+	return json.Unmarshal(content, out)
+}
+
+// nodeStatus are the checkpoints of the watchers and the number of operations by status
+type nodeStatus struct {
+	Checkpoints map[string]int64 `json:"checkpoints"`
+	Transfers   map[string]int64 `json:"transfers"`
+	BurnEvents  map[string]int64 `json:"burnEvents"`
+	Fees        map[string]int64 `json:"fees"`
+}
+
+// showStatus prints the checkpoints of the watchers and the number of operations by status
+func showStatus(configuration config.Config, _ []string) error {
+	repositories := PrepareRepositories(persistence.NewDatabase(configuration.Validator.Database))
+	checkpointsService := checkpoints.New(
+		configuration.Validator.Clients.Hedera.BridgeAccount,
+		configuration.Validator.Clients.Hedera.TopicId,
+		repositories.transferStatus,
+		repositories.messageStatus)
+
+	var s nodeStatus
+	var err error
+	s.Checkpoints, err = checkpointsService.Get()
+	if err != nil {
+		return err
+	}
+	s.Transfers, err = repositories.transfer.CountByStatus()
+	if err != nil {
+		return err
+	}
+	s.BurnEvents, err = repositories.burnEvent.CountByStatus()
+	if err != nil {
+		return err
+	}
+	s.Fees, err = repositories.fee.CountByStatus()
+	if err != nil {
+		return err
+	}
+
+	return printJSON(s)
+}
+
+type transferView struct {
+	TransactionID      string          `json:"transactionId"`
+	Status             string          `json:"status"`
+	SignatureMsgStatus string          `json:"signatureMsgStatus"`
+	Receiver           string          `json:"receiver"`
+	NativeAsset        string          `json:"nativeAsset"`
+	WrappedAsset       string          `json:"wrappedAsset"`
+	Amount             string          `json:"amount"`
+	RouterAddress      string          `json:"routerAddress"`
+	RequiredSignatures int             `json:"requiredSignatures"`
+	EligibleSigners    []string        `json:"eligibleSigners"`
+	Fee                *feeView        `json:"fee,omitempty"`
+	Signatures         []signatureView `json:"signatures"`
+}
+
+type feeView struct {
+	TransactionID string `json:"transactionId"`
+	ScheduleID    string `json:"scheduleId,omitempty"`
+	SettlementID  string `json:"settlementId,omitempty"`
+	Amount        string `json:"amount"`
+	Status        string `json:"status"`
+}
+
+type signatureView struct {
+	Signer    string `json:"signer"`
+	Signature string `json:"signature"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// showTransfer prints the transfer with its fee and signatures. Pruned transfers are looked up in the archive
+func showTransfer(configuration config.Config, args []string) error {
+	if len(args) != 1 {
+		return errors.New("expected the transaction id of the transfer")
+	}
+
+	repositories := PrepareRepositories(persistence.NewDatabase(configuration.Validator.Database))
+	t, err := repositories.transfer.GetWithPreloads(args[0])
+	if err != nil {
+		return err
+	}
+	if t == nil || t.TransactionID == "" {
+		t, err = archive.New(configuration.Validator.Archive, repositories.archive).Transfer(args[0])
+		if err != nil {
+			return err
+		}
+	}
+	if t == nil {
+		return errors.New(fmt.Sprintf("transfer [%s] not found", args[0]))
+	}
+
+	view := transferView{
+		TransactionID:      t.TransactionID,
+		Status:             t.Status,
+		SignatureMsgStatus: t.SignatureMsgStatus,
+		Receiver:           t.Receiver,
+		NativeAsset:        t.NativeAsset,
+		WrappedAsset:       t.WrappedAsset,
+		Amount:             t.Amount,
+		RouterAddress:      t.RouterAddress,
+		RequiredSignatures: t.RequiredSignatures,
+		EligibleSigners:    []string{},
+		Signatures:         []signatureView{},
+	}
+	if t.EligibleSigners != "" {
+		view.EligibleSigners = strings.Split(t.EligibleSigners, ",")
+	}
+	if t.Fee.TransactionID != "" {
+		view.Fee = &feeView{
+			TransactionID: t.Fee.TransactionID,
+			ScheduleID:    t.Fee.ScheduleID.String,
+			SettlementID:  t.Fee.SettlementID.String,
+			Amount:        t.Fee.Amount,
+			Status:        t.Fee.Status,
+		}
+	}
+	for _, m := range t.Messages {
+		view.Signatures = append(view.Signatures, signatureView{
+			Signer:    m.Signer,
+			Signature: m.Signature,
+			Timestamp: m.TransactionTimestamp,
+		})
+	}
+
+	return printJSON(view)
+}
+
+// verifyConfig verifies the configuration and prints the issues found
+func verifyConfig(configuration config.Config, _ []string) error {
+	issues := configIssues(configuration)
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	if len(issues) > 0 {
+		return errors.New(fmt.Sprintf("found [%d] configuration issues", len(issues)))
+	}
+
+	fmt.Println("Configuration is valid")
+	return nil
+}
+
+// configIssues returns the issues of the configuration, which would prevent the node from starting
+func configIssues(configuration config.Config) []string {
+	var issues []string
+	issue := func(format string, args ...interface{}) {
+		issues = append(issues, fmt.Sprintf(format, args...))
+	}
+
+	v := configuration.Validator
+	switch v.Database.Driver {
+	case "", persistence.DriverPostgres:
+		if v.Database.Host == "" || v.Database.Name == "" || v.Database.Username == "" {
+			issue("database: host, name and username are required")
+		}
+	case persistence.DriverSQLite:
+		if v.Database.Name == "" {
+			issue("database.name: the path of the SQLite database is required")
+		}
+	default:
+		issue("database.driver: unsupported driver [%s]", v.Database.Driver)
+	}
+
+	h := v.Clients.Hedera
+	if _, err := hedera.AccountIDFromString(h.Operator.AccountId); err != nil {
+		issue("hedera.operator.account_id: invalid account [%s]", h.Operator.AccountId)
+	}
+	if _, err := hedera.PrivateKeyFromString(h.Operator.PrivateKey); err != nil {
+		issue("hedera.operator.private_key: invalid private key")
+	}
+	if _, err := hedera.AccountIDFromString(h.BridgeAccount); err != nil {
+		issue("hedera.bridge_account: invalid account [%s]", h.BridgeAccount)
+	}
+	if _, err := hedera.AccountIDFromString(h.PayerAccount); err != nil {
+		issue("hedera.payer_account: invalid account [%s]", h.PayerAccount)
+	}
+	if _, err := hedera.TopicIDFromString(h.TopicId); err != nil {
+		issue("hedera.topic_id: invalid topic [%s]", h.TopicId)
+	}
+	if h.FeePercentage < 0 || h.FeePercentage > 100000 {
+		issue("hedera.fee_percentage: [%d] is out of range [0; 100000]", h.FeePercentage)
+	}
+	if h.MemberRegistry.File == "" && len(h.Members) == 0 {
+		issue("hedera.members: either members or a member registry is required")
+	}
+	for _, member := range h.Members {
+		if _, err := hedera.AccountIDFromString(member); err != nil {
+			issue("hedera.members: invalid account [%s]", member)
+		}
+	}
+	if v.Clients.MirrorNode.ApiAddress == "" {
+		issue("mirror_node.api_address: is required")
+	}
+
+	e := v.Clients.Ethereum
+	if e.NodeUrl == "" {
+		issue("ethereum.node_url: is required")
+	}
+	if !common.IsHexAddress(e.RouterContractAddress) {
+		issue("ethereum.router_contract_address: invalid address [%s]", e.RouterContractAddress)
+	}
+	if _, err := crypto.HexToECDSA(e.PrivateKey); err != nil {
+		issue("ethereum.private_key: invalid private key")
+	}
+
+	q := v.Quorum
+	switch q.Type {
+	case "", quorum.TypeMajority:
+	case quorum.TypeFraction:
+		if q.Numerator <= 0 || q.Denominator <= 0 || q.Numerator > q.Denominator {
+			issue("quorum: invalid fraction [%d/%d]", q.Numerator, q.Denominator)
+		}
+	case quorum.TypeAbsolute:
+		if q.Signatures <= 0 {
+			issue("quorum: invalid signatures [%d]", q.Signatures)
+		}
+	default:
+		issue("quorum.type: invalid type [%s]", q.Type)
+	}
+
+	admin := v.Admin
+	if admin.TLS.ClientCAFile != "" && (admin.Port == "" || admin.TLS.CertFile == "" || admin.TLS.KeyFile == "") {
+		issue("admin.tls: client_ca_file requires admin port, cert_file and key_file")
+	}
+
+	w := v.Webhooks
+	for _, subscription := range w.Subscriptions {
+		if _, err := url.ParseRequestURI(subscription.URL); err != nil {
+			issue("webhooks.subscriptions: invalid url [%s]", subscription.URL)
+		}
+		if subscription.Secret == "" {
+			issue("webhooks.subscriptions: secret of [%s] is required", subscription.URL)
+		}
+	}
+	if len(w.Subscriptions) > 0 && (w.MaxAttempts <= 0 || w.Backoff <= 0 || w.Timeout <= 0) {
+		issue("webhooks: max_attempts, backoff and timeout must be positive")
+	}
+	return issues
+}
+
+// showAddress prints the Ethereum address of the validator, which signs the authorisation messages,
+// and the public key of the Hedera operator
+func showAddress(configuration config.Config, _ []string) error {
+	ethKey, err := crypto.HexToECDSA(configuration.Validator.Clients.Ethereum.PrivateKey)
+	if err != nil {
+		return errors.New("invalid Ethereum private key")
+	}
+	hederaKey, err := hedera.PrivateKeyFromString(configuration.Validator.Clients.Hedera.Operator.PrivateKey)
+	if err != nil {
+		return errors.New("invalid Hedera operator private key")
+	}
+
+	return printJSON(map[string]string{
+		"ethereumAddress": crypto.PubkeyToAddress(*ethKey.Public().(*ecdsa.PublicKey)).String(),
+		"hederaAccount":   configuration.Validator.Clients.Hedera.Operator.AccountId,
+		"hederaPublicKey": hederaKey.PublicKey().String(),
+	})
+}
+
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/limechain/hedera-eth-bridge-validator/config"
+	tc "github.com/limechain/hedera-eth-bridge-validator/test/test-config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveCommand(t *testing.T) {
+	command, args, err := resolveCommand([]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, "serve", command.name)
+	assert.Empty(t, args)
+
+	command, args, err = resolveCommand([]string{"transfer", "show", "0.0.1-1-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "transfer show", command.name)
+	assert.Equal(t, []string{"0.0.1-1-1"}, args)
+
+	command, args, err = resolveCommand([]string{"recover", "--from", "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "recover", command.name)
+	assert.Equal(t, []string{"--from", "1"}, args)
+
+	_, _, err = resolveCommand([]string{"transfer", "delete"})
+	assert.Error(t, err)
+}
+
+func TestConfigIssues(t *testing.T) {
+	configuration := tc.TestConfig
+	configuration.Validator.Clients.MirrorNode.ApiAddress = "https://testnet.mirrornode.hedera.com/api/v1/"
+	assert.Empty(t, configIssues(configuration))
+
+	configuration.Validator.Database = config.Database{Driver: "sqlite", Name: "validator.db"}
+	assert.Empty(t, configIssues(configuration))
+
+	configuration.Validator.Database.Driver = "mysql"
+	configuration.Validator.Clients.Ethereum.RouterContractAddress = "invalid"
+	configuration.Validator.Quorum.Type = "unknown"
+	configuration.Validator.Webhooks = config.Webhooks{Subscriptions: []config.WebhookSubscription{{URL: "invalid"}}}
+
+	assert.Equal(t, []string{
+		"database.driver: unsupported driver [mysql]",
+		"ethereum.router_contract_address: invalid address [invalid]",
+		"quorum.type: invalid type [unknown]",
+		"webhooks.subscriptions: invalid url [invalid]",
+		"webhooks.subscriptions: secret of [invalid] is required",
+		"webhooks: max_attempts, backoff and timeout must be positive",
+	}, configIssues(configuration))
+}
+
+func TestRecoverThroughAdminAPI(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("X-API-Key"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(map[string]interface{}{"from": 1, "to": 2, "running": true})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"from": 1, "to": 2, "running": false})
+	}))
+	defer server.Close()
+
+	configuration := tc.TestConfig
+	configuration.Validator.Admin = config.Admin{APIKeys: []string{"key"}}
+
+	err := recoverInterval(configuration, []string{"--from", "1", "--to", "2", "--url", server.URL + "/api/admin"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"POST /api/admin/recovery key", "GET /api/admin/recovery key"}, requests)
+}
+
+func TestRecoverRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error":"recovery-in-progress"}`))
+	}))
+	defer server.Close()
+
+	err := recoverInterval(tc.TestConfig, []string{"--from", "1", "--to", "2", "--url", server.URL})
+
+	assert.Error(t, err)
+}
+
+func TestAdminURL(t *testing.T) {
+	assert.Equal(t, "http://localhost:5200/api/admin", adminURL(config.Validator{Port: "5200"}))
+	assert.Equal(t, "https://localhost:5300/api/admin", adminURL(config.Validator{Port: "5200", Admin: config.Admin{Port: "5300", TLS: config.AdminTLS{CertFile: "admin.crt"}}}))
+}
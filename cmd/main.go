@@ -32,17 +32,41 @@ import (
 	tw "github.com/limechain/hedera-eth-bridge-validator/app/process/watcher/transfer"
 	apirouter "github.com/limechain/hedera-eth-bridge-validator/app/router"
 	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/router/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/router/checkpoint"
+	configuration_router "github.com/limechain/hedera-eth-bridge-validator/app/router/configuration"
+	"github.com/limechain/hedera-eth-bridge-validator/app/router/equivocation"
 	"github.com/limechain/hedera-eth-bridge-validator/app/router/healthcheck"
+	"github.com/limechain/hedera-eth-bridge-validator/app/router/metrics"
+	"github.com/limechain/hedera-eth-bridge-validator/app/router/pause"
+	recovery_router "github.com/limechain/hedera-eth-bridge-validator/app/router/recovery"
+	"github.com/limechain/hedera-eth-bridge-validator/app/router/refund"
+	rejected_message "github.com/limechain/hedera-eth-bridge-validator/app/router/rejected-message"
 	"github.com/limechain/hedera-eth-bridge-validator/app/router/transfer"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 	log "github.com/sirupsen/logrus"
+	"os"
 )
 
 func main() {
+	command, args, err := resolveCommand(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		printUsage()
+		os.Exit(2)
+	}
+
 	// Config
 	configuration := config.LoadConfig()
 	config.InitLogger(configuration.Validator.LogLevel)
 
+	err = command.run(configuration, args)
+	if err != nil {
+		log.Fatalf("Command [%s] failed. Error: [%s]", command.name, err)
+	}
+}
+
+// serve starts the validator node
+func serve(configuration config.Config, _ []string) error {
 	// Prepare Clients
 	clients := PrepareClients(configuration.Validator.Clients)
 
@@ -54,43 +78,53 @@ func main() {
 		log.Println("Starting Validator Node in REST-API Mode only. No Watchers or Handlers will start.")
 		services = PrepareApiOnlyServices(configuration, *clients)
 	} else {
-		db := persistence.NewDatabase(configuration.Validator.Database)
+		dbConfig := configuration.Validator.Database
+		if configuration.Validator.Recovery.DryRun {
+			// The dry-run does not persist anything, so the schema is only checked
+			dbConfig.AutoMigrate = false
+		}
+		db := persistence.NewDatabase(dbConfig)
 		// Prepare repositories
 		repositories := PrepareRepositories(db)
 		// Prepare Services
 		services = PrepareServices(configuration, *clients, *repositories)
+		// Pending deliveries are resumed before recovery and the watchers record new ones.
+		// Background jobs are not started on a dry-run, since it does not persist anything
+		if !configuration.Validator.Recovery.DryRun {
+			services.webhooks.Start()
+			services.pending.Start()
+		}
 
 		// Execute Recovery Process. Computing Watchers starting timestamp
-		err, watchersStartTimestamp := executeRecoveryProcess(configuration, *services, *repositories, *clients)
+		recoveryProcess, err, watchersStartTimestamp := executeRecoveryProcess(configuration, *services, *repositories, *clients)
 		if err != nil {
 			log.Fatal(err)
 		}
+		services.recovery = recoveryProcess
 		initializeServerPairs(server, services, repositories, clients, configuration, watchersStartTimestamp)
+		services.settlement.Start()
+		services.archive.Start()
 	}
 
 	apiRouter := initializeAPIRouter(services)
+	if !configuration.Validator.RestApiOnly {
+		initializeAdminRouter(server, apiRouter, services, configuration)
+	}
 
 	// Start
 	server.Run(apiRouter.Router, fmt.Sprintf(":%s", configuration.Validator.Port))
+	return nil
 }
 
-func executeRecoveryProcess(configuration config.Config, services Services, repository Repositories, client Clients) (error, int64) {
-	r, err := recovery.NewProcess(configuration.Validator,
-		services.transfers,
-		services.messages,
-		services.contracts,
-		repository.transferStatus,
-		repository.messageStatus,
-		repository.transfer,
-		client.MirrorNode,
-		client.HederaNode)
-	if err != nil {
-		log.Fatalf("Could not prepare Recovery process. Error [%s]", err)
-	}
+func executeRecoveryProcess(configuration config.Config, services Services, repository Repositories, client Clients) (*recovery.Recovery, error, int64) {
+	r := prepareRecoveryProcess(configuration, services, repository, client)
 	transfersRecoveryFrom, messagesRecoveryFrom, recoveryTo, err := r.ComputeIntervals()
 	if err != nil {
 		log.Fatalf("Could not compute recovery interval. Error [%s]", err)
 	}
+	if configuration.Validator.Recovery.DryRun {
+		reportRecovery(r, transfersRecoveryFrom, messagesRecoveryFrom, recoveryTo)
+	}
 	if transfersRecoveryFrom <= 0 {
 		log.Infof("Skipping Recovery process. Nothing to recover")
 	} else {
@@ -99,7 +133,54 @@ func executeRecoveryProcess(configuration config.Config, services Services, repo
 			log.Fatalf("Recovery Process with interval [%d;%d] finished unsuccessfully. Error: [%s].", transfersRecoveryFrom, recoveryTo, err)
 		}
 	}
-	return err, recoveryTo
+	return r, err, recoveryTo
+}
+
+// reportRecovery prints the report of the recovery dry-run to the standard output and exits, without starting the node
+func reportRecovery(r *recovery.Recovery, transfersFrom, messagesFrom, to int64) {
+	if transfersFrom <= 0 {
+		// Nothing to recover, only the unfinished operations are reported
+		transfersFrom, messagesFrom = to, to
+	}
+
+	report, err := r.DryRun(transfersFrom, messagesFrom, to)
+	if err != nil {
+		log.Fatalf("Recovery dry-run with interval [%d;%d] finished unsuccessfully. Error: [%s].", transfersFrom, to, err)
+	}
+
+	err = printJSON(report)
+	if err != nil {
+		log.Fatalf("Could not encode recovery dry-run report. Error [%s]", err)
+	}
+	os.Exit(0)
+}
+
+func prepareRecoveryProcess(configuration config.Config, services Services, repository Repositories, client Clients) *recovery.Recovery {
+	r, err := recovery.NewProcess(configuration.Validator,
+		services.transfers,
+		services.messages,
+		services.contracts,
+		repository.transferStatus,
+		repository.messageStatus,
+		repository.transfer,
+		client.MirrorNode,
+		client.HederaNode,
+		services.assetPolicy,
+		services.refunds,
+		repository.refund,
+		services.pause,
+		services.burnEvents,
+		repository.burnEvent,
+		client.Ethereum,
+		services.decimals,
+		repository.fee,
+		services.scheduled,
+		services.archive,
+		services.webhooks)
+	if err != nil {
+		log.Fatalf("Could not prepare Recovery process. Error [%s]", err)
+	}
+	return r
 }
 
 func initializeAPIRouter(services *Services) *apirouter.APIRouter {
@@ -107,9 +188,35 @@ func initializeAPIRouter(services *Services) *apirouter.APIRouter {
 	apiRouter.AddV1Router(healthcheck.Route, healthcheck.NewRouter())
 	apiRouter.AddV1Router(transfer.Route, transfer.NewRouter(services.transfers))
 	apiRouter.AddV1Router(burn_event.Route, burn_event.NewRouter(services.burnEvents))
+	apiRouter.AddV1Router(rejected_message.Route, rejected_message.NewRouter(services.messages))
+	apiRouter.AddV1Router(equivocation.Route, equivocation.NewRouter(services.messages))
+	apiRouter.AddV1Router(refund.Route, refund.NewRouter(services.refunds))
+	apiRouter.AddV1Router(metrics.Route, metrics.NewRouter())
 	return apiRouter
 }
 
+// initializeAdminRouter serves the admin API either on its own port or as part of the REST API
+func initializeAdminRouter(server *server.Server, apiRouter *apirouter.APIRouter, services *Services, configuration config.Config) {
+	adminRouter := apirouter.NewAdminRouter(configuration.Validator.Admin)
+	if !adminRouter.Enabled() {
+		log.Infof("Admin API is disabled. No API keys or client CA are configured.")
+		return
+	}
+
+	adminRouter.AddRouter(transfer.Route, transfer.NewAdminRouter(services.transfers))
+	adminRouter.AddRouter(burn_event.Route, burn_event.NewAdminRouter(services.burnEvents))
+	adminRouter.AddRouter(checkpoint.Route, checkpoint.NewRouter(services.checkpoints))
+	adminRouter.AddRouter(recovery_router.Route, recovery_router.NewRouter(services.recovery))
+	adminRouter.AddRouter(pause.Route, pause.NewRouter(services.pause))
+	adminRouter.AddRouter(configuration_router.Route, configuration_router.NewRouter(configuration))
+
+	if adminRouter.Standalone() {
+		server.RunAdmin(adminRouter.Handler(), configuration.Validator.Admin)
+	} else {
+		apiRouter.AddAdminRouter(adminRouter)
+	}
+}
+
 func initializeServerPairs(server *server.Server, services *Services, repositories *Repositories, clients *Clients, configuration config.Config, watchersTimestamp int64) {
 	server.AddPair(
 		addTransferWatcher(
@@ -118,7 +225,9 @@ func initializeServerPairs(server *server.Server, services *Services, repositori
 			clients.MirrorNode,
 			&repositories.transferStatus,
 			watchersTimestamp,
-			services.contracts),
+			services.contracts,
+			services.assetPolicy,
+			services.refunds),
 		th.NewHandler(services.transfers))
 
 	server.AddPair(
@@ -131,10 +240,21 @@ func initializeServerPairs(server *server.Server, services *Services, repositori
 			configuration.Validator.Clients.Hedera.TopicId,
 			repositories.transfer,
 			repositories.message,
-			services.contracts,
-			services.messages))
+			services.quorum,
+			services.messages,
+			services.pending,
+			services.pause,
+			services.webhooks))
 
-	server.AddPair(ethereum.NewWatcher(services.contracts, clients.Ethereum, configuration.Validator.Clients.Ethereum),
+	server.AddPair(
+		ethereum.NewWatcher(
+			services.contracts,
+			clients.Ethereum,
+			configuration.Validator.Clients.Ethereum,
+			services.burnEvents,
+			services.assetPolicy,
+			services.decimals,
+			services.webhooks),
 		beh.NewHandler(services.burnEvents))
 }
 
@@ -144,6 +264,8 @@ func addTransferWatcher(configuration *config.Config,
 	repository *repository.Status,
 	startTimestamp int64,
 	contractService service.Contracts,
+	assetPolicy service.AssetPolicy,
+	refunds service.Refunds,
 ) *tw.Watcher {
 	account := configuration.Validator.Clients.Hedera.BridgeAccount
 
@@ -155,7 +277,9 @@ func addTransferWatcher(configuration *config.Config,
 		configuration.Validator.Clients.MirrorNode.PollingInterval,
 		*repository,
 		startTimestamp,
-		contractService)
+		contractService,
+		assetPolicy,
+		refunds)
 }
 
 func addConsensusTopicWatcher(configuration *config.Config,
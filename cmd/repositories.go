@@ -19,32 +19,62 @@ package main
 import (
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/database"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/archive"
 	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/persistence/burn-event"
+	control_action "github.com/limechain/hedera-eth-bridge-validator/app/persistence/control-action"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/equivocation"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/fee"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/message"
+	pending_message "github.com/limechain/hedera-eth-bridge-validator/app/persistence/pending-message"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/refund"
+	rejected_message "github.com/limechain/hedera-eth-bridge-validator/app/persistence/rejected-message"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/status"
+	status_transition "github.com/limechain/hedera-eth-bridge-validator/app/persistence/status-transition"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/transfer"
+	unit_of_work "github.com/limechain/hedera-eth-bridge-validator/app/persistence/unit-of-work"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/volume"
+	webhook_delivery "github.com/limechain/hedera-eth-bridge-validator/app/persistence/webhook-delivery"
 )
 
 // Repositories struct holding the referenced repositories
 type Repositories struct {
-	transferStatus repository.Status
-	messageStatus  repository.Status
-	transfer       repository.Transfer
-	message        repository.Message
-	burnEvent      repository.BurnEvent
-	fee            repository.Fee
+	transferStatus   repository.Status
+	messageStatus    repository.Status
+	transfer         repository.Transfer
+	message          repository.Message
+	burnEvent        repository.BurnEvent
+	fee              repository.Fee
+	pendingMessage   repository.PendingMessage
+	rejectedMessage  repository.RejectedMessage
+	equivocation     repository.Equivocation
+	volume           repository.Volume
+	refund           repository.Refund
+	controlAction    repository.ControlAction
+	archive          repository.Archive
+	unitOfWork       repository.UnitOfWork
+	statusTransition repository.StatusTransition
+	webhookDelivery  repository.WebhookDelivery
 }
 
 // PrepareRepositories initialises connection to the Database and instantiates the repositories
 func PrepareRepositories(db database.Database) *Repositories {
 	connection := db.GetConnection()
 	return &Repositories{
-		transferStatus: status.NewRepositoryForStatus(connection, status.Transfer),
-		messageStatus:  status.NewRepositoryForStatus(connection, status.Message),
-		transfer:       transfer.NewRepository(connection),
-		message:        message.NewRepository(connection),
-		burnEvent:      burn_event.NewRepository(connection),
-		fee:            fee.NewRepository(connection),
+		transferStatus:   status.NewRepositoryForStatus(connection, status.Transfer),
+		messageStatus:    status.NewRepositoryForStatus(connection, status.Message),
+		transfer:         transfer.NewRepository(connection),
+		message:          message.NewRepository(connection),
+		burnEvent:        burn_event.NewRepository(connection),
+		fee:              fee.NewRepository(connection),
+		pendingMessage:   pending_message.NewRepository(connection),
+		rejectedMessage:  rejected_message.NewRepository(connection),
+		equivocation:     equivocation.NewRepository(connection),
+		volume:           volume.NewRepository(connection),
+		refund:           refund.NewRepository(connection),
+		controlAction:    control_action.NewRepository(connection),
+		archive:          archive.NewRepository(connection),
+		unitOfWork:       unit_of_work.New(connection),
+		statusTransition: status_transition.NewRepository(connection),
+		webhookDelivery:  webhook_delivery.NewRepository(connection),
 	}
 }
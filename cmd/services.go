@@ -18,14 +18,25 @@ package main
 
 import (
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/archive"
 	burn_event "github.com/limechain/hedera-eth-bridge-validator/app/services/burn-event"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/checkpoints"
 	"github.com/limechain/hedera-eth-bridge-validator/app/services/contracts"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/decimals"
 	"github.com/limechain/hedera-eth-bridge-validator/app/services/fee/calculator"
 	"github.com/limechain/hedera-eth-bridge-validator/app/services/fee/distributor"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/fee/settlement"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/members"
 	"github.com/limechain/hedera-eth-bridge-validator/app/services/messages"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/pause"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/pending"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/policy"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/quorum"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/refund"
 	"github.com/limechain/hedera-eth-bridge-validator/app/services/scheduled"
 	"github.com/limechain/hedera-eth-bridge-validator/app/services/signer/eth"
 	"github.com/limechain/hedera-eth-bridge-validator/app/services/transfers"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/webhooks"
 	"github.com/limechain/hedera-eth-bridge-validator/config"
 )
 
@@ -33,21 +44,56 @@ import (
 type Services struct {
 	signer      service.Signer
 	contracts   service.Contracts
+	members     service.MemberRegistry
+	quorum      service.Quorum
 	transfers   service.Transfers
 	messages    service.Messages
 	burnEvents  service.BurnEvent
 	fees        service.Fee
 	distributor service.Distributor
 	scheduled   service.Scheduled
+	settlement  service.FeeSettlement
+	pending     service.PendingSignatures
+	decimals    service.Decimals
+	assetPolicy service.AssetPolicy
+	refunds     service.Refunds
+	pause       service.Pause
+	checkpoints service.Checkpoints
+	archive     service.Archive
+	recovery    service.Recovery
+	webhooks    service.Webhooks
 }
 
 // PrepareServices instantiates all the necessary services with their required context and parameters
 func PrepareServices(c config.Config, clients Clients, repositories Repositories) *Services {
 	ethSigner := eth.NewEthSigner(c.Validator.Clients.Ethereum.PrivateKey)
 	contracts := contracts.NewService(clients.Ethereum, c.Validator.Clients.Ethereum)
-	fees := calculator.New(c.Validator.Clients.Hedera.FeePercentage)
-	distributor := distributor.New(c.Validator.Clients.Hedera.Members)
+	fees := calculator.New(c.Validator.Clients.Hedera.FeePercentage, c.Validator.Clients.Hedera.FeePolicy)
+	members := members.New(contracts, c.Validator.Clients.Hedera.MemberRegistry, c.Validator.Clients.Hedera.Members)
+	distributor := distributor.New(members, c.Validator.Clients.Hedera.MemberWeights)
+	quorum := quorum.New(c.Validator.Quorum, members)
 	scheduled := scheduled.New(c.Validator.Clients.Hedera.PayerAccount, clients.HederaNode, clients.MirrorNode)
+	webhooks := webhooks.New(c.Validator.Webhooks, repositories.webhookDelivery)
+	settlement := settlement.New(
+		c.Validator.Clients.Hedera.FeeSettlement,
+		c.Validator.Clients.Hedera.BridgeAccount,
+		repositories.fee,
+		repositories.transferStatus,
+		distributor,
+		scheduled,
+		webhooks)
+	pending := pending.New(c.Validator.PendingSignatures, repositories.transfer, repositories.pendingMessage)
+	decimals := decimals.New(clients.MirrorNode, contracts)
+	assetPolicy := policy.New(c.Validator.AssetPolicy, repositories.volume)
+	pause := pause.New(
+		c.Validator.Pause,
+		c.Validator.Clients.Hedera.TopicId,
+		clients.HederaNode,
+		contracts,
+		ethSigner,
+		quorum,
+		repositories.controlAction)
+	archive := archive.New(c.Validator.Archive, repositories.archive)
 
 	transfers := transfers.NewService(
 		clients.HederaNode,
@@ -56,38 +102,85 @@ func PrepareServices(c config.Config, clients Clients, repositories Repositories
 		ethSigner,
 		repositories.transfer,
 		repositories.fee,
+		repositories.statusTransition,
 		fees,
 		distributor,
 		c.Validator.Clients.Hedera.TopicId,
 		c.Validator.Clients.Hedera.BridgeAccount,
-		scheduled)
+		scheduled,
+		settlement,
+		quorum,
+		pending,
+		decimals,
+		pause,
+		archive,
+		webhooks)
 
 	messages := messages.NewService(
 		ethSigner,
 		contracts,
 		repositories.transfer,
 		repositories.message,
+		repositories.rejectedMessage,
+		repositories.equivocation,
+		repositories.unitOfWork,
+		decimals,
 		clients.HederaNode,
 		clients.MirrorNode,
 		clients.Ethereum,
 		c.Validator.Clients.Hedera.TopicId)
 
+	refunds := refund.New(
+		c.Validator.Clients.Hedera.Refund,
+		c.Validator.Clients.Hedera.BridgeAccount,
+		repositories.transfer,
+		repositories.refund,
+		repositories.unitOfWork,
+		distributor,
+		scheduled,
+		settlement,
+		pause,
+		webhooks)
+
 	burnEvent := burn_event.NewService(
 		c.Validator.Clients.Hedera.BridgeAccount,
 		repositories.burnEvent,
 		repositories.fee,
+		repositories.unitOfWork,
+		repositories.statusTransition,
 		distributor,
 		scheduled,
-		fees)
+		fees,
+		settlement,
+		decimals,
+		pause,
+		archive,
+		webhooks)
 
 	return &Services{
 		signer:      ethSigner,
 		contracts:   contracts,
+		members:     members,
+		quorum:      quorum,
 		transfers:   transfers,
 		messages:    messages,
 		burnEvents:  burnEvent,
 		fees:        fees,
 		distributor: distributor,
+		scheduled:   scheduled,
+		settlement:  settlement,
+		pending:     pending,
+		decimals:    decimals,
+		assetPolicy: assetPolicy,
+		refunds:     refunds,
+		pause:       pause,
+		archive:     archive,
+		webhooks:    webhooks,
+		checkpoints: checkpoints.New(
+			c.Validator.Clients.Hedera.BridgeAccount,
+			c.Validator.Clients.Hedera.TopicId,
+			repositories.transferStatus,
+			repositories.messageStatus),
 	}
 }
 
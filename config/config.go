@@ -63,10 +63,76 @@ func GetConfig(config *Config, path string) error {
 	return err
 }
 
+// LoadFeeRules parses the fee rules from the provided YAML file
+func LoadFeeRules(path string) (FeeRules, error) {
+	var rules FeeRules
+	filename, err := filepath.Abs(path)
+	if err != nil {
+		return rules, err
+	}
+
+	yamlFile, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return rules, err
+	}
+
+	err = yaml.Unmarshal(yamlFile, &rules)
+	return rules, err
+}
+
+// LoadMemberRegistry parses the registered members from the provided YAML file
+func LoadMemberRegistry(path string) ([]RegisteredMember, error) {
+	var registry struct {
+		Members []RegisteredMember `yaml:"members"`
+	}
+	filename, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	yamlFile, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	err = yaml.Unmarshal(yamlFile, &registry)
+	return registry.Members, err
+}
+
 type Config struct {
 	Validator Validator `yaml:"validator"`
 }
 
+const redacted = "<redacted>"
+
+// Redacted returns a copy of the configuration with the secrets redacted
+func (c Config) Redacted() Config {
+	redact := func(secret string) string {
+		if secret == "" {
+			return ""
+		}
+		return redacted
+	}
+
+	c.Validator.Database.Password = redact(c.Validator.Database.Password)
+	c.Validator.Clients.Ethereum.PrivateKey = redact(c.Validator.Clients.Ethereum.PrivateKey)
+	c.Validator.Clients.Hedera.Operator.PrivateKey = redact(c.Validator.Clients.Hedera.Operator.PrivateKey)
+
+	apiKeys := make([]string, len(c.Validator.Admin.APIKeys))
+	for i, key := range c.Validator.Admin.APIKeys {
+		apiKeys[i] = redact(key)
+	}
+	c.Validator.Admin.APIKeys = apiKeys
+
+	subscriptions := make([]WebhookSubscription, len(c.Validator.Webhooks.Subscriptions))
+	for i, subscription := range c.Validator.Webhooks.Subscriptions {
+		subscription.Secret = redact(subscription.Secret)
+		subscriptions[i] = subscription
+	}
+	c.Validator.Webhooks.Subscriptions = subscriptions
+	return c
+}
+
 type Validator struct {
 	LogLevel    string   `yaml:"log_level" env:"VALIDATOR_LOG_LEVEL"`
 	RestApiOnly bool     `yaml:"rest_api_only" env:"VALIDATOR_REST_API_ONLY"`
@@ -74,6 +140,93 @@ type Validator struct {
 	Database    Database `yaml:"database"`
 	Clients     Clients  `yaml:"clients"`
 	Recovery    Recovery `yaml:"recovery"`
+	Quorum      Quorum   `yaml:"quorum"`
+	// PendingSignatures configures the buffering of signature messages, received before their transfer is processed
+	PendingSignatures PendingSignatures `yaml:"pending_signatures"`
+	AssetPolicy       AssetPolicy       `yaml:"asset_policy"`
+	Pause             Pause             `yaml:"pause"`
+	Admin             Admin             `yaml:"admin"`
+	Archive           Archive           `yaml:"archive"`
+	Webhooks          Webhooks          `yaml:"webhooks"`
+}
+
+// Archive configures the periodic export of completed and failed records to compressed JSONL
+// files and their pruning from the database. Archived records remain queryable by the REST API.
+type Archive struct {
+	Enabled   bool   `yaml:"enabled" env:"VALIDATOR_ARCHIVE_ENABLED"`
+	Directory string `yaml:"directory" env:"VALIDATOR_ARCHIVE_DIRECTORY"`
+	// RetentionDays is the number of days, for which terminal records are kept in the database
+	RetentionDays int64 `yaml:"retention_days" env:"VALIDATOR_ARCHIVE_RETENTION_DAYS"`
+	// Interval is how often (in seconds) records are archived
+	Interval time.Duration `yaml:"interval" env:"VALIDATOR_ARCHIVE_INTERVAL"`
+	// BatchSize is the max number of records, written to a single archive file
+	BatchSize int `yaml:"batch_size" env:"VALIDATOR_ARCHIVE_BATCH_SIZE"`
+}
+
+// Webhooks configures the subscriptions, notified with signed JSON payloads about the lifecycle events
+// of transfers and burn events. Failed deliveries are retried with exponential backoff
+type Webhooks struct {
+	Subscriptions []WebhookSubscription `yaml:"subscriptions"`
+	// MaxAttempts is the max number of delivery attempts of a single event
+	MaxAttempts int `yaml:"max_attempts" env:"VALIDATOR_WEBHOOKS_MAX_ATTEMPTS"`
+	// Backoff is the time in seconds before the first retry, doubled with every next one
+	Backoff time.Duration `yaml:"backoff" env:"VALIDATOR_WEBHOOKS_BACKOFF"`
+	// Timeout is the time in seconds, in which the subscription has to respond to a delivery
+	Timeout time.Duration `yaml:"timeout" env:"VALIDATOR_WEBHOOKS_TIMEOUT"`
+}
+
+// WebhookSubscription receives the given events, or all of them if none are set.
+// Payloads are signed with HMAC-SHA256 of the secret
+type WebhookSubscription struct {
+	URL    string   `yaml:"url"`
+	Secret string   `yaml:"secret"`
+	Events []string `yaml:"events"`
+}
+
+type Pause struct {
+	// RetryInterval is how often (in seconds) operations, queued because of a paused wrapped token, are retried
+	RetryInterval time.Duration `yaml:"retry_interval" env:"VALIDATOR_PAUSE_RETRY_INTERVAL"`
+}
+
+// Admin configures the access to the admin API. Requests are authenticated with API keys and/or
+// client certificates (mTLS). The admin API is disabled, if neither is configured
+type Admin struct {
+	APIKeys []string `yaml:"api_keys" env:"VALIDATOR_ADMIN_API_KEYS"`
+	// Port serves the admin API separately from the public API, if set
+	Port string   `yaml:"port" env:"VALIDATOR_ADMIN_PORT"`
+	TLS  AdminTLS `yaml:"tls"`
+}
+
+// AdminTLS serves the admin API over TLS, requiring client certificates signed by the client CA
+type AdminTLS struct {
+	CertFile     string `yaml:"cert_file" env:"VALIDATOR_ADMIN_TLS_CERT_FILE"`
+	KeyFile      string `yaml:"key_file" env:"VALIDATOR_ADMIN_TLS_KEY_FILE"`
+	ClientCAFile string `yaml:"client_ca_file" env:"VALIDATOR_ADMIN_TLS_CLIENT_CA_FILE"`
+}
+
+// AssetPolicy limits the operations bridged per native asset. Once any assets are configured,
+// operations of other assets are not allowed. Amounts are in the units of the native asset
+// and `0` means no limit. Out-of-policy operations are held for manual review.
+type AssetPolicy struct {
+	Assets map[string]AssetLimits `yaml:"assets"`
+}
+
+type AssetLimits struct {
+	MinAmount int64 `yaml:"min_amount"`
+	MaxAmount int64 `yaml:"max_amount"`
+	// DailyCap limits the volume of the asset, bridged in the last 24 hours in each direction
+	DailyCap int64 `yaml:"daily_cap"`
+	// ReceiverDailyCap limits the volume of the asset, bridged to a single receiver in the last 24 hours in each direction
+	ReceiverDailyCap int64 `yaml:"receiver_daily_cap"`
+	// Paused holds all operations of the asset
+	Paused bool `yaml:"paused"`
+}
+
+type PendingSignatures struct {
+	// TTL is the time in seconds, after which a buffered signature message expires
+	TTL time.Duration `yaml:"ttl" env:"VALIDATOR_PENDING_SIGNATURES_TTL"`
+	// ExpirationInterval is how often (in seconds) expired signature messages are removed
+	ExpirationInterval time.Duration `yaml:"expiration_interval" env:"VALIDATOR_PENDING_SIGNATURES_EXPIRATION_INTERVAL"`
 }
 
 type Clients struct {
@@ -82,8 +235,19 @@ type Clients struct {
 	Hedera     Hedera     `yaml:"hedera"`
 }
 
+// Quorum configures the number of signatures required for a transfer out of its eligible signers.
+// Supported types are `majority` (more than half of the signers), `fraction` (at least
+// `numerator`/`denominator` of the signers) and `absolute` (a fixed number of `signatures`).
+type Quorum struct {
+	Type        string `yaml:"type" env:"VALIDATOR_QUORUM_TYPE"`
+	Numerator   int    `yaml:"numerator" env:"VALIDATOR_QUORUM_NUMERATOR"`
+	Denominator int    `yaml:"denominator" env:"VALIDATOR_QUORUM_DENOMINATOR"`
+	Signatures  int    `yaml:"signatures" env:"VALIDATOR_QUORUM_SIGNATURES"`
+}
+
 type Recovery struct {
 	StartTimestamp int64 `yaml:"start_timestamp" env:"VALIDATOR_RECOVERY_START_TIMESTAMP"`
+	DryRun         bool  `yaml:"dry_run" env:"VALIDATOR_RECOVERY_DRY_RUN"`
 }
 
 type Ethereum struct {
@@ -94,13 +258,87 @@ type Ethereum struct {
 }
 
 type Hedera struct {
-	NetworkType   string   `yaml:"network_type" env:"VALIDATOR_CLIENTS_HEDERA_NETWORK_TYPE"`
-	Operator      Operator `yaml:"operator"`
-	BridgeAccount string   `yaml:"bridge_account" env:"VALIDATOR_CLIENTS_HEDERA_BRIDGE_ACCOUNT"`
-	PayerAccount  string   `yaml:"payer_account" env:"VALIDATOR_CLIENTS_HEDERA_PAYER_ACCOUNT"`
-	TopicId       string   `yaml:"topic_id" env:"VALIDATOR_CLIENTS_HEDERA_TOPIC_ID"`
-	FeePercentage int64    `yaml:"fee_percentage" env:"VALIDATOR_CLIENTS_HEDERA_FEE_PERCENTAGE"`
-	Members       []string `yaml:"members" env:"VALIDATOR_CLIENTS_HEDERA_MEMBERS"`
+	NetworkType   string    `yaml:"network_type" env:"VALIDATOR_CLIENTS_HEDERA_NETWORK_TYPE"`
+	Operator      Operator  `yaml:"operator"`
+	BridgeAccount string    `yaml:"bridge_account" env:"VALIDATOR_CLIENTS_HEDERA_BRIDGE_ACCOUNT"`
+	PayerAccount  string    `yaml:"payer_account" env:"VALIDATOR_CLIENTS_HEDERA_PAYER_ACCOUNT"`
+	TopicId       string    `yaml:"topic_id" env:"VALIDATOR_CLIENTS_HEDERA_TOPIC_ID"`
+	FeePercentage int64     `yaml:"fee_percentage" env:"VALIDATOR_CLIENTS_HEDERA_FEE_PERCENTAGE"`
+	FeePolicy     FeePolicy `yaml:"fee_policy"`
+	Members       []string  `yaml:"members" env:"VALIDATOR_CLIENTS_HEDERA_MEMBERS"`
+	// MemberWeights maps members accounts to their share in the fee distribution
+	MemberWeights  map[string]int64 `yaml:"member_weights"`
+	MemberRegistry MemberRegistry   `yaml:"member_registry"`
+	FeeSettlement  FeeSettlement    `yaml:"fee_settlement"`
+	Refund         Refund           `yaml:"refund"`
+}
+
+// Refund configures the return of rejected deposits (f.e. with invalid memo or unsupported asset)
+// to their senders. The refund fee is withheld from the deposit and paid out to the members
+type Refund struct {
+	Enabled bool `yaml:"enabled" env:"VALIDATOR_CLIENTS_HEDERA_REFUND_ENABLED"`
+	// FeePercentage has the precision of `fee_percentage` (100000 = 100%)
+	FeePercentage int64 `yaml:"fee_percentage" env:"VALIDATOR_CLIENTS_HEDERA_REFUND_FEE_PERCENTAGE"`
+}
+
+// MemberRegistry references a file, mapping the Ethereum addresses of the bridge members
+// to their Hedera fee accounts. Every entry must be signed by the Ethereum key of the member.
+// The file is reloaded once changed. If not set, fees are distributed to the static `members`.
+type MemberRegistry struct {
+	File           string        `yaml:"file" env:"VALIDATOR_CLIENTS_HEDERA_MEMBER_REGISTRY_FILE"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"VALIDATOR_CLIENTS_HEDERA_MEMBER_REGISTRY_RELOAD_INTERVAL"`
+}
+
+type RegisteredMember struct {
+	Address   string `yaml:"address"`
+	Account   string `yaml:"account"`
+	Signature string `yaml:"signature"`
+}
+
+// FeeSettlement configures the accrual mode, in which fees are not paid out per
+// bridge operation, but are accrued and settled periodically per asset. Batches
+// are computed from the timestamps of the operations, so that all validators
+// settle the same fees in identical scheduled transactions.
+type FeeSettlement struct {
+	Enabled bool `yaml:"enabled" env:"VALIDATOR_CLIENTS_HEDERA_FEE_SETTLEMENT_ENABLED"`
+	// Interval is the length of a settlement batch in seconds
+	Interval time.Duration `yaml:"interval" env:"VALIDATOR_CLIENTS_HEDERA_FEE_SETTLEMENT_INTERVAL"`
+	// Delay is the time in seconds, which has to pass after the end of a batch, before it is settled
+	Delay time.Duration `yaml:"delay" env:"VALIDATOR_CLIENTS_HEDERA_FEE_SETTLEMENT_DELAY"`
+}
+
+// FeePolicy holds the rules used for calculating the fee of every bridge operation.
+// Rules can be provided inline or in a separate file, which is reloaded once changed.
+// All validators must be configured with the same rules.
+type FeePolicy struct {
+	File           string        `yaml:"file" env:"VALIDATOR_CLIENTS_HEDERA_FEE_POLICY_FILE"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"VALIDATOR_CLIENTS_HEDERA_FEE_POLICY_RELOAD_INTERVAL"`
+	FeeRules       `yaml:",inline"`
+}
+
+type FeeRules struct {
+	// EffectiveFrom is the timestamp (in seconds) of the operations, from which the rules are applied
+	EffectiveFrom int64 `yaml:"effective_from"`
+	// Percentage overrides the default `fee_percentage` if set
+	Percentage *int64              `yaml:"percentage"`
+	Assets     map[string]AssetFee `yaml:"assets"`
+	Whitelist  []string            `yaml:"whitelist"`
+	// History holds the rules, which were applied before `effective_from`. Every operation is charged by the rules
+	// effective at its timestamp, so that validators calculate the same fee, regardless of when they loaded the rules
+	History []FeeRules `yaml:"history"`
+}
+
+type AssetFee struct {
+	Percentage *int64    `yaml:"percentage"`
+	MinFee     int64     `yaml:"min_fee"`
+	MaxFee     int64     `yaml:"max_fee"`
+	Tiers      []FeeTier `yaml:"tiers"`
+}
+
+// FeeTier applies its percentage to amounts greater than or equal to `from`
+type FeeTier struct {
+	From       int64 `yaml:"from"`
+	Percentage int64 `yaml:"percentage"`
 }
 
 type Operator struct {
@@ -115,9 +353,13 @@ type MirrorNode struct {
 }
 
 type Database struct {
+	// Driver is either `postgres` (default) or `sqlite`. The SQLite database is stored in the file at `name`
+	Driver   string `yaml:"driver" env:"VALIDATOR_DATABASE_DRIVER"`
 	Host     string `yaml:"host" env:"VALIDATOR_DATABASE_HOST"`
 	Name     string `yaml:"name" env:"VALIDATOR_DATABASE_NAME"`
 	Password string `yaml:"password" env:"VALIDATOR_DATABASE_DB_PASSWORD"`
 	Port     string `yaml:"port" env:"VALIDATOR_DATABASE_DB_PORT"`
 	Username string `yaml:"username" env:"VALIDATOR_DATABASE_DB_USERNAME"`
+	// AutoMigrate applies the pending migrations on start. Otherwise, they are applied with the `migrate` command
+	AutoMigrate bool `yaml:"auto_migrate" env:"VALIDATOR_DATABASE_AUTO_MIGRATE"`
 }
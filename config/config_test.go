@@ -43,3 +43,27 @@ func Test_GetConfig(t *testing.T) {
 		t.Fatalf(err.Error())
 	}
 }
+
+func Test_Redacted(t *testing.T) {
+	var configuration Config
+	configuration.Validator.Database.Password = "validator_pass"
+	configuration.Validator.Clients.Ethereum.PrivateKey = "ethereum-key"
+	configuration.Validator.Admin.APIKeys = []string{"key"}
+	configuration.Validator.Webhooks.Subscriptions = []WebhookSubscription{{URL: "http://localhost", Secret: "webhook-secret"}}
+
+	redactedConfiguration := configuration.Redacted()
+
+	if redactedConfiguration.Validator.Database.Password != redacted ||
+		redactedConfiguration.Validator.Clients.Ethereum.PrivateKey != redacted ||
+		redactedConfiguration.Validator.Admin.APIKeys[0] != redacted ||
+		redactedConfiguration.Validator.Webhooks.Subscriptions[0].Secret != redacted {
+		t.Fatalf("Expected secrets to be redacted")
+	}
+	if redactedConfiguration.Validator.Clients.Hedera.Operator.PrivateKey != "" {
+		t.Fatalf("Expected empty secret to remain empty")
+	}
+	if configuration.Validator.Admin.APIKeys[0] != "key" ||
+		configuration.Validator.Webhooks.Subscriptions[0].Secret != "webhook-secret" {
+		t.Fatalf("Expected original configuration to remain unchanged")
+	}
+}
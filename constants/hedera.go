@@ -17,3 +17,6 @@
 package constants
 
 const Hbar = "HBAR"
+
+// HbarDecimals is the number of decimals of HBAR (1 HBAR = 10^8 tinybars)
+const HbarDecimals = 8
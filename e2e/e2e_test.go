@@ -64,7 +64,7 @@ func Test_HBAR(t *testing.T) {
 	now = time.Now()
 
 	memo := setupEnv.EthReceiver.String()
-	mintAmount, fee := calculateReceiverAndFeeAmounts(setupEnv, hBarSendAmount.AsTinybar())
+	mintAmount, fee := calculateReceiverAndFeeAmounts(setupEnv, constants.Hbar, memo, hBarSendAmount.AsTinybar())
 
 	// Step 1 - Verify the transfer of Hbars to the Bridge Account
 	transactionResponse, wrappedBalanceBefore := verifyTransferToBridgeAccount(setupEnv, memo, setupEnv.EthReceiver, t)
@@ -73,7 +73,7 @@ func Test_HBAR(t *testing.T) {
 	receivedSignatures := verifyTopicMessages(setupEnv, transactionResponse, t)
 
 	// Step 3 - Validate fee scheduled transaction
-	scheduledTxID, scheduleID := validateMembersScheduledTxs(setupEnv, constants.Hbar, generateMirrorNodeExpectedTransfersForHederaTransfer(setupEnv, constants.Hbar, fee), t)
+	scheduledTxID, scheduleID := validateMembersScheduledTxs(setupEnv, constants.Hbar, generateMirrorNodeExpectedTransfersForHederaTransfer(setupEnv, transactionResponse, constants.Hbar, fee, t), t)
 
 	// Step 4 - Verify Transfer retrieved from Validator API
 	transactionData, tokenAddress := verifyTransferFromValidatorAPI(setupEnv, transactionResponse, constants.Hbar, mintAmount, t)
@@ -117,7 +117,7 @@ func Test_E2E_Token_Transfer(t *testing.T) {
 	now = time.Now()
 
 	memo := setupEnv.EthReceiver.String()
-	mintAmount, fee := calculateReceiverAndFeeAmounts(setupEnv, tinyBarAmount)
+	mintAmount, fee := calculateReceiverAndFeeAmounts(setupEnv, setupEnv.TokenID.String(), memo, tinyBarAmount)
 
 	// Step 1 - Verify the transfer of HTS to the Bridge Account
 	transactionResponse, wrappedBalanceBefore := verifyTokenTransferToBridgeAccount(setupEnv, memo, setupEnv.EthReceiver, t)
@@ -126,7 +126,7 @@ func Test_E2E_Token_Transfer(t *testing.T) {
 	receivedSignatures := verifyTopicMessages(setupEnv, transactionResponse, t)
 
 	// Step 3 - Validate fee scheduled transaction
-	scheduledTxID, scheduleID := validateMembersScheduledTxs(setupEnv, setupEnv.TokenID.String(), generateMirrorNodeExpectedTransfersForHederaTransfer(setupEnv, setupEnv.TokenID.String(), fee), t)
+	scheduledTxID, scheduleID := validateMembersScheduledTxs(setupEnv, setupEnv.TokenID.String(), generateMirrorNodeExpectedTransfersForHederaTransfer(setupEnv, transactionResponse, setupEnv.TokenID.String(), fee, t), t)
 
 	// Step 4 - Verify Transfer retrieved from Validator API
 	transactionData, tokenAddress := verifyTransferFromValidatorAPI(setupEnv, transactionResponse, setupEnv.TokenID.String(), mintAmount, t)
@@ -171,7 +171,7 @@ func Test_Ethereum_Hedera_HBAR(t *testing.T) {
 	accountBalanceBefore := util.GetHederaAccountBalance(setupEnv.Clients.Hedera, setupEnv.Clients.Hedera.GetOperatorAccountID(), t)
 
 	// 1. Calculate Expected Receive And Fee Amounts
-	expectedReceiveAmount, fee := calculateReceiverAndFeeAmounts(setupEnv, receiveAmount)
+	expectedReceiveAmount, fee := calculateReceiverAndFeeAmounts(setupEnv, constants.Hbar, setupEnv.Clients.Hedera.GetOperatorAccountID().String(), receiveAmount)
 
 	// 2. Submit burn transaction to the bridge contract
 	burnTxReceipt, expectedRouterBurn := sendEthTransaction(setupEnv, constants.Hbar, t)
@@ -180,7 +180,7 @@ func Test_Ethereum_Hedera_HBAR(t *testing.T) {
 	expectedId := validateBurnEvent(burnTxReceipt, expectedRouterBurn, t)
 
 	// 4. Validate that a scheduled transaction was submitted
-	transactionID, scheduleID := validateSubmittedScheduledTx(setupEnv, constants.Hbar, generateMirrorNodeExpectedTransfersForBurnEvent(setupEnv, constants.Hbar, expectedReceiveAmount, fee), t)
+	transactionID, scheduleID := validateSubmittedScheduledTx(setupEnv, constants.Hbar, generateMirrorNodeExpectedTransfersForBurnEvent(setupEnv, expectedId, constants.Hbar, expectedReceiveAmount, fee, t), t)
 
 	// 5. Validate Event Transaction ID retrieved from Validator API
 	validateEventTransactionIDFromValidatorAPI(setupEnv, expectedId, transactionID, t)
@@ -213,7 +213,7 @@ func Test_Ethereum_Hedera_Token(t *testing.T) {
 	accountBalanceBefore := util.GetHederaAccountBalance(setupEnv.Clients.Hedera, setupEnv.Clients.Hedera.GetOperatorAccountID(), t)
 
 	// 1. Calculate Expected Receive Amount
-	expectedReceiveAmount, fee := calculateReceiverAndFeeAmounts(setupEnv, receiveAmount)
+	expectedReceiveAmount, fee := calculateReceiverAndFeeAmounts(setupEnv, setupEnv.TokenID.String(), setupEnv.Clients.Hedera.GetOperatorAccountID().String(), receiveAmount)
 
 	// 2. Submit burn transaction to the bridge contract
 	burnTxReceipt, expectedRouterBurn := sendEthTransaction(setupEnv, setupEnv.TokenID.String(), t)
@@ -222,7 +222,7 @@ func Test_Ethereum_Hedera_Token(t *testing.T) {
 	expectedId := validateBurnEvent(burnTxReceipt, expectedRouterBurn, t)
 
 	// 4. Validate that a scheduled transaction was submitted
-	transactionID, scheduleID := validateSubmittedScheduledTx(setupEnv, setupEnv.TokenID.String(), generateMirrorNodeExpectedTransfersForBurnEvent(setupEnv, setupEnv.TokenID.String(), expectedReceiveAmount, fee), t)
+	transactionID, scheduleID := validateSubmittedScheduledTx(setupEnv, setupEnv.TokenID.String(), generateMirrorNodeExpectedTransfersForBurnEvent(setupEnv, expectedId, setupEnv.TokenID.String(), expectedReceiveAmount, fee, t), t)
 
 	// 5. Validate Event Transaction ID retrieved from Validator API
 	validateEventTransactionIDFromValidatorAPI(setupEnv, expectedId, transactionID, t)
@@ -416,14 +416,9 @@ func validateMembersScheduledTxs(setupEnv *setup.Setup, asset string, expectedTr
 	return transactions[0], scheduleIDs[0]
 }
 
-func calculateReceiverAndFeeAmounts(setup *setup.Setup, amount int64) (receiverAmount, fee int64) {
-	fee, remainder := setup.Clients.FeeCalculator.CalculateFee(amount)
-	validFee := setup.Clients.Distributor.ValidAmount(fee)
-	if validFee != fee {
-		remainder += fee - validFee
-	}
-
-	return remainder, validFee
+func calculateReceiverAndFeeAmounts(setup *setup.Setup, asset, receiver string, amount int64) (receiverAmount, fee int64) {
+	feeAmount, remainder := setup.Clients.FeeCalculator.CalculateFee(asset, receiver, big.NewInt(amount), time.Now().Unix())
+	return remainder.Int64(), feeAmount.Int64()
 }
 
 func submitMintTransaction(setupEnv *setup.Setup, transactionResponse hedera.TransactionResponse, transactionData *service.TransferData, tokenAddress *common.Address, t *testing.T) common.Hash {
@@ -455,9 +450,8 @@ func submitMintTransaction(setupEnv *setup.Setup, transactionResponse hedera.Tra
 	return res.Hash()
 }
 
-func generateMirrorNodeExpectedTransfersForBurnEvent(setupEnv *setup.Setup, asset string, amount, fee int64) []mirror_node.Transfer {
+func generateMirrorNodeExpectedTransfersForBurnEvent(setupEnv *setup.Setup, id, asset string, amount, fee int64, t *testing.T) []mirror_node.Transfer {
 	total := amount + fee
-	feePerMember := fee / int64(len(setupEnv.Members))
 
 	var expectedTransfers []mirror_node.Transfer
 	expectedTransfers = append(expectedTransfers, mirror_node.Transfer{
@@ -468,13 +462,7 @@ func generateMirrorNodeExpectedTransfersForBurnEvent(setupEnv *setup.Setup, asse
 			Account: setupEnv.Clients.Hedera.GetOperatorAccountID().String(),
 			Amount:  amount,
 		})
-
-	for _, member := range setupEnv.Members {
-		expectedTransfers = append(expectedTransfers, mirror_node.Transfer{
-			Account: member.String(),
-			Amount:  feePerMember,
-		})
-	}
+	expectedTransfers = append(expectedTransfers, generateMirrorNodeExpectedMembersTransfers(setupEnv, id, fee, t)...)
 
 	if asset != constants.Hbar {
 		for i := range expectedTransfers {
@@ -485,21 +473,15 @@ func generateMirrorNodeExpectedTransfersForBurnEvent(setupEnv *setup.Setup, asse
 	return expectedTransfers
 }
 
-func generateMirrorNodeExpectedTransfersForHederaTransfer(setupEnv *setup.Setup, asset string, fee int64) []mirror_node.Transfer {
-	feePerMember := fee / int64(len(setupEnv.Members))
+func generateMirrorNodeExpectedTransfersForHederaTransfer(setupEnv *setup.Setup, transactionResponse hedera.TransactionResponse, asset string, fee int64, t *testing.T) []mirror_node.Transfer {
+	id := hederahelper.FromHederaTransactionID(&transactionResponse.TransactionID).String()
 
 	var expectedTransfers []mirror_node.Transfer
 	expectedTransfers = append(expectedTransfers, mirror_node.Transfer{
 		Account: setupEnv.BridgeAccount.String(),
 		Amount:  -fee,
 	})
-
-	for _, member := range setupEnv.Members {
-		expectedTransfers = append(expectedTransfers, mirror_node.Transfer{
-			Account: member.String(),
-			Amount:  feePerMember,
-		})
-	}
+	expectedTransfers = append(expectedTransfers, generateMirrorNodeExpectedMembersTransfers(setupEnv, id, fee, t)...)
 
 	if asset != constants.Hbar {
 		for i := range expectedTransfers {
@@ -510,6 +492,22 @@ func generateMirrorNodeExpectedTransfersForHederaTransfer(setupEnv *setup.Setup,
 	return expectedTransfers
 }
 
+func generateMirrorNodeExpectedMembersTransfers(setupEnv *setup.Setup, id string, fee int64, t *testing.T) []mirror_node.Transfer {
+	distribution, err := setupEnv.Clients.Distributor.CalculateMemberDistribution(id, big.NewInt(fee))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expectedTransfers []mirror_node.Transfer
+	for _, d := range distribution {
+		expectedTransfers = append(expectedTransfers, mirror_node.Transfer{
+			Account: d.AccountID.String(),
+			Amount:  d.Amount,
+		})
+	}
+	return expectedTransfers
+}
+
 func sendEthTransaction(setupEnv *setup.Setup, asset string, t *testing.T) (*types.Receipt, *routerContract.RouterBurn) {
 	wrappedAsset, err := setup.WrappedAsset(setupEnv.Clients.RouterContract, asset)
 	if err != nil {
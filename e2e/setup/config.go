@@ -21,8 +21,10 @@ import (
 	"fmt"
 	mirror_node "github.com/limechain/hedera-eth-bridge-validator/app/clients/hedera/mirror-node"
 	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/contracts"
 	fee "github.com/limechain/hedera-eth-bridge-validator/app/services/fee/calculator"
 	"github.com/limechain/hedera-eth-bridge-validator/app/services/fee/distributor"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/members"
 	e2eClients "github.com/limechain/hedera-eth-bridge-validator/e2e/clients"
 	"io/ioutil"
 	"os"
@@ -191,6 +193,9 @@ func newClients(config Config) (*clients, error) {
 
 	mirrorNode := mirror_node.NewClient(config.Hedera.MirrorNode.ApiAddress, config.Hedera.MirrorNode.PollingInterval)
 
+	contractsService := contracts.NewService(ethClient, config.Ethereum)
+	memberRegistry := members.New(contractsService, config.Hedera.MemberRegistry, config.Hedera.Members)
+
 	return &clients{
 		Hedera:          hederaClient,
 		EthClient:       ethClient,
@@ -200,8 +205,8 @@ func newClients(config Config) (*clients, error) {
 		ValidatorClient: validatorClient,
 		KeyTransactor:   keyTransactor,
 		MirrorNode:      mirrorNode,
-		FeeCalculator:   fee.New(config.Hedera.FeePercentage),
-		Distributor:     distributor.New(config.Hedera.Members),
+		FeeCalculator:   fee.New(config.Hedera.FeePercentage, config.Hedera.FeePolicy),
+		Distributor:     distributor.New(memberRegistry, config.Hedera.MemberWeights),
 		Signer:          signer,
 	}, nil
 }
@@ -279,14 +284,17 @@ type Tokens struct {
 
 // hedera props from the application.yml
 type Hedera struct {
-	NetworkType       string            `yaml:"network_type"`
-	BridgeAccount     string            `yaml:"bridge_account"`
-	FeePercentage     int64             `yaml:"fee_percentage"`
-	Members           []string          `yaml:"members"`
-	TopicID           string            `yaml:"topic_id"`
-	Sender            Sender            `yaml:"sender"`
-	DbValidationProps []config.Database `yaml:"dbs"`
-	MirrorNode        config.MirrorNode `yaml:"mirror_node"`
+	NetworkType       string                `yaml:"network_type"`
+	BridgeAccount     string                `yaml:"bridge_account"`
+	FeePercentage     int64                 `yaml:"fee_percentage"`
+	FeePolicy         config.FeePolicy      `yaml:"fee_policy"`
+	Members           []string              `yaml:"members"`
+	MemberWeights     map[string]int64      `yaml:"member_weights"`
+	MemberRegistry    config.MemberRegistry `yaml:"member_registry"`
+	TopicID           string                `yaml:"topic_id"`
+	Sender            Sender                `yaml:"sender"`
+	DbValidationProps []config.Database     `yaml:"dbs"`
+	MirrorNode        config.MirrorNode     `yaml:"mirror_node"`
 }
 
 // sender props from the application.yml
@@ -34,7 +34,7 @@ func PrepareExpectedBurnEventRecord(scheduleID string, amount int64, recipient h
 	return &entity.BurnEvent{
 		Id:         burnEventId,
 		ScheduleID: scheduleID,
-		Amount:     amount,
+		Amount:     strconv.FormatInt(amount, 10),
 		Recipient:  recipient.String(),
 		Status:     burn_event.StatusCompleted,
 		TransactionId: sql.NullString{
@@ -47,9 +47,12 @@ func PrepareExpectedBurnEventRecord(scheduleID string, amount int64, recipient h
 func PrepareExpectedFeeRecord(transactionID, scheduleID string, amount int64, transferID, burnEventID string) *entity.Fee {
 	fee := &entity.Fee{
 		TransactionID: transactionID,
-		ScheduleID:    scheduleID,
-		Amount:        strconv.FormatInt(amount, 10),
-		Status:        fee.StatusCompleted,
+		ScheduleID: sql.NullString{
+			String: scheduleID,
+			Valid:  true,
+		},
+		Amount: strconv.FormatInt(amount, 10),
+		Status: fee.StatusCompleted,
 	}
 
 	if transferID != "" {
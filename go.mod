@@ -12,7 +12,9 @@ require (
 	github.com/hashgraph/hedera-sdk-go/v2 v2.1.5-beta.3
 	github.com/hashgraph/hedera-state-proof-verifier-go v0.0.0-20210331132016-d77f113cf098
 	github.com/jackc/pgx/v4 v4.9.2 // indirect
+	github.com/mattn/go-sqlite3 v1.14.16 // indirect
 	github.com/pkg/errors v0.9.1
+	github.com/prometheus/client_golang v1.0.0
 	github.com/rs/cors v1.7.0
 	github.com/sirupsen/logrus v1.4.2
 	github.com/stretchr/testify v1.7.0
@@ -24,5 +26,6 @@ require (
 	google.golang.org/protobuf v1.26.0
 	gopkg.in/yaml.v2 v2.3.0
 	gorm.io/driver/postgres v1.0.5
+	gorm.io/driver/sqlite v1.1.3
 	gorm.io/gorm v1.20.6
 )
@@ -25,13 +25,14 @@ type TopicEthSignatureMessage struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TransferID           string `protobuf:"bytes,1,opt,name=transferID,proto3" json:"transferID,omitempty"`                      // The transaction Id of the initial Hedera Transfer
-	RouterAddress        string `protobuf:"bytes,2,opt,name=routerAddress,proto3" json:"routerAddress,omitempty"`                // The router address to which the message will be submitted
-	WrappedAsset         string `protobuf:"bytes,3,opt,name=wrappedAsset,proto3" json:"wrappedAsset,omitempty"`                  // The wrapped eth token
-	Receiver             string `protobuf:"bytes,4,opt,name=receiver,proto3" json:"receiver,omitempty"`                          // The receiver of the initial Hedera Transfer Memo
-	Amount               string `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`                              // The amount of the initial Hedera Transfer
-	Signature            string `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`                        // The signature of the validator
-	TransactionTimestamp int64  `protobuf:"varint,7,opt,name=transactionTimestamp,proto3" json:"transactionTimestamp,omitempty"` // The timestamp of the Hedera Transfer
+	TransferID           string               `protobuf:"bytes,1,opt,name=transferID,proto3" json:"transferID,omitempty"`                      // The transaction Id of the initial Hedera Transfer
+	RouterAddress        string               `protobuf:"bytes,2,opt,name=routerAddress,proto3" json:"routerAddress,omitempty"`                // The router address to which the message will be submitted
+	WrappedAsset         string               `protobuf:"bytes,3,opt,name=wrappedAsset,proto3" json:"wrappedAsset,omitempty"`                  // The wrapped eth token
+	Receiver             string               `protobuf:"bytes,4,opt,name=receiver,proto3" json:"receiver,omitempty"`                          // The receiver of the initial Hedera Transfer Memo
+	Amount               string               `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`                              // The amount of the initial Hedera Transfer
+	Signature            string               `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`                        // The signature of the validator
+	TransactionTimestamp int64                `protobuf:"varint,7,opt,name=transactionTimestamp,proto3" json:"transactionTimestamp,omitempty"` // The timestamp of the Hedera Transfer
+	Control              *TopicControlMessage `protobuf:"bytes,8,opt,name=control,proto3" json:"control,omitempty"`                            // Set instead of the signature fields, if the message controls the bridge
 }
 
 func (x *TopicEthSignatureMessage) Reset() {
@@ -115,12 +116,90 @@ func (x *TopicEthSignatureMessage) GetTransactionTimestamp() int64 {
 	return 0
 }
 
+func (x *TopicEthSignatureMessage) GetControl() *TopicControlMessage {
+	if x != nil {
+		return x.Control
+	}
+	return nil
+}
+
+type TopicControlMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action    string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`       // The control action - PAUSE or RESUME
+	Reason    string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`       // The reason for the action
+	Nonce     int64  `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`        // The time (in nanoseconds) at which the action was issued. Actions not newer than the last applied one are ignored
+	Signature string `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"` // The signature of the issuing validator
+}
+
+func (x *TopicControlMessage) Reset() {
+	*x = TopicControlMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topic_eth_signature_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopicControlMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicControlMessage) ProtoMessage() {}
+
+func (x *TopicControlMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_topic_eth_signature_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicControlMessage.ProtoReflect.Descriptor instead.
+func (*TopicControlMessage) Descriptor() ([]byte, []int) {
+	return file_topic_eth_signature_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TopicControlMessage) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TopicControlMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TopicControlMessage) GetNonce() int64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *TopicControlMessage) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
 var File_topic_eth_signature_message_proto protoreflect.FileDescriptor
 
 var file_topic_eth_signature_message_proto_rawDesc = []byte{
 	0x0a, 0x21, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x5f, 0x65, 0x74, 0x68, 0x5f, 0x73, 0x69, 0x67, 0x6e,
 	0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8a, 0x02, 0x0a, 0x18, 0x54,
+	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc0, 0x02, 0x0a, 0x18, 0x54,
 	0x6f, 0x70, 0x69, 0x63, 0x45, 0x74, 0x68, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
 	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73,
 	0x66, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x72, 0x61,
@@ -137,11 +216,22 @@ var file_topic_eth_signature_message_proto_rawDesc = []byte{
 	0x75, 0x72, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
 	0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28,
 	0x03, 0x52, 0x14, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x6d, 0x65, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f,
-	0x68, 0x65, 0x64, 0x65, 0x72, 0x61, 0x2d, 0x65, 0x74, 0x68, 0x2d, 0x62, 0x72, 0x69, 0x64, 0x67,
-	0x65, 0x2d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x22, 0x79, 0x0a,
+	0x13, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x6d, 0x65, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x2f, 0x68, 0x65, 0x64, 0x65, 0x72, 0x61, 0x2d, 0x65, 0x74, 0x68, 0x2d, 0x62, 0x72, 0x69, 0x64,
+	0x67, 0x65, 0x2d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -156,16 +246,18 @@ func file_topic_eth_signature_message_proto_rawDescGZIP() []byte {
 	return file_topic_eth_signature_message_proto_rawDescData
 }
 
-var file_topic_eth_signature_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_topic_eth_signature_message_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_topic_eth_signature_message_proto_goTypes = []interface{}{
 	(*TopicEthSignatureMessage)(nil), // 0: proto.TopicEthSignatureMessage
+	(*TopicControlMessage)(nil),      // 1: proto.TopicControlMessage
 }
 var file_topic_eth_signature_message_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: proto.TopicEthSignatureMessage.control:type_name -> proto.TopicControlMessage
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_topic_eth_signature_message_proto_init() }
@@ -186,6 +278,18 @@ func file_topic_eth_signature_message_proto_init() {
 				return nil
 			}
 		}
+		file_topic_eth_signature_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopicControlMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -193,7 +297,7 @@ func file_topic_eth_signature_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_topic_eth_signature_message_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/limechain/hedera-eth-bridge-validator/app/services/members"
+)
+
+func main() {
+	privateKey := flag.String("privateKey", "0x0", "Ethereum Private Key of the bridge member")
+	accountID := flag.String("accountID", "0.0", "Hedera Account ID, to which fees are paid out")
+	flag.Parse()
+	if *privateKey == "0x0" {
+		panic("Private key was not provided")
+	}
+	if *accountID == "0.0" {
+		panic("Account id was not provided")
+	}
+
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(*privateKey, "0x"))
+	if err != nil {
+		panic(err)
+	}
+
+	account, err := hedera.AccountIDFromString(*accountID)
+	if err != nil {
+		panic(err)
+	}
+
+	address := crypto.PubkeyToAddress(key.PublicKey).String()
+	signature, err := crypto.Sign(members.Hash(address, account.String()), key)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Add the following entry to the `members` of the member registry file:")
+	fmt.Printf("  - address: \"%s\"\n", address)
+	fmt.Printf("    account: \"%s\"\n", account.String())
+	fmt.Printf("    signature: \"%s\"\n", hex.EncodeToString(signature))
+}
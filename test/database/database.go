@@ -0,0 +1,79 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Env is the connection string of a Postgres database, used by the tests and benchmarks of the persistence.
+// If it is not set, an ephemeral Postgres server is started from the local Postgres installation.
+// SQLite databases do not require any setup
+const Env = "TEST_DATABASE_URL"
+
+// Connect returns a connection to the schema of the test database, so that packages tested in parallel do not share
+// their tables. The schema is created, if it does not exist
+func Connect(tb testing.TB, schema string) *gorm.DB {
+	dsn := os.Getenv(Env)
+	if dsn == "" {
+		dsn = startPostgres(tb)
+	}
+
+	db := open(tb, dsn)
+	err := db.Exec(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", schema)).Error
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return open(tb, fmt.Sprintf("%s search_path=%s", dsn, schema))
+}
+
+// ConnectSQLite returns a connection to a new SQLite database, which is removed once the test completes
+func ConnectSQLite(tb testing.TB) *gorm.DB {
+	directory, err := ioutil.TempDir("", "validator")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() {
+		os.RemoveAll(directory)
+	})
+
+	db, err := gorm.Open(
+		sqlite.Open(fmt.Sprintf("%s?_foreign_keys=on", filepath.Join(directory, "validator.db"))),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return db
+}
+
+func open(tb testing.TB, dsn string) *gorm.DB {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return db
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2021 LimeChain Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// postgresBinaries are the directories searched for a Postgres installation, if its binaries are not in the PATH
+const postgresBinaries = "/usr/lib/postgresql/*/bin"
+
+// startPostgres initialises and starts an ephemeral Postgres server from the local Postgres installation
+// and returns its connection string. The server and its data are removed once the test completes.
+// The test is skipped, if there is no Postgres installation
+func startPostgres(tb testing.TB) string {
+	initdb, pgCtl := findPostgres()
+	if initdb == "" || pgCtl == "" {
+		tb.Skipf("%s is not set and no Postgres installation is found", Env)
+	}
+	// initdb refuses to initialise a database as root
+	if os.Geteuid() == 0 {
+		tb.Skipf("%s is not set and Postgres cannot be started as root", Env)
+	}
+
+	directory, err := ioutil.TempDir("", "validator-postgres")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() {
+		os.RemoveAll(directory)
+	})
+	data := filepath.Join(directory, "data")
+
+	port, err := freePort()
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	run(tb, initdb, "-D", data, "-U", "postgres", "-A", "trust", "-N")
+	run(tb, pgCtl, "-D", data, "-l", filepath.Join(directory, "postgres.log"), "-w",
+		"-o", fmt.Sprintf("-p %d -k %s -c listen_addresses=127.0.0.1 -c fsync=off", port, directory),
+		"start")
+	tb.Cleanup(func() {
+		exec.Command(pgCtl, "-D", data, "-m", "immediate", "-w", "stop").Run()
+	})
+
+	return fmt.Sprintf("host=127.0.0.1 port=%d user=postgres dbname=postgres sslmode=disable", port)
+}
+
+// findPostgres returns the paths of the initdb and pg_ctl binaries of the latest Postgres installation
+func findPostgres() (initdb, pgCtl string) {
+	initdb, err := exec.LookPath("initdb")
+	if err == nil {
+		pgCtl, err = exec.LookPath("pg_ctl")
+		if err == nil {
+			return initdb, pgCtl
+		}
+	}
+
+	directories, _ := filepath.Glob(postgresBinaries)
+	sort.Strings(directories)
+	for i := len(directories) - 1; i >= 0; i-- {
+		initdb = filepath.Join(directories[i], "initdb")
+		pgCtl = filepath.Join(directories[i], "pg_ctl")
+		if exists(initdb) && exists(pgCtl) {
+			return initdb, pgCtl
+		}
+	}
+	return "", ""
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+func run(tb testing.TB, name string, args ...string) {
+	output, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		tb.Fatalf("%s failed: %s. Output: %s", filepath.Base(name), err, output)
+	}
+}
@@ -37,13 +37,26 @@ func (m *MockBridgeContract) Wrapped(nativeAsset string) (string, error) {
 }
 
 func (m *MockBridgeContract) IsMember(address string) bool {
-	panic("implement me")
+	args := m.Called(address)
+	return args.Bool(0)
 }
 
 func (m *MockBridgeContract) WatchBurnEventLogs(opts *bind.WatchOpts, sink chan<- *router.RouterBurn) (event.Subscription, error) {
 	panic("implement me")
 }
 
+func (m *MockBridgeContract) WatchMintEventLogs(opts *bind.WatchOpts, sink chan<- *router.RouterMint) (event.Subscription, error) {
+	panic("implement me")
+}
+
+func (m *MockBridgeContract) FilterBurnEventLogs(opts *bind.FilterOpts) ([]*router.RouterBurn, error) {
+	args := m.Called(opts)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*router.RouterBurn), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
 func (m *MockBridgeContract) GetBridgeContractAddress() common.Address {
 	return common.HexToAddress("0x0000000000000000000000000000000000000000")
 }
@@ -52,3 +65,39 @@ func (m *MockBridgeContract) GetMembers() []string {
 	args := m.Called()
 	return args.Get(0).([]string)
 }
+
+func (m *MockBridgeContract) Address() common.Address {
+	return m.GetBridgeContractAddress()
+}
+
+func (m *MockBridgeContract) ToWrapped(nativeAsset string) (string, error) {
+	args := m.Called(nativeAsset)
+	if args.Get(1) == nil {
+		return args.Get(0).(string), nil
+	}
+	return "", args.Get(1).(error)
+}
+
+func (m *MockBridgeContract) ToNative(wrappedAsset common.Address) (string, error) {
+	args := m.Called(wrappedAsset)
+	if args.Get(1) == nil {
+		return args.Get(0).(string), nil
+	}
+	return "", args.Get(1).(error)
+}
+
+func (m *MockBridgeContract) WrappedDecimals(wrappedAsset string) (uint8, error) {
+	args := m.Called(wrappedAsset)
+	if args.Get(1) == nil {
+		return args.Get(0).(uint8), nil
+	}
+	return 0, args.Get(1).(error)
+}
+
+func (m *MockBridgeContract) WrappedPaused(wrappedAsset string) (bool, error) {
+	args := m.Called(wrappedAsset)
+	if args.Get(1) == nil {
+		return args.Get(0).(bool), nil
+	}
+	return false, args.Get(1).(error)
+}
@@ -98,3 +98,27 @@ func (m *MockHederaMirrorClient) GetTransaction(transactionID string) (*mirror_n
 func (m *MockHederaMirrorClient) WaitForScheduledTransferTransaction(txId string, onSuccess, onFailure func()) {
 	m.Called(txId /*, onSuccess, onFailure*/)
 }
+
+func (m *MockHederaMirrorClient) GetToken(tokenID string) (*mirror_node.Token, error) {
+	args := m.Called(tokenID)
+	if args.Get(1) == nil {
+		return args.Get(0).(*mirror_node.Token), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (m *MockHederaMirrorClient) GetScheduledTransactionsAfterTimestamp(accountId hedera.AccountID, from int64) ([]mirror_node.Transaction, error) {
+	args := m.Called(accountId, from)
+	if args.Get(1) == nil {
+		return args.Get(0).([]mirror_node.Transaction), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (m *MockHederaMirrorClient) GetSchedule(scheduleID string) (*mirror_node.Schedule, error) {
+	args := m.Called(scheduleID)
+	if args.Get(1) == nil {
+		return args.Get(0).(*mirror_node.Schedule), nil
+	}
+	return nil, args.Get(1).(error)
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockArchiveRepository struct {
+	mock.Mock
+}
+
+func (mar *MockArchiveRepository) Get(id string) (*entity.ArchivedRecord, error) {
+	args := mar.Called(id)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	if args.Get(1) == nil {
+		return args.Get(0).(*entity.ArchivedRecord), nil
+	}
+	return args.Get(0).(*entity.ArchivedRecord), args.Get(1).(error)
+}
+
+func (mar *MockArchiveRepository) GetArchivableTransfers(before int64, limit int) ([]*entity.Transfer, error) {
+	args := mar.Called(before, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*entity.Transfer), nil
+	}
+	return args.Get(0).([]*entity.Transfer), args.Get(1).(error)
+}
+
+func (mar *MockArchiveRepository) GetArchivableBurnEvents(before int64, limit int) ([]*entity.BurnEvent, error) {
+	args := mar.Called(before, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*entity.BurnEvent), nil
+	}
+	return args.Get(0).([]*entity.BurnEvent), args.Get(1).(error)
+}
+
+func (mar *MockArchiveRepository) PruneTransfers(ids []string, file string) error {
+	args := mar.Called(ids, file)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mar *MockArchiveRepository) PruneBurnEvents(ids []string, file string) error {
+	args := mar.Called(ids, file)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
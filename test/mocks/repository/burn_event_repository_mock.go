@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	model "github.com/limechain/hedera-eth-bridge-validator/app/model/burn-event"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
 	"github.com/stretchr/testify/mock"
 )
@@ -9,32 +11,40 @@ type MockBurnEventRepository struct {
 	mock.Mock
 }
 
-func (berm *MockBurnEventRepository) Create(id string, amount int64, recipient string) error {
-	args := berm.Called(id, amount, recipient)
+func (berm *MockBurnEventRepository) Create(event *model.BurnEvent) error {
+	args := berm.Called(event)
 	if args.Get(0) == nil {
 		return nil
 	}
 	return args.Get(0).(error)
 }
 
-func (berm *MockBurnEventRepository) UpdateStatusSubmitted(ethTxHash, scheduleID, transactionId string) error {
-	args := berm.Called(ethTxHash, scheduleID, transactionId)
+func (berm *MockBurnEventRepository) CreateHeld(event *model.BurnEvent, violation string) error {
+	args := berm.Called(event, violation)
 	if args.Get(0) == nil {
 		return nil
 	}
 	return args.Get(0).(error)
 }
 
-func (berm *MockBurnEventRepository) UpdateStatusCompleted(id string) error {
-	args := berm.Called(id)
+func (berm *MockBurnEventRepository) UpdateStatusSubmitted(ethTxHash, scheduleID, transactionId string, cause repository.Cause) error {
+	args := berm.Called(ethTxHash, scheduleID, transactionId, cause)
 	if args.Get(0) == nil {
 		return nil
 	}
 	return args.Get(0).(error)
 }
 
-func (berm *MockBurnEventRepository) UpdateStatusFailed(id string) error {
-	args := berm.Called(id)
+func (berm *MockBurnEventRepository) UpdateStatusCompleted(id string, cause repository.Cause) error {
+	args := berm.Called(id, cause)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (berm *MockBurnEventRepository) UpdateStatusFailed(id string, cause repository.Cause) error {
+	args := berm.Called(id, cause)
 	if args.Get(0) == nil {
 		return nil
 	}
@@ -51,3 +61,19 @@ func (berm *MockBurnEventRepository) Get(id string) (*entity.BurnEvent, error) {
 	}
 	return nil, args.Get(1).(error)
 }
+
+func (berm *MockBurnEventRepository) GetUnprocessed() ([]*entity.BurnEvent, error) {
+	args := berm.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).([]*entity.BurnEvent), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (berm *MockBurnEventRepository) CountByStatus() (map[string]int64, error) {
+	args := berm.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).(map[string]int64), nil
+	}
+	return nil, args.Get(1).(error)
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockControlActionRepository struct {
+	mock.Mock
+}
+
+func (mcar *MockControlActionRepository) Create(action *entity.ControlAction) error {
+	args := mcar.Called(action)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mcar *MockControlActionRepository) GetLatest() (*entity.ControlAction, error) {
+	args := mcar.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).(*entity.ControlAction), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (mcar *MockControlActionRepository) CreateSignature(signature *entity.ControlSignature) error {
+	args := mcar.Called(signature)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mcar *MockControlActionRepository) GetSignatures(nonce int64, action string) ([]entity.ControlSignature, error) {
+	args := mcar.Called(nonce, action)
+	if args.Get(1) == nil {
+		return args.Get(0).([]entity.ControlSignature), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (mcar *MockControlActionRepository) GetSignaturesAfter(nonce int64) ([]entity.ControlSignature, error) {
+	args := mcar.Called(nonce)
+	if args.Get(1) == nil {
+		return args.Get(0).([]entity.ControlSignature), nil
+	}
+	return nil, args.Get(1).(error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEquivocationRepository struct {
+	mock.Mock
+}
+
+func (mer *MockEquivocationRepository) Create(equivocation *entity.Equivocation) error {
+	args := mer.Called(equivocation)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mer *MockEquivocationRepository) Get(transferID, signer string) ([]entity.Equivocation, error) {
+	args := mer.Called(transferID, signer)
+	if args.Get(1) == nil {
+		return args.Get(0).([]entity.Equivocation), nil
+	}
+	return args.Get(0).([]entity.Equivocation), args.Get(1).(error)
+}
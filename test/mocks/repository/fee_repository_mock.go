@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
 	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
 	"github.com/stretchr/testify/mock"
 )
@@ -17,16 +18,16 @@ func (mfr *MockFeeRepository) Create(entity *entity.Fee) error {
 	return args.Get(0).(error)
 }
 
-func (mfr *MockFeeRepository) UpdateStatusCompleted(id string) error {
-	args := mfr.Called(id)
+func (mfr *MockFeeRepository) UpdateStatusCompleted(id string, cause repository.Cause) error {
+	args := mfr.Called(id, cause)
 	if args.Get(0) == nil {
 		return nil
 	}
 	return args.Get(0).(error)
 }
 
-func (mfr *MockFeeRepository) UpdateStatusFailed(id string) error {
-	args := mfr.Called(id)
+func (mfr *MockFeeRepository) UpdateStatusFailed(id string, cause repository.Cause) error {
+	args := mfr.Called(id, cause)
 	if args.Get(0) == nil {
 		return nil
 	}
@@ -40,3 +41,75 @@ func (mfr *MockFeeRepository) Get(id string) (*entity.Fee, error) {
 	}
 	return nil, args.Get(1).(error)
 }
+
+func (mfr *MockFeeRepository) GetSubmitted() ([]*entity.Fee, error) {
+	args := mfr.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).([]*entity.Fee), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (mfr *MockFeeRepository) GetAccruedBatches(before int64) ([]repository.FeeBatch, error) {
+	args := mfr.Called(before)
+	if args.Get(1) == nil {
+		return args.Get(0).([]repository.FeeBatch), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (mfr *MockFeeRepository) GetAccruedInBatch(nativeAsset string, batch int64) ([]*entity.Fee, error) {
+	args := mfr.Called(nativeAsset, batch)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*entity.Fee), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (mfr *MockFeeRepository) UpdateSettlementSubmitted(txIds []string, settlement *entity.FeeSettlement, cause repository.Cause) error {
+	args := mfr.Called(txIds, settlement, cause)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mfr *MockFeeRepository) UpdateSettlementCompleted(txIds []string, cause repository.Cause) error {
+	args := mfr.Called(txIds, cause)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mfr *MockFeeRepository) UpdateSettlementAccrued(txIds []string, cause repository.Cause) error {
+	args := mfr.Called(txIds, cause)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mfr *MockFeeRepository) GetSettlement(id string) (*entity.FeeSettlement, error) {
+	args := mfr.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*entity.FeeSettlement), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (mfr *MockFeeRepository) UpdateBatch(txIds []string, batch int64) error {
+	args := mfr.Called(txIds, batch)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mfr *MockFeeRepository) CountByStatus() (map[string]int64, error) {
+	args := mfr.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).(map[string]int64), nil
+	}
+	return nil, args.Get(1).(error)
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMessageRepository struct {
+	mock.Mock
+}
+
+func (mmr *MockMessageRepository) Create(message *entity.Message) error {
+	args := mmr.Called(message)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mmr *MockMessageRepository) Exist(transferID, signature, hash string) (bool, error) {
+	args := mmr.Called(transferID, signature, hash)
+	if args.Get(1) == nil {
+		return args.Get(0).(bool), nil
+	}
+	return args.Get(0).(bool), args.Get(1).(error)
+}
+
+func (mmr *MockMessageRepository) Get(transferID string) ([]entity.Message, error) {
+	args := mmr.Called(transferID)
+	if args.Get(1) == nil {
+		return args.Get(0).([]entity.Message), nil
+	}
+	return args.Get(0).([]entity.Message), args.Get(1).(error)
+}
+
+func (mmr *MockMessageRepository) GetMessageWith(transferID, signature, hash string) (*entity.Message, error) {
+	args := mmr.Called(transferID, signature, hash)
+	if args.Get(1) == nil {
+		return args.Get(0).(*entity.Message), nil
+	}
+	return args.Get(0).(*entity.Message), args.Get(1).(error)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPendingMessageRepository struct {
+	mock.Mock
+}
+
+func (mpr *MockPendingMessageRepository) Create(message *entity.PendingMessage) error {
+	args := mpr.Called(message)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mpr *MockPendingMessageRepository) Pop(transferID string) ([]entity.PendingMessage, error) {
+	args := mpr.Called(transferID)
+	if args.Get(1) == nil {
+		return args.Get(0).([]entity.PendingMessage), nil
+	}
+	return args.Get(0).([]entity.PendingMessage), args.Get(1).(error)
+}
+
+func (mpr *MockPendingMessageRepository) DeleteExpired(now int64) ([]entity.PendingMessage, error) {
+	args := mpr.Called(now)
+	if args.Get(1) == nil {
+		return args.Get(0).([]entity.PendingMessage), nil
+	}
+	return args.Get(0).([]entity.PendingMessage), args.Get(1).(error)
+}
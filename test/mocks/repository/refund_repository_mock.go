@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRefundRepository struct {
+	mock.Mock
+}
+
+func (mrr *MockRefundRepository) Create(refund *entity.Refund) error {
+	args := mrr.Called(refund)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mrr *MockRefundRepository) UpdateStatusSubmitted(transferID, scheduleID, transactionID string, cause repository.Cause) error {
+	args := mrr.Called(transferID, scheduleID, transactionID, cause)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mrr *MockRefundRepository) UpdateStatusCompleted(transferID string, cause repository.Cause) error {
+	args := mrr.Called(transferID, cause)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mrr *MockRefundRepository) UpdateStatusFailed(transferID string, cause repository.Cause) error {
+	args := mrr.Called(transferID, cause)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mrr *MockRefundRepository) Get(transferID string) (*entity.Refund, error) {
+	args := mrr.Called(transferID)
+	if args.Get(1) == nil {
+		if args.Get(0) == nil {
+			return nil, nil
+		}
+		return args.Get(0).(*entity.Refund), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (mrr *MockRefundRepository) GetInitial() ([]*entity.Refund, error) {
+	args := mrr.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).([]*entity.Refund), nil
+	}
+	return nil, args.Get(1).(error)
+}
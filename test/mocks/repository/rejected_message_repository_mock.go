@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRejectedMessageRepository struct {
+	mock.Mock
+}
+
+func (mrr *MockRejectedMessageRepository) Create(message *entity.RejectedMessage) error {
+	args := mrr.Called(message)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mrr *MockRejectedMessageRepository) Get(transferID, signer string) ([]entity.RejectedMessage, error) {
+	args := mrr.Called(transferID, signer)
+	if args.Get(1) == nil {
+		return args.Get(0).([]entity.RejectedMessage), nil
+	}
+	return args.Get(0).([]entity.RejectedMessage), args.Get(1).(error)
+}
+
+func (mrr *MockRejectedMessageRepository) GetPage(transferID, signer string, after int64, limit int) ([]entity.RejectedMessage, error) {
+	args := mrr.Called(transferID, signer, after, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).([]entity.RejectedMessage), nil
+	}
+	return args.Get(0).([]entity.RejectedMessage), args.Get(1).(error)
+}
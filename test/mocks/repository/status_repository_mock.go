@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStatusRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatusRepository) GetLastFetchedTimestamp(entityID string) (int64, error) {
+	args := m.Called(entityID)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return args.Get(0).(int64), args.Get(1).(error)
+}
+
+func (m *MockStatusRepository) UpdateLastFetchedTimestamp(entityID string, timestamp int64) error {
+	args := m.Called(entityID, timestamp)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (m *MockStatusRepository) CreateTimestamp(entityID string, timestamp int64) error {
+	args := m.Called(entityID, timestamp)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
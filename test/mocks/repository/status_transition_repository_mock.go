@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStatusTransitionRepository struct {
+	mock.Mock
+}
+
+func (mstr *MockStatusTransitionRepository) GetForTransfer(transferID string) ([]entity.StatusTransition, error) {
+	args := mstr.Called(transferID)
+	if args.Get(1) == nil {
+		return args.Get(0).([]entity.StatusTransition), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (mstr *MockStatusTransitionRepository) GetForBurnEvent(id string) ([]entity.StatusTransition, error) {
+	args := mstr.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).([]entity.StatusTransition), nil
+	}
+	return nil, args.Get(1).(error)
+}
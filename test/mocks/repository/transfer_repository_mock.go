@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/transfer"
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTransferRepository struct {
+	mock.Mock
+}
+
+func (mtr *MockTransferRepository) GetByTransactionId(txId string) (*entity.Transfer, error) {
+	args := mtr.Called(txId)
+	if args.Get(1) == nil {
+		return args.Get(0).(*entity.Transfer), nil
+	}
+	return args.Get(0).(*entity.Transfer), args.Get(1).(error)
+}
+
+func (mtr *MockTransferRepository) GetWithFee(txId string) (*entity.Transfer, error) {
+	args := mtr.Called(txId)
+	if args.Get(1) == nil {
+		return args.Get(0).(*entity.Transfer), nil
+	}
+	return args.Get(0).(*entity.Transfer), args.Get(1).(error)
+}
+
+func (mtr *MockTransferRepository) GetWithPreloads(txId string) (*entity.Transfer, error) {
+	args := mtr.Called(txId)
+	if args.Get(1) == nil {
+		return args.Get(0).(*entity.Transfer), nil
+	}
+	return args.Get(0).(*entity.Transfer), args.Get(1).(error)
+}
+
+func (mtr *MockTransferRepository) GetUnprocessedTransfers() ([]*entity.Transfer, error) {
+	args := mtr.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).([]*entity.Transfer), nil
+	}
+	return args.Get(0).([]*entity.Transfer), args.Get(1).(error)
+}
+
+func (mtr *MockTransferRepository) CreateHeld(ct *transfer.Transfer, violation string) (*entity.Transfer, error) {
+	args := mtr.Called(ct, violation)
+	if args.Get(1) == nil {
+		return args.Get(0).(*entity.Transfer), nil
+	}
+	return args.Get(0).(*entity.Transfer), args.Get(1).(error)
+}
+
+func (mtr *MockTransferRepository) CreateRejected(ct *transfer.Transfer) (*entity.Transfer, error) {
+	args := mtr.Called(ct)
+	if args.Get(1) == nil {
+		return args.Get(0).(*entity.Transfer), nil
+	}
+	return args.Get(0).(*entity.Transfer), args.Get(1).(error)
+}
+
+func (mtr *MockTransferRepository) Create(ct *transfer.Transfer, signers []string, requiredSignatures int) (*entity.Transfer, error) {
+	args := mtr.Called(ct, signers, requiredSignatures)
+	if args.Get(1) == nil {
+		return args.Get(0).(*entity.Transfer), nil
+	}
+	return args.Get(0).(*entity.Transfer), args.Get(1).(error)
+}
+
+func (mtr *MockTransferRepository) SaveRecoveredTxn(ct *transfer.Transfer, signers []string, requiredSignatures int) error {
+	args := mtr.Called(ct, signers, requiredSignatures)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mtr *MockTransferRepository) UpdateStatusCompleted(txId string, cause repository.Cause) error {
+	args := mtr.Called(txId, cause)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mtr *MockTransferRepository) UpdateStatusSignatureSubmitted(txId string, cause repository.Cause) error {
+	args := mtr.Called(txId, cause)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mtr *MockTransferRepository) UpdateStatusSignatureMined(txId string, cause repository.Cause) error {
+	args := mtr.Called(txId, cause)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mtr *MockTransferRepository) UpdateStatusSignatureFailed(txId string, cause repository.Cause) error {
+	args := mtr.Called(txId, cause)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mtr *MockTransferRepository) CountByStatus() (map[string]int64, error) {
+	args := mtr.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).(map[string]int64), nil
+	}
+	return nil, args.Get(1).(error)
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/repository"
+)
+
+// MockUnitOfWork executes the work with the mock repositories of its transaction
+type MockUnitOfWork struct {
+	Transaction *MockTransaction
+}
+
+func (m *MockUnitOfWork) Do(work func(tx repository.Transaction) error) error {
+	return work(m.Transaction)
+}
+
+type MockTransaction struct {
+	TransferRepository        *MockTransferRepository
+	MessageRepository         *MockMessageRepository
+	RejectedMessageRepository *MockRejectedMessageRepository
+	EquivocationRepository    *MockEquivocationRepository
+	FeeRepository             *MockFeeRepository
+	BurnEventRepository       *MockBurnEventRepository
+	RefundRepository          *MockRefundRepository
+}
+
+func (m *MockTransaction) Transfers() repository.Transfer {
+	return m.TransferRepository
+}
+
+func (m *MockTransaction) Messages() repository.Message {
+	return m.MessageRepository
+}
+
+func (m *MockTransaction) RejectedMessages() repository.RejectedMessage {
+	return m.RejectedMessageRepository
+}
+
+func (m *MockTransaction) Equivocations() repository.Equivocation {
+	return m.EquivocationRepository
+}
+
+func (m *MockTransaction) Fees() repository.Fee {
+	return m.FeeRepository
+}
+
+func (m *MockTransaction) BurnEvents() repository.BurnEvent {
+	return m.BurnEventRepository
+}
+
+func (m *MockTransaction) Refunds() repository.Refund {
+	return m.RefundRepository
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVolumeRepository struct {
+	mock.Mock
+}
+
+func (mvr *MockVolumeRepository) Create(volume *entity.Volume) error {
+	args := mvr.Called(volume)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mvr *MockVolumeRepository) Get(id string) (*entity.Volume, error) {
+	args := mvr.Called(id)
+	if args.Get(1) == nil {
+		if args.Get(0) == nil {
+			return nil, nil
+		}
+		return args.Get(0).(*entity.Volume), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (mvr *MockVolumeRepository) GetBetween(nativeAsset, direction string, from, to int64) ([]entity.Volume, error) {
+	args := mvr.Called(nativeAsset, direction, from, to)
+	if args.Get(1) == nil {
+		return args.Get(0).([]entity.Volume), nil
+	}
+	return nil, args.Get(1).(error)
+}
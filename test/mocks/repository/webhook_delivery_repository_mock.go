@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookDeliveryRepository struct {
+	mock.Mock
+}
+
+func (mwdr *MockWebhookDeliveryRepository) Create(delivery *entity.WebhookDelivery) error {
+	args := mwdr.Called(delivery)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mwdr *MockWebhookDeliveryRepository) UpdateAttempt(id uint64, status string, attempts, responseCode int, lastError string) error {
+	args := mwdr.Called(id, status, attempts, responseCode, lastError)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mwdr *MockWebhookDeliveryRepository) GetPending() ([]*entity.WebhookDelivery, error) {
+	args := mwdr.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).([]*entity.WebhookDelivery), nil
+	}
+	return nil, args.Get(1).(error)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockArchiveService struct {
+	mock.Mock
+}
+
+func (mas *MockArchiveService) Start() {
+	mas.Called()
+}
+
+func (mas *MockArchiveService) Archive() error {
+	args := mas.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mas *MockArchiveService) Transfer(id string) (*entity.Transfer, error) {
+	args := mas.Called(id)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	if args.Get(1) == nil {
+		return args.Get(0).(*entity.Transfer), nil
+	}
+	return args.Get(0).(*entity.Transfer), args.Get(1).(error)
+}
+
+func (mas *MockArchiveService) BurnEvent(id string) (*entity.BurnEvent, error) {
+	args := mas.Called(id)
+	if args.Get(0) == nil {
+		if args.Get(1) == nil {
+			return nil, nil
+		}
+		return nil, args.Get(1).(error)
+	}
+	if args.Get(1) == nil {
+		return args.Get(0).(*entity.BurnEvent), nil
+	}
+	return args.Get(0).(*entity.BurnEvent), args.Get(1).(error)
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"math/big"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDecimalsService struct {
+	mock.Mock
+}
+
+func (mds *MockDecimalsService) ToWrapped(nativeAsset, wrappedAsset string, amount *big.Int) (wrappedAmount, dust *big.Int, err error) {
+	args := mds.Called(nativeAsset, wrappedAsset, amount)
+	if args.Get(2) == nil {
+		return args.Get(0).(*big.Int), args.Get(1).(*big.Int), nil
+	}
+	return nil, nil, args.Get(2).(error)
+}
+
+func (mds *MockDecimalsService) ToNative(nativeAsset, wrappedAsset string, amount *big.Int) (nativeAmount, dust *big.Int, err error) {
+	args := mds.Called(nativeAsset, wrappedAsset, amount)
+	if args.Get(2) == nil {
+		return args.Get(0).(*big.Int), args.Get(1).(*big.Int), nil
+	}
+	return nil, nil, args.Get(2).(error)
+}
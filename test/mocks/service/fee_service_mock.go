@@ -1,12 +1,16 @@
 package service
 
-import "github.com/stretchr/testify/mock"
+import (
+	"math/big"
+
+	"github.com/stretchr/testify/mock"
+)
 
 type MockFeeService struct {
 	mock.Mock
 }
 
-func (mfs *MockFeeService) CalculateFee(amount int64) (fee, remainder int64) {
-	args := mfs.Called(amount)
-	return args.Get(0).(int64), args.Get(1).(int64)
+func (mfs *MockFeeService) CalculateFee(nativeAsset, receiver string, amount *big.Int, timestamp int64) (fee, remainder *big.Int) {
+	args := mfs.Called(nativeAsset, receiver, amount, timestamp)
+	return args.Get(0).(*big.Int), args.Get(1).(*big.Int)
 }
@@ -0,0 +1,21 @@
+package service
+
+import "github.com/stretchr/testify/mock"
+
+type MockFeeSettlementService struct {
+	mock.Mock
+}
+
+func (mfs *MockFeeSettlementService) Enabled() bool {
+	args := mfs.Called()
+	return args.Get(0).(bool)
+}
+
+func (mfs *MockFeeSettlementService) Batch(timestamp int64) int64 {
+	args := mfs.Called(timestamp)
+	return args.Get(0).(int64)
+}
+
+func (mfs *MockFeeSettlementService) Start() {
+	mfs.Called()
+}
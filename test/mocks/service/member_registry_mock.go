@@ -0,0 +1,20 @@
+package service
+
+import (
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMemberRegistry struct {
+	mock.Mock
+}
+
+func (mmr *MockMemberRegistry) Signers() []string {
+	args := mmr.Called()
+	return args.Get(0).([]string)
+}
+
+func (mmr *MockMemberRegistry) Accounts() []hedera.AccountID {
+	args := mmr.Called()
+	return args.Get(0).([]hedera.AccountID)
+}
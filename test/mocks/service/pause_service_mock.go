@@ -0,0 +1,42 @@
+package service
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/message"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPauseService struct {
+	mock.Mock
+}
+
+func (mps *MockPauseService) Paused() bool {
+	args := mps.Called()
+	return args.Bool(0)
+}
+
+func (mps *MockPauseService) Queue(id, wrappedAsset string, operation func()) bool {
+	args := mps.Called(id, wrappedAsset, operation)
+	return args.Bool(0)
+}
+
+func (mps *MockPauseService) Submit(action, reason string, nonce int64) (int64, error) {
+	args := mps.Called(action, reason, nonce)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return 0, args.Get(1).(error)
+}
+
+func (mps *MockPauseService) ProcessControlMessage(msg message.Message) error {
+	args := mps.Called(msg)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mps *MockPauseService) Status() service.PauseStatus {
+	args := mps.Called()
+	return args.Get(0).(service.PauseStatus)
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/model/message"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPendingSignaturesService struct {
+	mock.Mock
+}
+
+func (mps *MockPendingSignaturesService) Add(tm message.Message) error {
+	args := mps.Called(tm)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mps *MockPendingSignaturesService) Release(transferID string) {
+	mps.Called(transferID)
+}
+
+func (mps *MockPendingSignaturesService) Subscribe(handler func(tm message.Message)) {
+	mps.Called(handler)
+}
+
+func (mps *MockPendingSignaturesService) Start() {
+	mps.Called()
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/persistence/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockQuorumService struct {
+	mock.Mock
+}
+
+func (mqs *MockQuorumService) Snapshot() ([]string, int) {
+	args := mqs.Called()
+	return args.Get(0).([]string), args.Int(1)
+}
+
+func (mqs *MockQuorumService) Reached(t *entity.Transfer, messages []entity.Message) (int, int, bool) {
+	args := mqs.Called(t, messages)
+	return args.Int(0), args.Int(1), args.Bool(2)
+}
+
+func (mqs *MockQuorumService) Eligible(t *entity.Transfer, messages []entity.Message) []entity.Message {
+	args := mqs.Called(t, messages)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]entity.Message)
+}
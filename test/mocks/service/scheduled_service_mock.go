@@ -13,3 +13,12 @@ func (mss *MockScheduledService) Execute(id, nativeAsset string, transfers []tra
 	// TODO: Find a way to mock these functions properly, without rewriting them once more in the unit test file.
 	mss.Called(id, nativeAsset, transfers)
 }
+
+func (mss *MockScheduledService) Await(transactionID string, onSuccess, onFail func(transactionID string)) {
+	mss.Called(transactionID)
+}
+
+func (mss *MockScheduledService) Find(memo string, from int64) (string, string, error) {
+	args := mss.Called(memo, from)
+	return args.String(0), args.String(1), args.Error(2)
+}
@@ -20,6 +20,22 @@ func (mts *MockTransferService) ProcessTransfer(tm transfer.Transfer) error {
 	return args.Get(0).(error)
 }
 
+func (mts *MockTransferService) ReprocessTransfer(txId string) error {
+	args := mts.Called(txId)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (mts *MockTransferService) ExecuteFee(txId string) error {
+	args := mts.Called(txId)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
 func (mts *MockTransferService) SanityCheckTransfer(tx mirror_node.Transaction) (string, error) {
 	args := mts.Called(tx)
 	if args.Get(0) == nil {
@@ -39,6 +55,14 @@ func (mts *MockTransferService) SaveRecoveredTxn(txId, amount, nativeAsset, wrap
 	return args.Get(0).(error)
 }
 
+func (mts *MockTransferService) HoldTransfer(tm transfer.Transfer, violation string) error {
+	args := mts.Called(tm, violation)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
 func (mts *MockTransferService) InitiateNewTransfer(tm transfer.Transfer) (*entity.Transfer, error) {
 	args := mts.Called(tm)
 	if args.Get(0) == nil {
@@ -58,3 +82,11 @@ func (mts *MockTransferService) TransferData(txId string) (service.TransferData,
 
 	return args.Get(0).(service.TransferData), args.Get(0).(error)
 }
+
+func (mts *MockTransferService) Timeline(txId string) ([]service.StatusTransition, error) {
+	args := mts.Called(txId)
+	if args.Get(1) == nil {
+		return args.Get(0).([]service.StatusTransition), nil
+	}
+	return nil, args.Get(1).(error)
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"github.com/limechain/hedera-eth-bridge-validator/app/domain/service"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhooksService struct {
+	mock.Mock
+}
+
+func (mws *MockWebhooksService) Enabled() bool {
+	args := mws.Called()
+	return args.Bool(0)
+}
+
+func (mws *MockWebhooksService) Notify(event string, data service.WebhookData) {
+	mws.Called(event, data)
+}
+
+func (mws *MockWebhooksService) Start() {
+	mws.Called()
+}
@@ -30,9 +30,30 @@ var MTransferService *service.MockTransferService
 var MDistributorService *service.MockDistrubutorService
 var MScheduledService *service.MockScheduledService
 var MFeeService *service.MockFeeService
+var MFeeSettlementService *service.MockFeeSettlementService
+var MDecimalsService *service.MockDecimalsService
+var MPauseService *service.MockPauseService
+var MPendingSignaturesService *service.MockPendingSignaturesService
+var MArchiveService *service.MockArchiveService
+var MWebhooksService *service.MockWebhooksService
+var MMemberRegistry *service.MockMemberRegistry
+var MQuorumService *service.MockQuorumService
 var MBridgeContractService *MockBridgeContract
 var MBurnEventRepository *repository.MockBurnEventRepository
 var MFeeRepository *repository.MockFeeRepository
+var MTransferRepository *repository.MockTransferRepository
+var MPendingMessageRepository *repository.MockPendingMessageRepository
+var MRejectedMessageRepository *repository.MockRejectedMessageRepository
+var MEquivocationRepository *repository.MockEquivocationRepository
+var MMessageRepository *repository.MockMessageRepository
+var MVolumeRepository *repository.MockVolumeRepository
+var MRefundRepository *repository.MockRefundRepository
+var MControlActionRepository *repository.MockControlActionRepository
+var MStatusRepository *repository.MockStatusRepository
+var MArchiveRepository *repository.MockArchiveRepository
+var MUnitOfWork *repository.MockUnitOfWork
+var MStatusTransitionRepository *repository.MockStatusTransitionRepository
+var MWebhookDeliveryRepository *repository.MockWebhookDeliveryRepository
 var MHederaMirrorClient *hedera_mirror_client.MockHederaMirrorClient
 var MHederaNodeClient *hedera_node_client.MockHederaNodeClient
 var MDatabase *database.MockDatabase
@@ -44,8 +65,39 @@ func Setup() {
 	MTransferService = &service.MockTransferService{}
 	MScheduledService = &service.MockScheduledService{}
 	MFeeService = &service.MockFeeService{}
+	MFeeSettlementService = &service.MockFeeSettlementService{}
+	MDecimalsService = &service.MockDecimalsService{}
+	MPauseService = &service.MockPauseService{}
+	MPendingSignaturesService = &service.MockPendingSignaturesService{}
+	MArchiveService = &service.MockArchiveService{}
+	MWebhooksService = &service.MockWebhooksService{}
+	MMemberRegistry = &service.MockMemberRegistry{}
+	MQuorumService = &service.MockQuorumService{}
 	MBurnEventRepository = &repository.MockBurnEventRepository{}
 	MFeeRepository = &repository.MockFeeRepository{}
+	MTransferRepository = &repository.MockTransferRepository{}
+	MPendingMessageRepository = &repository.MockPendingMessageRepository{}
+	MRejectedMessageRepository = &repository.MockRejectedMessageRepository{}
+	MEquivocationRepository = &repository.MockEquivocationRepository{}
+	MMessageRepository = &repository.MockMessageRepository{}
+	MVolumeRepository = &repository.MockVolumeRepository{}
+	MRefundRepository = &repository.MockRefundRepository{}
+	MControlActionRepository = &repository.MockControlActionRepository{}
+	MStatusRepository = &repository.MockStatusRepository{}
+	MArchiveRepository = &repository.MockArchiveRepository{}
+	MStatusTransitionRepository = &repository.MockStatusTransitionRepository{}
+	MWebhookDeliveryRepository = &repository.MockWebhookDeliveryRepository{}
+	MUnitOfWork = &repository.MockUnitOfWork{
+		Transaction: &repository.MockTransaction{
+			TransferRepository:        MTransferRepository,
+			MessageRepository:         MMessageRepository,
+			RejectedMessageRepository: MRejectedMessageRepository,
+			EquivocationRepository:    MEquivocationRepository,
+			FeeRepository:             MFeeRepository,
+			BurnEventRepository:       MBurnEventRepository,
+			RefundRepository:          MRefundRepository,
+		},
+	}
 	MDistributorService = &service.MockDistrubutorService{}
 	MHederaMirrorClient = &hedera_mirror_client.MockHederaMirrorClient{}
 	MHederaNodeClient = &hedera_node_client.MockHederaNodeClient{}